@@ -9,6 +9,7 @@ package api
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -32,8 +33,35 @@ type Card struct {
 	NextDueDate                     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=nextDueDate,proto3" json:"nextDueDate,omitempty"`
 	Learnt                          bool                   `protobuf:"varint,7,opt,name=learnt,proto3" json:"learnt,omitempty"`
 	LearntAt                        *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=learnt_at,json=learntAt,proto3,oneof" json:"learnt_at,omitempty"`
-	unknownFields                   protoimpl.UnknownFields
-	sizeCache                       protoimpl.SizeCache
+	LastReviewedAt                  *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=last_reviewed_at,json=lastReviewedAt,proto3,oneof" json:"last_reviewed_at,omitempty"`
+	MemoryState                     *MemoryState           `protobuf:"bytes,10,opt,name=memory_state,json=memoryState,proto3" json:"memory_state,omitempty"`
+	// set while the card is scheduled with the learning or relearning steps
+	LearningState *LearningState `protobuf:"bytes,11,opt,name=learning_state,json=learningState,proto3" json:"learning_state,omitempty"`
+	// the number of times the card has been forgotten after being scheduled
+	Lapses    uint32 `protobuf:"varint,12,opt,name=lapses,proto3" json:"lapses,omitempty"`
+	Leech     bool   `protobuf:"varint,13,opt,name=leech,proto3" json:"leech,omitempty"`
+	Suspended bool   `protobuf:"varint,14,opt,name=suspended,proto3" json:"suspended,omitempty"`
+	// the card is hidden until this moment, the start of the user's next day when it was buried
+	BuriedUntil *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=buried_until,json=buriedUntil,proto3,oneof" json:"buried_until,omitempty"`
+	// unset for the cards created before the timestamps have been tracked and not migrated yet
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=created_at,json=createdAt,proto3,oneof" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=updated_at,json=updatedAt,proto3,oneof" json:"updated_at,omitempty"`
+	// the time the card has been answered the first time, unset for the new cards
+	StartedLearningAt *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=started_learning_at,json=startedLearningAt,proto3,oneof" json:"started_learning_at,omitempty"`
+	Tags              []string               `protobuf:"bytes,19,rep,name=tags,proto3" json:"tags,omitempty"`
+	// empty if the card is in no deck
+	Deck string `protobuf:"bytes,20,opt,name=deck,proto3" json:"deck,omitempty"`
+	// one of words, phrase, cloze or grammar, the word cards hold wordInformationList, the others hold note
+	Type string `protobuf:"bytes,21,opt,name=type,proto3" json:"type,omitempty"`
+	Note *Note  `protobuf:"bytes,22,opt,name=note,proto3" json:"note,omitempty"`
+	// set on the cards to repeat only: the direction the card is due in, production, recognition or listening,
+	// the schedule of the card is the one of the direction
+	Direction string `protobuf:"bytes,23,opt,name=direction,proto3" json:"direction,omitempty"`
+	// incremented by every change of the card, UpdateCard fails with ABORTED if the card has been changed
+	// since the version it's based on
+	Version       uint64 `protobuf:"varint,24,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Card) Reset() {
@@ -122,112 +150,205 @@ func (x *Card) GetLearntAt() *timestamppb.Timestamp {
 	return nil
 }
 
-type WordInformation struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Word            string                 `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
-	Translation     *Translation           `protobuf:"bytes,2,opt,name=Translation,proto3" json:"Translation,omitempty"`
-	Origin          string                 `protobuf:"bytes,3,opt,name=origin,proto3" json:"origin,omitempty"`
-	Phonetics       []*Phonetic            `protobuf:"bytes,4,rep,name=phonetics,proto3" json:"phonetics,omitempty"`
-	Meanings        []*Meaning             `protobuf:"bytes,5,rep,name=meanings,proto3" json:"meanings,omitempty"`
-	AudioByLanguage map[string][]byte      `protobuf:"bytes,6,rep,name=audioByLanguage,proto3" json:"audioByLanguage,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *Card) GetLastReviewedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastReviewedAt
+	}
+	return nil
 }
 
-func (x *WordInformation) Reset() {
-	*x = WordInformation{}
-	mi := &file_api_lale_service_proto_msgTypes[1]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Card) GetMemoryState() *MemoryState {
+	if x != nil {
+		return x.MemoryState
+	}
+	return nil
 }
 
-func (x *WordInformation) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Card) GetLearningState() *LearningState {
+	if x != nil {
+		return x.LearningState
+	}
+	return nil
 }
 
-func (*WordInformation) ProtoMessage() {}
+func (x *Card) GetLapses() uint32 {
+	if x != nil {
+		return x.Lapses
+	}
+	return 0
+}
 
-func (x *WordInformation) ProtoReflect() protoreflect.Message {
-	mi := &file_api_lale_service_proto_msgTypes[1]
+func (x *Card) GetLeech() bool {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Leech
 	}
-	return mi.MessageOf(x)
+	return false
 }
 
-// Deprecated: Use WordInformation.ProtoReflect.Descriptor instead.
-func (*WordInformation) Descriptor() ([]byte, []int) {
-	return file_api_lale_service_proto_rawDescGZIP(), []int{1}
+func (x *Card) GetSuspended() bool {
+	if x != nil {
+		return x.Suspended
+	}
+	return false
 }
 
-func (x *WordInformation) GetWord() string {
+func (x *Card) GetBuriedUntil() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Word
+		return x.BuriedUntil
 	}
-	return ""
+	return nil
 }
 
-func (x *WordInformation) GetTranslation() *Translation {
+func (x *Card) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Translation
+		return x.CreatedAt
 	}
 	return nil
 }
 
-func (x *WordInformation) GetOrigin() string {
+func (x *Card) GetUpdatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Origin
+		return x.UpdatedAt
 	}
-	return ""
+	return nil
 }
 
-func (x *WordInformation) GetPhonetics() []*Phonetic {
+func (x *Card) GetStartedLearningAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Phonetics
+		return x.StartedLearningAt
 	}
 	return nil
 }
 
-func (x *WordInformation) GetMeanings() []*Meaning {
+func (x *Card) GetTags() []string {
 	if x != nil {
-		return x.Meanings
+		return x.Tags
 	}
 	return nil
 }
 
-func (x *WordInformation) GetAudioByLanguage() map[string][]byte {
+func (x *Card) GetDeck() string {
+	if x != nil {
+		return x.Deck
+	}
+	return ""
+}
+
+func (x *Card) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Card) GetNote() *Note {
 	if x != nil {
-		return x.AudioByLanguage
+		return x.Note
 	}
 	return nil
 }
 
-type Translation struct {
+func (x *Card) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *Card) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// content of the phrase, cloze and grammar cards
+type Note struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the phrase, the cloze sentence with the deleted parts in double braces or the grammar prompt
+	Text string `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	// the meaning of the phrase or the answer to the grammar prompt, empty for the cloze cards
+	Answer string `protobuf:"bytes,2,opt,name=answer,proto3" json:"answer,omitempty"`
+	// shown once the card has been answered
+	Explanation   string `protobuf:"bytes,3,opt,name=explanation,proto3" json:"explanation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Note) Reset() {
+	*x = Note{}
+	mi := &file_api_lale_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Note) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Note) ProtoMessage() {}
+
+func (x *Note) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Note.ProtoReflect.Descriptor instead.
+func (*Note) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Note) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Note) GetAnswer() string {
+	if x != nil {
+		return x.Answer
+	}
+	return ""
+}
+
+func (x *Note) GetExplanation() string {
+	if x != nil {
+		return x.Explanation
+	}
+	return ""
+}
+
+// narrows the cards down to the ones in the deck having all the tags, unset fields match every card
+type CardFilter struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Language      string                 `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
-	Translations  []string               `protobuf:"bytes,2,rep,name=Translations,proto3" json:"Translations,omitempty"`
+	Tags          []string               `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	Deck          string                 `protobuf:"bytes,2,opt,name=deck,proto3" json:"deck,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Translation) Reset() {
-	*x = Translation{}
+func (x *CardFilter) Reset() {
+	*x = CardFilter{}
 	mi := &file_api_lale_service_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Translation) String() string {
+func (x *CardFilter) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Translation) ProtoMessage() {}
+func (*CardFilter) ProtoMessage() {}
 
-func (x *Translation) ProtoReflect() protoreflect.Message {
+func (x *CardFilter) ProtoReflect() protoreflect.Message {
 	mi := &file_api_lale_service_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -239,46 +360,47 @@ func (x *Translation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Translation.ProtoReflect.Descriptor instead.
-func (*Translation) Descriptor() ([]byte, []int) {
+// Deprecated: Use CardFilter.ProtoReflect.Descriptor instead.
+func (*CardFilter) Descriptor() ([]byte, []int) {
 	return file_api_lale_service_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *Translation) GetLanguage() string {
+func (x *CardFilter) GetTags() []string {
 	if x != nil {
-		return x.Language
+		return x.Tags
 	}
-	return ""
+	return nil
 }
 
-func (x *Translation) GetTranslations() []string {
+func (x *CardFilter) GetDeck() string {
 	if x != nil {
-		return x.Translations
+		return x.Deck
 	}
-	return nil
+	return ""
 }
 
-type Phonetic struct {
+type MemoryState struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Stability     float64                `protobuf:"fixed64,1,opt,name=stability,proto3" json:"stability,omitempty"`
+	Difficulty    float64                `protobuf:"fixed64,2,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Phonetic) Reset() {
-	*x = Phonetic{}
+func (x *MemoryState) Reset() {
+	*x = MemoryState{}
 	mi := &file_api_lale_service_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Phonetic) String() string {
+func (x *MemoryState) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Phonetic) ProtoMessage() {}
+func (*MemoryState) ProtoMessage() {}
 
-func (x *Phonetic) ProtoReflect() protoreflect.Message {
+func (x *MemoryState) ProtoReflect() protoreflect.Message {
 	mi := &file_api_lale_service_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -290,40 +412,49 @@ func (x *Phonetic) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Phonetic.ProtoReflect.Descriptor instead.
-func (*Phonetic) Descriptor() ([]byte, []int) {
+// Deprecated: Use MemoryState.ProtoReflect.Descriptor instead.
+func (*MemoryState) Descriptor() ([]byte, []int) {
 	return file_api_lale_service_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *Phonetic) GetText() string {
+func (x *MemoryState) GetStability() float64 {
 	if x != nil {
-		return x.Text
+		return x.Stability
 	}
-	return ""
+	return 0
 }
 
-type Meaning struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PartOfSpeech  string                 `protobuf:"bytes,1,opt,name=partOfSpeech,proto3" json:"partOfSpeech,omitempty"`
-	Definitions   []*Definition          `protobuf:"bytes,2,rep,name=Definitions,proto3" json:"Definitions,omitempty"`
+func (x *MemoryState) GetDifficulty() float64 {
+	if x != nil {
+		return x.Difficulty
+	}
+	return 0
+}
+
+type LearningState struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// learning or relearning
+	Phase string `protobuf:"bytes,1,opt,name=phase,proto3" json:"phase,omitempty"`
+	// the index of the step the card is scheduled with
+	Step          uint32 `protobuf:"varint,2,opt,name=step,proto3" json:"step,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Meaning) Reset() {
-	*x = Meaning{}
+func (x *LearningState) Reset() {
+	*x = LearningState{}
 	mi := &file_api_lale_service_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Meaning) String() string {
+func (x *LearningState) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Meaning) ProtoMessage() {}
+func (*LearningState) ProtoMessage() {}
 
-func (x *Meaning) ProtoReflect() protoreflect.Message {
+func (x *LearningState) ProtoReflect() protoreflect.Message {
 	mi := &file_api_lale_service_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -335,49 +466,56 @@ func (x *Meaning) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Meaning.ProtoReflect.Descriptor instead.
-func (*Meaning) Descriptor() ([]byte, []int) {
+// Deprecated: Use LearningState.ProtoReflect.Descriptor instead.
+func (*LearningState) Descriptor() ([]byte, []int) {
 	return file_api_lale_service_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *Meaning) GetPartOfSpeech() string {
+func (x *LearningState) GetPhase() string {
 	if x != nil {
-		return x.PartOfSpeech
+		return x.Phase
 	}
 	return ""
 }
 
-func (x *Meaning) GetDefinitions() []*Definition {
+func (x *LearningState) GetStep() uint32 {
 	if x != nil {
-		return x.Definitions
+		return x.Step
 	}
-	return nil
+	return 0
 }
 
-type Definition struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Definition    string                 `protobuf:"bytes,1,opt,name=definition,proto3" json:"definition,omitempty"`
-	Example       string                 `protobuf:"bytes,2,opt,name=example,proto3" json:"example,omitempty"`
-	Synonyms      []string               `protobuf:"bytes,3,rep,name=synonyms,proto3" json:"synonyms,omitempty"`
-	Antonyms      []string               `protobuf:"bytes,4,rep,name=antonyms,proto3" json:"antonyms,omitempty"`
+type WordInformation struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Word        string                 `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	Translation *Translation           `protobuf:"bytes,2,opt,name=Translation,proto3" json:"Translation,omitempty"`
+	Origin      string                 `protobuf:"bytes,3,opt,name=origin,proto3" json:"origin,omitempty"`
+	Phonetics   []*Phonetic            `protobuf:"bytes,4,rep,name=phonetics,proto3" json:"phonetics,omitempty"`
+	Meanings    []*Meaning             `protobuf:"bytes,5,rep,name=meanings,proto3" json:"meanings,omitempty"`
+	// user's own notes on the word, e.g. a mnemonic
+	Notes string `protobuf:"bytes,7,opt,name=notes,proto3" json:"notes,omitempty"`
+	// ID of the image returned by UploadImage, empty if the word has no image
+	ImageID string `protobuf:"bytes,8,opt,name=imageID,proto3" json:"imageID,omitempty"`
+	// IDs of the word's audio by the voice language, e.g. en-GB, the audio is fetched with GetAudio
+	AudioIDs      map[string]string `protobuf:"bytes,9,rep,name=audioIDs,proto3" json:"audioIDs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Definition) Reset() {
-	*x = Definition{}
+func (x *WordInformation) Reset() {
+	*x = WordInformation{}
 	mi := &file_api_lale_service_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Definition) String() string {
+func (x *WordInformation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Definition) ProtoMessage() {}
+func (*WordInformation) ProtoMessage() {}
 
-func (x *Definition) ProtoReflect() protoreflect.Message {
+func (x *WordInformation) ProtoReflect() protoreflect.Message {
 	mi := &file_api_lale_service_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -389,61 +527,89 @@ func (x *Definition) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Definition.ProtoReflect.Descriptor instead.
-func (*Definition) Descriptor() ([]byte, []int) {
+// Deprecated: Use WordInformation.ProtoReflect.Descriptor instead.
+func (*WordInformation) Descriptor() ([]byte, []int) {
 	return file_api_lale_service_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *Definition) GetDefinition() string {
+func (x *WordInformation) GetWord() string {
 	if x != nil {
-		return x.Definition
+		return x.Word
 	}
 	return ""
 }
 
-func (x *Definition) GetExample() string {
+func (x *WordInformation) GetTranslation() *Translation {
 	if x != nil {
-		return x.Example
+		return x.Translation
+	}
+	return nil
+}
+
+func (x *WordInformation) GetOrigin() string {
+	if x != nil {
+		return x.Origin
 	}
 	return ""
 }
 
-func (x *Definition) GetSynonyms() []string {
+func (x *WordInformation) GetPhonetics() []*Phonetic {
 	if x != nil {
-		return x.Synonyms
+		return x.Phonetics
 	}
 	return nil
 }
 
-func (x *Definition) GetAntonyms() []string {
+func (x *WordInformation) GetMeanings() []*Meaning {
 	if x != nil {
-		return x.Antonyms
+		return x.Meanings
 	}
 	return nil
 }
 
-type GetCardsRequest struct {
+func (x *WordInformation) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *WordInformation) GetImageID() string {
+	if x != nil {
+		return x.ImageID
+	}
+	return ""
+}
+
+func (x *WordInformation) GetAudioIDs() map[string]string {
+	if x != nil {
+		return x.AudioIDs
+	}
+	return nil
+}
+
+type Translation struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserID        string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
-	Language      string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	Language      string                 `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
+	Translations  []string               `protobuf:"bytes,2,rep,name=Translations,proto3" json:"Translations,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCardsRequest) Reset() {
-	*x = GetCardsRequest{}
+func (x *Translation) Reset() {
+	*x = Translation{}
 	mi := &file_api_lale_service_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCardsRequest) String() string {
+func (x *Translation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCardsRequest) ProtoMessage() {}
+func (*Translation) ProtoMessage() {}
 
-func (x *GetCardsRequest) ProtoReflect() protoreflect.Message {
+func (x *Translation) ProtoReflect() protoreflect.Message {
 	mi := &file_api_lale_service_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -455,49 +621,1836 @@ func (x *GetCardsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCardsRequest.ProtoReflect.Descriptor instead.
-func (*GetCardsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Translation.ProtoReflect.Descriptor instead.
+func (*Translation) Descriptor() ([]byte, []int) {
 	return file_api_lale_service_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetCardsRequest) GetUserID() string {
+func (x *Translation) GetLanguage() string {
 	if x != nil {
-		return x.UserID
+		return x.Language
 	}
 	return ""
 }
 
-func (x *GetCardsRequest) GetLanguage() string {
+func (x *Translation) GetTranslations() []string {
+	if x != nil {
+		return x.Translations
+	}
+	return nil
+}
+
+type Phonetic struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Phonetic) Reset() {
+	*x = Phonetic{}
+	mi := &file_api_lale_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Phonetic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Phonetic) ProtoMessage() {}
+
+func (x *Phonetic) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Phonetic.ProtoReflect.Descriptor instead.
+func (*Phonetic) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Phonetic) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type Meaning struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PartOfSpeech  string                 `protobuf:"bytes,1,opt,name=partOfSpeech,proto3" json:"partOfSpeech,omitempty"`
+	Definitions   []*Definition          `protobuf:"bytes,2,rep,name=Definitions,proto3" json:"Definitions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Meaning) Reset() {
+	*x = Meaning{}
+	mi := &file_api_lale_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Meaning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Meaning) ProtoMessage() {}
+
+func (x *Meaning) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Meaning.ProtoReflect.Descriptor instead.
+func (*Meaning) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Meaning) GetPartOfSpeech() string {
+	if x != nil {
+		return x.PartOfSpeech
+	}
+	return ""
+}
+
+func (x *Meaning) GetDefinitions() []*Definition {
+	if x != nil {
+		return x.Definitions
+	}
+	return nil
+}
+
+type Definition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Definition    string                 `protobuf:"bytes,1,opt,name=definition,proto3" json:"definition,omitempty"`
+	Example       string                 `protobuf:"bytes,2,opt,name=example,proto3" json:"example,omitempty"`
+	Synonyms      []string               `protobuf:"bytes,3,rep,name=synonyms,proto3" json:"synonyms,omitempty"`
+	Antonyms      []string               `protobuf:"bytes,4,rep,name=antonyms,proto3" json:"antonyms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Definition) Reset() {
+	*x = Definition{}
+	mi := &file_api_lale_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Definition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Definition) ProtoMessage() {}
+
+func (x *Definition) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Definition.ProtoReflect.Descriptor instead.
+func (*Definition) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Definition) GetDefinition() string {
+	if x != nil {
+		return x.Definition
+	}
+	return ""
+}
+
+func (x *Definition) GetExample() string {
+	if x != nil {
+		return x.Example
+	}
+	return ""
+}
+
+func (x *Definition) GetSynonyms() []string {
+	if x != nil {
+		return x.Synonyms
+	}
+	return nil
+}
+
+func (x *Definition) GetAntonyms() []string {
+	if x != nil {
+		return x.Antonyms
+	}
+	return nil
+}
+
+type GetCardsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserID        string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	Language      string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	Filter        *CardFilter            `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCardsRequest) Reset() {
+	*x = GetCardsRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCardsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCardsRequest) ProtoMessage() {}
+
+func (x *GetCardsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCardsRequest.ProtoReflect.Descriptor instead.
+func (*GetCardsRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetCardsRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *GetCardsRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *GetCardsRequest) GetFilter() *CardFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type GetCardsForCramRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserID string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	// empty selects the cards in all languages
+	Language string `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	// narrow the cards down to a subset, empty selects all the cards
+	CardIDs []string `protobuf:"bytes,3,rep,name=cardIDs,proto3" json:"cardIDs,omitempty"`
+	// hardest, recent or random, empty is random
+	Order string `protobuf:"bytes,4,opt,name=order,proto3" json:"order,omitempty"`
+	// caps the number of cards returned, zero returns all of them
+	Limit         uint32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCardsForCramRequest) Reset() {
+	*x = GetCardsForCramRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCardsForCramRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCardsForCramRequest) ProtoMessage() {}
+
+func (x *GetCardsForCramRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCardsForCramRequest.ProtoReflect.Descriptor instead.
+func (*GetCardsForCramRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetCardsForCramRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *GetCardsForCramRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *GetCardsForCramRequest) GetCardIDs() []string {
+	if x != nil {
+		return x.CardIDs
+	}
+	return nil
+}
+
+func (x *GetCardsForCramRequest) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *GetCardsForCramRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type CreateCardRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	UserID              string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	Language            string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	WordInformationList []*WordInformation     `protobuf:"bytes,3,rep,name=wordInformationList,proto3" json:"wordInformationList,omitempty"`
+	// empty creates a word card
+	Type          string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Note          *Note  `protobuf:"bytes,5,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCardRequest) Reset() {
+	*x = CreateCardRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCardRequest) ProtoMessage() {}
+
+func (x *CreateCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCardRequest.ProtoReflect.Descriptor instead.
+func (*CreateCardRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateCardRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *CreateCardRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *CreateCardRequest) GetWordInformationList() []*WordInformation {
+	if x != nil {
+		return x.WordInformationList
+	}
+	return nil
+}
+
+func (x *CreateCardRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreateCardRequest) GetNote() *Note {
+	if x != nil {
+		return x.Note
+	}
+	return nil
+}
+
+type UpdateCardRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	UserID              string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	CardID              string                 `protobuf:"bytes,2,opt,name=cardID,proto3" json:"cardID,omitempty"`
+	WordInformationList []*WordInformation     `protobuf:"bytes,4,rep,name=wordInformationList,proto3" json:"wordInformationList,omitempty"`
+	// empty rewrites the card to a word card
+	Type string `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	Note *Note  `protobuf:"bytes,6,opt,name=note,proto3" json:"note,omitempty"`
+	// version of the card the update is based on, 0 overwrites the card whatever its version
+	Version       uint64 `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCardRequest) Reset() {
+	*x = UpdateCardRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCardRequest) ProtoMessage() {}
+
+func (x *UpdateCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCardRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCardRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateCardRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *UpdateCardRequest) GetCardID() string {
+	if x != nil {
+		return x.CardID
+	}
+	return ""
+}
+
+func (x *UpdateCardRequest) GetWordInformationList() []*WordInformation {
+	if x != nil {
+		return x.WordInformationList
+	}
+	return nil
+}
+
+func (x *UpdateCardRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *UpdateCardRequest) GetNote() *Note {
+	if x != nil {
+		return x.Note
+	}
+	return nil
+}
+
+func (x *UpdateCardRequest) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type InspectCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserID        string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	Language      string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	Word          string                 `protobuf:"bytes,3,opt,name=word,proto3" json:"word,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InspectCardRequest) Reset() {
+	*x = InspectCardRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InspectCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InspectCardRequest) ProtoMessage() {}
+
+func (x *InspectCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InspectCardRequest.ProtoReflect.Descriptor instead.
+func (*InspectCardRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *InspectCardRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *InspectCardRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *InspectCardRequest) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+type PromptCardRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	UserID              string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	Word                string                 `protobuf:"bytes,2,opt,name=word,proto3" json:"word,omitempty"`
+	WordLanguage        string                 `protobuf:"bytes,3,opt,name=word_language,json=wordLanguage,proto3" json:"word_language,omitempty"`
+	TranslationLanguage string                 `protobuf:"bytes,4,opt,name=translation_language,json=translationLanguage,proto3" json:"translation_language,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *PromptCardRequest) Reset() {
+	*x = PromptCardRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptCardRequest) ProtoMessage() {}
+
+func (x *PromptCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptCardRequest.ProtoReflect.Descriptor instead.
+func (*PromptCardRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PromptCardRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *PromptCardRequest) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *PromptCardRequest) GetWordLanguage() string {
+	if x != nil {
+		return x.WordLanguage
+	}
+	return ""
+}
+
+func (x *PromptCardRequest) GetTranslationLanguage() string {
+	if x != nil {
+		return x.TranslationLanguage
+	}
+	return ""
+}
+
+type PromptCardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Words         []string               `protobuf:"bytes,1,rep,name=words,proto3" json:"words,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptCardResponse) Reset() {
+	*x = PromptCardResponse{}
+	mi := &file_api_lale_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptCardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptCardResponse) ProtoMessage() {}
+
+func (x *PromptCardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptCardResponse.ProtoReflect.Descriptor instead.
+func (*PromptCardResponse) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PromptCardResponse) GetWords() []string {
+	if x != nil {
+		return x.Words
+	}
+	return nil
+}
+
+type GetCardsResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserID   string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	Language string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	Cards    []*Card                `protobuf:"bytes,3,rep,name=cards,proto3" json:"cards,omitempty"`
+	// the number of cards the daily limit still allows to study today, the returned cards included
+	RemainingToday uint32 `protobuf:"varint,4,opt,name=remainingToday,proto3" json:"remainingToday,omitempty"`
+	// the number of cards held back until tomorrow by the daily limit
+	Postponed     uint32 `protobuf:"varint,5,opt,name=postponed,proto3" json:"postponed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCardsResponse) Reset() {
+	*x = GetCardsResponse{}
+	mi := &file_api_lale_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCardsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCardsResponse) ProtoMessage() {}
+
+func (x *GetCardsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCardsResponse.ProtoReflect.Descriptor instead.
+func (*GetCardsResponse) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetCardsResponse) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *GetCardsResponse) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *GetCardsResponse) GetCards() []*Card {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+func (x *GetCardsResponse) GetRemainingToday() uint32 {
+	if x != nil {
+		return x.RemainingToday
+	}
+	return 0
+}
+
+func (x *GetCardsResponse) GetPostponed() uint32 {
+	if x != nil {
+		return x.Postponed
+	}
+	return 0
+}
+
+type UpdateCardPerformanceRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserID string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	CardID string                 `protobuf:"bytes,2,opt,name=cardID,proto3" json:"cardID,omitempty"`
+	// the rating of the answer, required, the requests of the clients sending is_input_correct don't have it
+	Performance  *uint32              `protobuf:"varint,4,opt,name=performance,proto3,oneof" json:"performance,omitempty"`
+	TimeToAnswer *durationpb.Duration `protobuf:"bytes,5,opt,name=timeToAnswer,proto3" json:"timeToAnswer,omitempty"`
+	// the direction the card has been answered in, production if empty
+	Direction     string `protobuf:"bytes,6,opt,name=direction,proto3" json:"direction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCardPerformanceRequest) Reset() {
+	*x = UpdateCardPerformanceRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCardPerformanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCardPerformanceRequest) ProtoMessage() {}
+
+func (x *UpdateCardPerformanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCardPerformanceRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCardPerformanceRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UpdateCardPerformanceRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *UpdateCardPerformanceRequest) GetCardID() string {
+	if x != nil {
+		return x.CardID
+	}
+	return ""
+}
+
+func (x *UpdateCardPerformanceRequest) GetPerformance() uint32 {
+	if x != nil && x.Performance != nil {
+		return *x.Performance
+	}
+	return 0
+}
+
+func (x *UpdateCardPerformanceRequest) GetTimeToAnswer() *durationpb.Duration {
+	if x != nil {
+		return x.TimeToAnswer
+	}
+	return nil
+}
+
+func (x *UpdateCardPerformanceRequest) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+type UpdateCardPerformanceResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	NextDueDate *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=nextDueDate,proto3" json:"nextDueDate,omitempty"`
+	// set if the answer has made the card learnt by the user's graduation policy
+	Graduated     bool `protobuf:"varint,2,opt,name=graduated,proto3" json:"graduated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCardPerformanceResponse) Reset() {
+	*x = UpdateCardPerformanceResponse{}
+	mi := &file_api_lale_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCardPerformanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCardPerformanceResponse) ProtoMessage() {}
+
+func (x *UpdateCardPerformanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCardPerformanceResponse.ProtoReflect.Descriptor instead.
+func (*UpdateCardPerformanceResponse) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateCardPerformanceResponse) GetNextDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextDueDate
+	}
+	return nil
+}
+
+func (x *UpdateCardPerformanceResponse) GetGraduated() bool {
+	if x != nil {
+		return x.Graduated
+	}
+	return false
+}
+
+type GetSentencesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserID         string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	Word           string                 `protobuf:"bytes,2,opt,name=word,proto3" json:"word,omitempty"`
+	SentencesCount uint32                 `protobuf:"varint,3,opt,name=sentencesCount,proto3" json:"sentencesCount,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetSentencesRequest) Reset() {
+	*x = GetSentencesRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSentencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSentencesRequest) ProtoMessage() {}
+
+func (x *GetSentencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSentencesRequest.ProtoReflect.Descriptor instead.
+func (*GetSentencesRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetSentencesRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *GetSentencesRequest) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *GetSentencesRequest) GetSentencesCount() uint32 {
+	if x != nil {
+		return x.SentencesCount
+	}
+	return 0
+}
+
+type GetSentencesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sentences     []string               `protobuf:"bytes,1,rep,name=sentences,proto3" json:"sentences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSentencesResponse) Reset() {
+	*x = GetSentencesResponse{}
+	mi := &file_api_lale_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSentencesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSentencesResponse) ProtoMessage() {}
+
+func (x *GetSentencesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSentencesResponse.ProtoReflect.Descriptor instead.
+func (*GetSentencesResponse) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetSentencesResponse) GetSentences() []string {
+	if x != nil {
+		return x.Sentences
+	}
+	return nil
+}
+
+type GenerateStoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserID        string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	Language      string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	Filter        *CardFilter            `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateStoryRequest) Reset() {
+	*x = GenerateStoryRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateStoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateStoryRequest) ProtoMessage() {}
+
+func (x *GenerateStoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateStoryRequest.ProtoReflect.Descriptor instead.
+func (*GenerateStoryRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GenerateStoryRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *GenerateStoryRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *GenerateStoryRequest) GetFilter() *CardFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type GenerateStoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Story         string                 `protobuf:"bytes,1,opt,name=story,proto3" json:"story,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateStoryResponse) Reset() {
+	*x = GenerateStoryResponse{}
+	mi := &file_api_lale_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateStoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateStoryResponse) ProtoMessage() {}
+
+func (x *GenerateStoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateStoryResponse.ProtoReflect.Descriptor instead.
+func (*GenerateStoryResponse) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GenerateStoryResponse) GetStory() string {
+	if x != nil {
+		return x.Story
+	}
+	return ""
+}
+
+type GetCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserID        string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	CardID        string                 `protobuf:"bytes,2,opt,name=cardID,proto3" json:"cardID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCardRequest) Reset() {
+	*x = GetCardRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCardRequest) ProtoMessage() {}
+
+func (x *GetCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCardRequest.ProtoReflect.Descriptor instead.
+func (*GetCardRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetCardRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *GetCardRequest) GetCardID() string {
+	if x != nil {
+		return x.CardID
+	}
+	return ""
+}
+
+type DeleteCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserID        string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	CardID        string                 `protobuf:"bytes,2,opt,name=cardID,proto3" json:"cardID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCardRequest) Reset() {
+	*x = DeleteCardRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCardRequest) ProtoMessage() {}
+
+func (x *DeleteCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCardRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCardRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DeleteCardRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *DeleteCardRequest) GetCardID() string {
+	if x != nil {
+		return x.CardID
+	}
+	return ""
+}
+
+type MarkCardLearntRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserID        string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	CardID        string                 `protobuf:"bytes,2,opt,name=cardID,proto3" json:"cardID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkCardLearntRequest) Reset() {
+	*x = MarkCardLearntRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkCardLearntRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkCardLearntRequest) ProtoMessage() {}
+
+func (x *MarkCardLearntRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkCardLearntRequest.ProtoReflect.Descriptor instead.
+func (*MarkCardLearntRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *MarkCardLearntRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *MarkCardLearntRequest) GetCardID() string {
+	if x != nil {
+		return x.CardID
+	}
+	return ""
+}
+
+type ResetCardRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserID string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	CardID string                 `protobuf:"bytes,2,opt,name=cardID,proto3" json:"cardID,omitempty"`
+	// relearn makes the card due for the repetition today, forget makes it new to learn from scratch
+	Mode          string `protobuf:"bytes,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetCardRequest) Reset() {
+	*x = ResetCardRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetCardRequest) ProtoMessage() {}
+
+func (x *ResetCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetCardRequest.ProtoReflect.Descriptor instead.
+func (*ResetCardRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ResetCardRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *ResetCardRequest) GetCardID() string {
+	if x != nil {
+		return x.CardID
+	}
+	return ""
+}
+
+func (x *ResetCardRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+type SuspendCardRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserID string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	CardID string                 `protobuf:"bytes,2,opt,name=cardID,proto3" json:"cardID,omitempty"`
+	// suspends the card if set, resumes it otherwise
+	Suspended     bool `protobuf:"varint,3,opt,name=suspended,proto3" json:"suspended,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuspendCardRequest) Reset() {
+	*x = SuspendCardRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuspendCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuspendCardRequest) ProtoMessage() {}
+
+func (x *SuspendCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuspendCardRequest.ProtoReflect.Descriptor instead.
+func (*SuspendCardRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SuspendCardRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *SuspendCardRequest) GetCardID() string {
+	if x != nil {
+		return x.CardID
+	}
+	return ""
+}
+
+func (x *SuspendCardRequest) GetSuspended() bool {
+	if x != nil {
+		return x.Suspended
+	}
+	return false
+}
+
+type BuryCardRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserID string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	CardID string                 `protobuf:"bytes,2,opt,name=cardID,proto3" json:"cardID,omitempty"`
+	// hides the card until the start of the user's next day if set, shows it again otherwise
+	Buried        bool `protobuf:"varint,3,opt,name=buried,proto3" json:"buried,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuryCardRequest) Reset() {
+	*x = BuryCardRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuryCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuryCardRequest) ProtoMessage() {}
+
+func (x *BuryCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuryCardRequest.ProtoReflect.Descriptor instead.
+func (*BuryCardRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *BuryCardRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *BuryCardRequest) GetCardID() string {
+	if x != nil {
+		return x.CardID
+	}
+	return ""
+}
+
+func (x *BuryCardRequest) GetBuried() bool {
+	if x != nil {
+		return x.Buried
+	}
+	return false
+}
+
+type UpdateCardTagsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserID string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	CardID string                 `protobuf:"bytes,2,opt,name=cardID,proto3" json:"cardID,omitempty"`
+	// the tags must not contain spaces
+	Add           []string `protobuf:"bytes,3,rep,name=add,proto3" json:"add,omitempty"`
+	Remove        []string `protobuf:"bytes,4,rep,name=remove,proto3" json:"remove,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCardTagsRequest) Reset() {
+	*x = UpdateCardTagsRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCardTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCardTagsRequest) ProtoMessage() {}
+
+func (x *UpdateCardTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCardTagsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCardTagsRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *UpdateCardTagsRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *UpdateCardTagsRequest) GetCardID() string {
+	if x != nil {
+		return x.CardID
+	}
+	return ""
+}
+
+func (x *UpdateCardTagsRequest) GetAdd() []string {
+	if x != nil {
+		return x.Add
+	}
+	return nil
+}
+
+func (x *UpdateCardTagsRequest) GetRemove() []string {
+	if x != nil {
+		return x.Remove
+	}
+	return nil
+}
+
+type SetCardDeckRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserID string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	CardID string                 `protobuf:"bytes,2,opt,name=cardID,proto3" json:"cardID,omitempty"`
+	// empty removes the card from its deck
+	Deck          string `protobuf:"bytes,3,opt,name=deck,proto3" json:"deck,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCardDeckRequest) Reset() {
+	*x = SetCardDeckRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCardDeckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCardDeckRequest) ProtoMessage() {}
+
+func (x *SetCardDeckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCardDeckRequest.ProtoReflect.Descriptor instead.
+func (*SetCardDeckRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SetCardDeckRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *SetCardDeckRequest) GetCardID() string {
+	if x != nil {
+		return x.CardID
+	}
+	return ""
+}
+
+func (x *SetCardDeckRequest) GetDeck() string {
+	if x != nil {
+		return x.Deck
+	}
+	return ""
+}
+
+type GetDecksRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserID string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	// empty selects the decks in all languages
+	Language      string `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDecksRequest) Reset() {
+	*x = GetDecksRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDecksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDecksRequest) ProtoMessage() {}
+
+func (x *GetDecksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDecksRequest.ProtoReflect.Descriptor instead.
+func (*GetDecksRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetDecksRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *GetDecksRequest) GetLanguage() string {
 	if x != nil {
 		return x.Language
 	}
 	return ""
 }
 
-type CreateCardRequest struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	UserID              string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
-	Language            string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
-	WordInformationList []*WordInformation     `protobuf:"bytes,3,rep,name=wordInformationList,proto3" json:"wordInformationList,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+type GetDecksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserID        string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	Decks         []*Deck                `protobuf:"bytes,2,rep,name=decks,proto3" json:"decks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDecksResponse) Reset() {
+	*x = GetDecksResponse{}
+	mi := &file_api_lale_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDecksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDecksResponse) ProtoMessage() {}
+
+func (x *GetDecksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDecksResponse.ProtoReflect.Descriptor instead.
+func (*GetDecksResponse) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetDecksResponse) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *GetDecksResponse) GetDecks() []*Deck {
+	if x != nil {
+		return x.Decks
+	}
+	return nil
+}
+
+type Deck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Cards         uint32                 `protobuf:"varint,2,opt,name=cards,proto3" json:"cards,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Deck) Reset() {
+	*x = Deck{}
+	mi := &file_api_lale_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Deck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Deck) ProtoMessage() {}
+
+func (x *Deck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Deck.ProtoReflect.Descriptor instead.
+func (*Deck) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Deck) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Deck) GetCards() uint32 {
+	if x != nil {
+		return x.Cards
+	}
+	return 0
+}
+
+type UploadImageRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserID string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	// image of up to 3MB, e.g. png, jpeg, gif or webp
+	Data          []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadImageRequest) Reset() {
+	*x = UploadImageRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadImageRequest) ProtoMessage() {}
+
+func (x *UploadImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadImageRequest.ProtoReflect.Descriptor instead.
+func (*UploadImageRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *UploadImageRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *UploadImageRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type GetImageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserID        string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	ImageID       string                 `protobuf:"bytes,2,opt,name=imageID,proto3" json:"imageID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetImageRequest) Reset() {
+	*x = GetImageRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetImageRequest) ProtoMessage() {}
+
+func (x *GetImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetImageRequest.ProtoReflect.Descriptor instead.
+func (*GetImageRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetImageRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *GetImageRequest) GetImageID() string {
+	if x != nil {
+		return x.ImageID
+	}
+	return ""
 }
 
-func (x *CreateCardRequest) Reset() {
-	*x = CreateCardRequest{}
-	mi := &file_api_lale_service_proto_msgTypes[7]
+type Image struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Image) Reset() {
+	*x = Image{}
+	mi := &file_api_lale_service_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateCardRequest) String() string {
+func (x *Image) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateCardRequest) ProtoMessage() {}
+func (*Image) ProtoMessage() {}
 
-func (x *CreateCardRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_lale_service_proto_msgTypes[7]
+func (x *Image) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -508,56 +2461,55 @@ func (x *CreateCardRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateCardRequest.ProtoReflect.Descriptor instead.
-func (*CreateCardRequest) Descriptor() ([]byte, []int) {
-	return file_api_lale_service_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use Image.ProtoReflect.Descriptor instead.
+func (*Image) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *CreateCardRequest) GetUserID() string {
+func (x *Image) GetId() string {
 	if x != nil {
-		return x.UserID
+		return x.Id
 	}
 	return ""
 }
 
-func (x *CreateCardRequest) GetLanguage() string {
+func (x *Image) GetContentType() string {
 	if x != nil {
-		return x.Language
+		return x.ContentType
 	}
 	return ""
 }
 
-func (x *CreateCardRequest) GetWordInformationList() []*WordInformation {
+func (x *Image) GetData() []byte {
 	if x != nil {
-		return x.WordInformationList
+		return x.Data
 	}
 	return nil
 }
 
-type UpdateCardRequest struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	UserID              string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
-	CardID              string                 `protobuf:"bytes,2,opt,name=cardID,proto3" json:"cardID,omitempty"`
-	WordInformationList []*WordInformation     `protobuf:"bytes,4,rep,name=wordInformationList,proto3" json:"wordInformationList,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+type GetAudioRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserID        string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	AudioID       string                 `protobuf:"bytes,2,opt,name=audioID,proto3" json:"audioID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateCardRequest) Reset() {
-	*x = UpdateCardRequest{}
-	mi := &file_api_lale_service_proto_msgTypes[8]
+func (x *GetAudioRequest) Reset() {
+	*x = GetAudioRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateCardRequest) String() string {
+func (x *GetAudioRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateCardRequest) ProtoMessage() {}
+func (*GetAudioRequest) ProtoMessage() {}
 
-func (x *UpdateCardRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_lale_service_proto_msgTypes[8]
+func (x *GetAudioRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -568,56 +2520,106 @@ func (x *UpdateCardRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateCardRequest.ProtoReflect.Descriptor instead.
-func (*UpdateCardRequest) Descriptor() ([]byte, []int) {
-	return file_api_lale_service_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use GetAudioRequest.ProtoReflect.Descriptor instead.
+func (*GetAudioRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *UpdateCardRequest) GetUserID() string {
+func (x *GetAudioRequest) GetUserID() string {
 	if x != nil {
 		return x.UserID
 	}
 	return ""
 }
 
-func (x *UpdateCardRequest) GetCardID() string {
+func (x *GetAudioRequest) GetAudioID() string {
 	if x != nil {
-		return x.CardID
+		return x.AudioID
 	}
 	return ""
 }
 
-func (x *UpdateCardRequest) GetWordInformationList() []*WordInformation {
+// AudioChunk is a part of the audio streamed by GetAudio, the audio is the concatenation of the chunks.
+type AudioChunk struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// set in the first chunk only
+	ContentType   string `protobuf:"bytes,1,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	Data          []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AudioChunk) Reset() {
+	*x = AudioChunk{}
+	mi := &file_api_lale_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AudioChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AudioChunk) ProtoMessage() {}
+
+func (x *AudioChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[39]
 	if x != nil {
-		return x.WordInformationList
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AudioChunk.ProtoReflect.Descriptor instead.
+func (*AudioChunk) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *AudioChunk) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *AudioChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
 	}
 	return nil
 }
 
-type InspectCardRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserID        string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
-	Language      string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
-	Word          string                 `protobuf:"bytes,3,opt,name=word,proto3" json:"word,omitempty"`
+type UserProfile struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IANA time zone name, e.g. Asia/Tokyo, empty means UTC
+	TimeZone string `protobuf:"bytes,1,opt,name=timeZone,proto3" json:"timeZone,omitempty"`
+	// the time the user's day starts at, HH:MM
+	DayStart string `protobuf:"bytes,2,opt,name=dayStart,proto3" json:"dayStart,omitempty"`
+	// the directions the word cards are reviewed in besides production: recognition and listening
+	Directions    []string `protobuf:"bytes,3,rep,name=directions,proto3" json:"directions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *InspectCardRequest) Reset() {
-	*x = InspectCardRequest{}
-	mi := &file_api_lale_service_proto_msgTypes[9]
+func (x *UserProfile) Reset() {
+	*x = UserProfile{}
+	mi := &file_api_lale_service_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *InspectCardRequest) String() string {
+func (x *UserProfile) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InspectCardRequest) ProtoMessage() {}
+func (*UserProfile) ProtoMessage() {}
 
-func (x *InspectCardRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_lale_service_proto_msgTypes[9]
+func (x *UserProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -628,57 +2630,54 @@ func (x *InspectCardRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InspectCardRequest.ProtoReflect.Descriptor instead.
-func (*InspectCardRequest) Descriptor() ([]byte, []int) {
-	return file_api_lale_service_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use UserProfile.ProtoReflect.Descriptor instead.
+func (*UserProfile) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *InspectCardRequest) GetUserID() string {
+func (x *UserProfile) GetTimeZone() string {
 	if x != nil {
-		return x.UserID
+		return x.TimeZone
 	}
 	return ""
 }
 
-func (x *InspectCardRequest) GetLanguage() string {
+func (x *UserProfile) GetDayStart() string {
 	if x != nil {
-		return x.Language
+		return x.DayStart
 	}
 	return ""
 }
 
-func (x *InspectCardRequest) GetWord() string {
+func (x *UserProfile) GetDirections() []string {
 	if x != nil {
-		return x.Word
+		return x.Directions
 	}
-	return ""
+	return nil
 }
 
-type PromptCardRequest struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	UserID              string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
-	Word                string                 `protobuf:"bytes,2,opt,name=word,proto3" json:"word,omitempty"`
-	WordLanguage        string                 `protobuf:"bytes,3,opt,name=word_language,json=wordLanguage,proto3" json:"word_language,omitempty"`
-	TranslationLanguage string                 `protobuf:"bytes,4,opt,name=translation_language,json=translationLanguage,proto3" json:"translation_language,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+type GetUserProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserID        string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PromptCardRequest) Reset() {
-	*x = PromptCardRequest{}
-	mi := &file_api_lale_service_proto_msgTypes[10]
+func (x *GetUserProfileRequest) Reset() {
+	*x = GetUserProfileRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PromptCardRequest) String() string {
+func (x *GetUserProfileRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PromptCardRequest) ProtoMessage() {}
+func (*GetUserProfileRequest) ProtoMessage() {}
 
-func (x *PromptCardRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_lale_service_proto_msgTypes[10]
+func (x *GetUserProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -689,61 +2688,95 @@ func (x *PromptCardRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PromptCardRequest.ProtoReflect.Descriptor instead.
-func (*PromptCardRequest) Descriptor() ([]byte, []int) {
-	return file_api_lale_service_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use GetUserProfileRequest.ProtoReflect.Descriptor instead.
+func (*GetUserProfileRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *PromptCardRequest) GetUserID() string {
+func (x *GetUserProfileRequest) GetUserID() string {
 	if x != nil {
 		return x.UserID
 	}
 	return ""
 }
 
-func (x *PromptCardRequest) GetWord() string {
+type UpdateUserProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserID        string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	Profile       *UserProfile           `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserProfileRequest) Reset() {
+	*x = UpdateUserProfileRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserProfileRequest) ProtoMessage() {}
+
+func (x *UpdateUserProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[42]
 	if x != nil {
-		return x.Word
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *PromptCardRequest) GetWordLanguage() string {
+// Deprecated: Use UpdateUserProfileRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserProfileRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *UpdateUserProfileRequest) GetUserID() string {
 	if x != nil {
-		return x.WordLanguage
+		return x.UserID
 	}
 	return ""
 }
 
-func (x *PromptCardRequest) GetTranslationLanguage() string {
+func (x *UpdateUserProfileRequest) GetProfile() *UserProfile {
 	if x != nil {
-		return x.TranslationLanguage
+		return x.Profile
 	}
-	return ""
+	return nil
 }
 
-type PromptCardResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Words         []string               `protobuf:"bytes,1,rep,name=words,proto3" json:"words,omitempty"`
+type DailyLimits struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the number of new cards introduced per day
+	NewCards uint32 `protobuf:"varint,1,opt,name=newCards,proto3" json:"newCards,omitempty"`
+	// the number of due cards reviewed per day
+	Reviews       uint32 `protobuf:"varint,2,opt,name=reviews,proto3" json:"reviews,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PromptCardResponse) Reset() {
-	*x = PromptCardResponse{}
-	mi := &file_api_lale_service_proto_msgTypes[11]
+func (x *DailyLimits) Reset() {
+	*x = DailyLimits{}
+	mi := &file_api_lale_service_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PromptCardResponse) String() string {
+func (x *DailyLimits) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PromptCardResponse) ProtoMessage() {}
+func (*DailyLimits) ProtoMessage() {}
 
-func (x *PromptCardResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_lale_service_proto_msgTypes[11]
+func (x *DailyLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -754,42 +2787,49 @@ func (x *PromptCardResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PromptCardResponse.ProtoReflect.Descriptor instead.
-func (*PromptCardResponse) Descriptor() ([]byte, []int) {
-	return file_api_lale_service_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use DailyLimits.ProtoReflect.Descriptor instead.
+func (*DailyLimits) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *PromptCardResponse) GetWords() []string {
+func (x *DailyLimits) GetNewCards() uint32 {
 	if x != nil {
-		return x.Words
+		return x.NewCards
 	}
-	return nil
+	return 0
 }
 
-type GetCardsResponse struct {
+func (x *DailyLimits) GetReviews() uint32 {
+	if x != nil {
+		return x.Reviews
+	}
+	return 0
+}
+
+type UpdateDailyLimitsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserID        string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
 	Language      string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
-	Cards         []*Card                `protobuf:"bytes,3,rep,name=cards,proto3" json:"cards,omitempty"`
+	Limits        *DailyLimits           `protobuf:"bytes,3,opt,name=limits,proto3" json:"limits,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCardsResponse) Reset() {
-	*x = GetCardsResponse{}
-	mi := &file_api_lale_service_proto_msgTypes[12]
+func (x *UpdateDailyLimitsRequest) Reset() {
+	*x = UpdateDailyLimitsRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCardsResponse) String() string {
+func (x *UpdateDailyLimitsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCardsResponse) ProtoMessage() {}
+func (*UpdateDailyLimitsRequest) ProtoMessage() {}
 
-func (x *GetCardsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_lale_service_proto_msgTypes[12]
+func (x *UpdateDailyLimitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -800,56 +2840,110 @@ func (x *GetCardsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCardsResponse.ProtoReflect.Descriptor instead.
-func (*GetCardsResponse) Descriptor() ([]byte, []int) {
-	return file_api_lale_service_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use UpdateDailyLimitsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateDailyLimitsRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *UpdateDailyLimitsRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *UpdateDailyLimitsRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *UpdateDailyLimitsRequest) GetLimits() *DailyLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+// GraduationPolicy marks the cards learnt automatically, a zero field disables its criterion
+type GraduationPolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the number of consecutive correct answers a card graduates after
+	CorrectAnswers uint32 `protobuf:"varint,1,opt,name=correctAnswers,proto3" json:"correctAnswers,omitempty"`
+	// the interval until the next review a card graduates at
+	Interval      *durationpb.Duration `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GraduationPolicy) Reset() {
+	*x = GraduationPolicy{}
+	mi := &file_api_lale_service_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GraduationPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraduationPolicy) ProtoMessage() {}
+
+func (x *GraduationPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *GetCardsResponse) GetUserID() string {
-	if x != nil {
-		return x.UserID
-	}
-	return ""
+// Deprecated: Use GraduationPolicy.ProtoReflect.Descriptor instead.
+func (*GraduationPolicy) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *GetCardsResponse) GetLanguage() string {
+func (x *GraduationPolicy) GetCorrectAnswers() uint32 {
 	if x != nil {
-		return x.Language
+		return x.CorrectAnswers
 	}
-	return ""
+	return 0
 }
 
-func (x *GetCardsResponse) GetCards() []*Card {
+func (x *GraduationPolicy) GetInterval() *durationpb.Duration {
 	if x != nil {
-		return x.Cards
+		return x.Interval
 	}
 	return nil
 }
 
-type UpdateCardPerformanceRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	UserID         string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
-	CardID         string                 `protobuf:"bytes,2,opt,name=cardID,proto3" json:"cardID,omitempty"`
-	IsInputCorrect bool                   `protobuf:"varint,3,opt,name=is_input_correct,json=isInputCorrect,proto3" json:"is_input_correct,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type UpdateGraduationPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserID        string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	Policy        *GraduationPolicy      `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateCardPerformanceRequest) Reset() {
-	*x = UpdateCardPerformanceRequest{}
-	mi := &file_api_lale_service_proto_msgTypes[13]
+func (x *UpdateGraduationPolicyRequest) Reset() {
+	*x = UpdateGraduationPolicyRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateCardPerformanceRequest) String() string {
+func (x *UpdateGraduationPolicyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateCardPerformanceRequest) ProtoMessage() {}
+func (*UpdateGraduationPolicyRequest) ProtoMessage() {}
 
-func (x *UpdateCardPerformanceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_lale_service_proto_msgTypes[13]
+func (x *UpdateGraduationPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -860,54 +2954,49 @@ func (x *UpdateCardPerformanceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateCardPerformanceRequest.ProtoReflect.Descriptor instead.
-func (*UpdateCardPerformanceRequest) Descriptor() ([]byte, []int) {
-	return file_api_lale_service_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use UpdateGraduationPolicyRequest.ProtoReflect.Descriptor instead.
+func (*UpdateGraduationPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *UpdateCardPerformanceRequest) GetUserID() string {
+func (x *UpdateGraduationPolicyRequest) GetUserID() string {
 	if x != nil {
 		return x.UserID
 	}
 	return ""
 }
 
-func (x *UpdateCardPerformanceRequest) GetCardID() string {
-	if x != nil {
-		return x.CardID
-	}
-	return ""
-}
-
-func (x *UpdateCardPerformanceRequest) GetIsInputCorrect() bool {
+func (x *UpdateGraduationPolicyRequest) GetPolicy() *GraduationPolicy {
 	if x != nil {
-		return x.IsInputCorrect
+		return x.Policy
 	}
-	return false
+	return nil
 }
 
-type UpdateCardPerformanceResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	NextDueDate   *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=nextDueDate,proto3" json:"nextDueDate,omitempty"`
+type UndoLastReviewRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserID string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	// empty undoes the last review of the user
+	CardID        string `protobuf:"bytes,2,opt,name=cardID,proto3" json:"cardID,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateCardPerformanceResponse) Reset() {
-	*x = UpdateCardPerformanceResponse{}
-	mi := &file_api_lale_service_proto_msgTypes[14]
+func (x *UndoLastReviewRequest) Reset() {
+	*x = UndoLastReviewRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateCardPerformanceResponse) String() string {
+func (x *UndoLastReviewRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateCardPerformanceResponse) ProtoMessage() {}
+func (*UndoLastReviewRequest) ProtoMessage() {}
 
-func (x *UpdateCardPerformanceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_lale_service_proto_msgTypes[14]
+func (x *UndoLastReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -918,42 +3007,56 @@ func (x *UpdateCardPerformanceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateCardPerformanceResponse.ProtoReflect.Descriptor instead.
-func (*UpdateCardPerformanceResponse) Descriptor() ([]byte, []int) {
-	return file_api_lale_service_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use UndoLastReviewRequest.ProtoReflect.Descriptor instead.
+func (*UndoLastReviewRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *UpdateCardPerformanceResponse) GetNextDueDate() *timestamppb.Timestamp {
+func (x *UndoLastReviewRequest) GetUserID() string {
 	if x != nil {
-		return x.NextDueDate
+		return x.UserID
 	}
-	return nil
+	return ""
 }
 
-type GetSentencesRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	UserID         string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
-	Word           string                 `protobuf:"bytes,2,opt,name=word,proto3" json:"word,omitempty"`
-	SentencesCount uint32                 `protobuf:"varint,3,opt,name=sentencesCount,proto3" json:"sentencesCount,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *UndoLastReviewRequest) GetCardID() string {
+	if x != nil {
+		return x.CardID
+	}
+	return ""
 }
 
-func (x *GetSentencesRequest) Reset() {
-	*x = GetSentencesRequest{}
-	mi := &file_api_lale_service_proto_msgTypes[15]
+type Review struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserID           string                 `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"`
+	CardID           string                 `protobuf:"bytes,3,opt,name=cardID,proto3" json:"cardID,omitempty"`
+	ReviewedAt       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=reviewedAt,proto3" json:"reviewedAt,omitempty"`
+	Performance      uint32                 `protobuf:"varint,5,opt,name=performance,proto3" json:"performance,omitempty"`
+	TimeToAnswer     *durationpb.Duration   `protobuf:"bytes,6,opt,name=timeToAnswer,proto3" json:"timeToAnswer,omitempty"`
+	PreviousInterval *durationpb.Duration   `protobuf:"bytes,7,opt,name=previousInterval,proto3" json:"previousInterval,omitempty"`
+	NewInterval      *durationpb.Duration   `protobuf:"bytes,8,opt,name=newInterval,proto3" json:"newInterval,omitempty"`
+	// production, recognition or listening
+	Direction     string `protobuf:"bytes,9,opt,name=direction,proto3" json:"direction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Review) Reset() {
+	*x = Review{}
+	mi := &file_api_lale_service_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSentencesRequest) String() string {
+func (x *Review) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSentencesRequest) ProtoMessage() {}
+func (*Review) ProtoMessage() {}
 
-func (x *GetSentencesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_lale_service_proto_msgTypes[15]
+func (x *Review) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -964,99 +3067,101 @@ func (x *GetSentencesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSentencesRequest.ProtoReflect.Descriptor instead.
-func (*GetSentencesRequest) Descriptor() ([]byte, []int) {
-	return file_api_lale_service_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use Review.ProtoReflect.Descriptor instead.
+func (*Review) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *GetSentencesRequest) GetUserID() string {
+func (x *Review) GetId() string {
 	if x != nil {
-		return x.UserID
+		return x.Id
 	}
 	return ""
 }
 
-func (x *GetSentencesRequest) GetWord() string {
+func (x *Review) GetUserID() string {
 	if x != nil {
-		return x.Word
+		return x.UserID
 	}
 	return ""
 }
 
-func (x *GetSentencesRequest) GetSentencesCount() uint32 {
+func (x *Review) GetCardID() string {
 	if x != nil {
-		return x.SentencesCount
+		return x.CardID
 	}
-	return 0
+	return ""
 }
 
-type GetSentencesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Sentences     []string               `protobuf:"bytes,1,rep,name=sentences,proto3" json:"sentences,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Review) GetReviewedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReviewedAt
+	}
+	return nil
 }
 
-func (x *GetSentencesResponse) Reset() {
-	*x = GetSentencesResponse{}
-	mi := &file_api_lale_service_proto_msgTypes[16]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Review) GetPerformance() uint32 {
+	if x != nil {
+		return x.Performance
+	}
+	return 0
 }
 
-func (x *GetSentencesResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Review) GetTimeToAnswer() *durationpb.Duration {
+	if x != nil {
+		return x.TimeToAnswer
+	}
+	return nil
 }
 
-func (*GetSentencesResponse) ProtoMessage() {}
-
-func (x *GetSentencesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_lale_service_proto_msgTypes[16]
+func (x *Review) GetPreviousInterval() *durationpb.Duration {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.PreviousInterval
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use GetSentencesResponse.ProtoReflect.Descriptor instead.
-func (*GetSentencesResponse) Descriptor() ([]byte, []int) {
-	return file_api_lale_service_proto_rawDescGZIP(), []int{16}
+func (x *Review) GetNewInterval() *durationpb.Duration {
+	if x != nil {
+		return x.NewInterval
+	}
+	return nil
 }
 
-func (x *GetSentencesResponse) GetSentences() []string {
+func (x *Review) GetDirection() string {
 	if x != nil {
-		return x.Sentences
+		return x.Direction
 	}
-	return nil
+	return ""
 }
 
-type GenerateStoryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserID        string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
-	Language      string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+type GetReviewHistoryRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserID string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	// empty returns the reviews of all the user's cards
+	CardID string `protobuf:"bytes,2,opt,name=cardID,proto3" json:"cardID,omitempty"`
+	// zero means the default page size
+	PageSize      uint32 `protobuf:"varint,3,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	PageToken     string `protobuf:"bytes,4,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenerateStoryRequest) Reset() {
-	*x = GenerateStoryRequest{}
-	mi := &file_api_lale_service_proto_msgTypes[17]
+func (x *GetReviewHistoryRequest) Reset() {
+	*x = GetReviewHistoryRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenerateStoryRequest) String() string {
+func (x *GetReviewHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenerateStoryRequest) ProtoMessage() {}
+func (*GetReviewHistoryRequest) ProtoMessage() {}
 
-func (x *GenerateStoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_lale_service_proto_msgTypes[17]
+func (x *GetReviewHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1067,47 +3172,64 @@ func (x *GenerateStoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenerateStoryRequest.ProtoReflect.Descriptor instead.
-func (*GenerateStoryRequest) Descriptor() ([]byte, []int) {
-	return file_api_lale_service_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use GetReviewHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetReviewHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *GenerateStoryRequest) GetUserID() string {
+func (x *GetReviewHistoryRequest) GetUserID() string {
 	if x != nil {
 		return x.UserID
 	}
 	return ""
 }
 
-func (x *GenerateStoryRequest) GetLanguage() string {
+func (x *GetReviewHistoryRequest) GetCardID() string {
 	if x != nil {
-		return x.Language
+		return x.CardID
 	}
 	return ""
 }
 
-type GenerateStoryResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Story         string                 `protobuf:"bytes,1,opt,name=story,proto3" json:"story,omitempty"`
+func (x *GetReviewHistoryRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetReviewHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GetReviewHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ordered from the newest to the oldest
+	Reviews []*Review `protobuf:"bytes,1,rep,name=reviews,proto3" json:"reviews,omitempty"`
+	// empty on the last page
+	NextPageToken string `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenerateStoryResponse) Reset() {
-	*x = GenerateStoryResponse{}
-	mi := &file_api_lale_service_proto_msgTypes[18]
+func (x *GetReviewHistoryResponse) Reset() {
+	*x = GetReviewHistoryResponse{}
+	mi := &file_api_lale_service_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenerateStoryResponse) String() string {
+func (x *GetReviewHistoryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenerateStoryResponse) ProtoMessage() {}
+func (*GetReviewHistoryResponse) ProtoMessage() {}
 
-func (x *GenerateStoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_lale_service_proto_msgTypes[18]
+func (x *GetReviewHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1118,41 +3240,49 @@ func (x *GenerateStoryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenerateStoryResponse.ProtoReflect.Descriptor instead.
-func (*GenerateStoryResponse) Descriptor() ([]byte, []int) {
-	return file_api_lale_service_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use GetReviewHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetReviewHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *GenerateStoryResponse) GetStory() string {
+func (x *GetReviewHistoryResponse) GetReviews() []*Review {
 	if x != nil {
-		return x.Story
+		return x.Reviews
+	}
+	return nil
+}
+
+func (x *GetReviewHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
 	}
 	return ""
 }
 
-type DeleteCardRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserID        string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
-	CardID        string                 `protobuf:"bytes,2,opt,name=cardID,proto3" json:"cardID,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type OptimiseSchedulerParametersRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserID string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	// zero keeps the desired retention of the current parameters
+	TargetRetention float64 `protobuf:"fixed64,2,opt,name=targetRetention,proto3" json:"targetRetention,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *DeleteCardRequest) Reset() {
-	*x = DeleteCardRequest{}
-	mi := &file_api_lale_service_proto_msgTypes[19]
+func (x *OptimiseSchedulerParametersRequest) Reset() {
+	*x = OptimiseSchedulerParametersRequest{}
+	mi := &file_api_lale_service_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteCardRequest) String() string {
+func (x *OptimiseSchedulerParametersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteCardRequest) ProtoMessage() {}
+func (*OptimiseSchedulerParametersRequest) ProtoMessage() {}
 
-func (x *DeleteCardRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_lale_service_proto_msgTypes[19]
+func (x *OptimiseSchedulerParametersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1163,48 +3293,49 @@ func (x *DeleteCardRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteCardRequest.ProtoReflect.Descriptor instead.
-func (*DeleteCardRequest) Descriptor() ([]byte, []int) {
-	return file_api_lale_service_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use OptimiseSchedulerParametersRequest.ProtoReflect.Descriptor instead.
+func (*OptimiseSchedulerParametersRequest) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *DeleteCardRequest) GetUserID() string {
+func (x *OptimiseSchedulerParametersRequest) GetUserID() string {
 	if x != nil {
 		return x.UserID
 	}
 	return ""
 }
 
-func (x *DeleteCardRequest) GetCardID() string {
+func (x *OptimiseSchedulerParametersRequest) GetTargetRetention() float64 {
 	if x != nil {
-		return x.CardID
+		return x.TargetRetention
 	}
-	return ""
+	return 0
 }
 
-type MarkCardLearntRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserID        string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
-	CardID        string                 `protobuf:"bytes,2,opt,name=cardID,proto3" json:"cardID,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type OptimiseSchedulerParametersResponse struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	PredictedRetentionBefore float64                `protobuf:"fixed64,1,opt,name=predictedRetentionBefore,proto3" json:"predictedRetentionBefore,omitempty"`
+	PredictedRetentionAfter  float64                `protobuf:"fixed64,2,opt,name=predictedRetentionAfter,proto3" json:"predictedRetentionAfter,omitempty"`
+	ReviewsNumber            uint32                 `protobuf:"varint,3,opt,name=reviewsNumber,proto3" json:"reviewsNumber,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
 }
 
-func (x *MarkCardLearntRequest) Reset() {
-	*x = MarkCardLearntRequest{}
-	mi := &file_api_lale_service_proto_msgTypes[20]
+func (x *OptimiseSchedulerParametersResponse) Reset() {
+	*x = OptimiseSchedulerParametersResponse{}
+	mi := &file_api_lale_service_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MarkCardLearntRequest) String() string {
+func (x *OptimiseSchedulerParametersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MarkCardLearntRequest) ProtoMessage() {}
+func (*OptimiseSchedulerParametersResponse) ProtoMessage() {}
 
-func (x *MarkCardLearntRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_lale_service_proto_msgTypes[20]
+func (x *OptimiseSchedulerParametersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_lale_service_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1215,30 +3346,37 @@ func (x *MarkCardLearntRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MarkCardLearntRequest.ProtoReflect.Descriptor instead.
-func (*MarkCardLearntRequest) Descriptor() ([]byte, []int) {
-	return file_api_lale_service_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use OptimiseSchedulerParametersResponse.ProtoReflect.Descriptor instead.
+func (*OptimiseSchedulerParametersResponse) Descriptor() ([]byte, []int) {
+	return file_api_lale_service_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *MarkCardLearntRequest) GetUserID() string {
+func (x *OptimiseSchedulerParametersResponse) GetPredictedRetentionBefore() float64 {
 	if x != nil {
-		return x.UserID
+		return x.PredictedRetentionBefore
 	}
-	return ""
+	return 0
 }
 
-func (x *MarkCardLearntRequest) GetCardID() string {
+func (x *OptimiseSchedulerParametersResponse) GetPredictedRetentionAfter() float64 {
 	if x != nil {
-		return x.CardID
+		return x.PredictedRetentionAfter
 	}
-	return ""
+	return 0
+}
+
+func (x *OptimiseSchedulerParametersResponse) GetReviewsNumber() uint32 {
+	if x != nil {
+		return x.ReviewsNumber
+	}
+	return 0
 }
 
 var File_api_lale_service_proto protoreflect.FileDescriptor
 
 const file_api_lale_service_proto_rawDesc = "" +
 	"\n" +
-	"\x16api/lale-service.proto\x12\x03api\x1a\x1fgoogle/protobuf/timestamp.proto\"\xfe\x02\n" +
+	"\x16api/lale-service.proto\x12\x03api\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x89\t\n" +
 	"\x04Card\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
 	"\x06userID\x18\x02 \x01(\tR\x06userID\x12\x1a\n" +
@@ -1247,19 +3385,61 @@ const file_api_lale_service_proto_rawDesc = "" +
 	"\x1fconsecutiveCorrectAnswersNumber\x18\x05 \x01(\rR\x1fconsecutiveCorrectAnswersNumber\x12<\n" +
 	"\vnextDueDate\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vnextDueDate\x12\x16\n" +
 	"\x06learnt\x18\a \x01(\bR\x06learnt\x12<\n" +
-	"\tlearnt_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampH\x00R\blearntAt\x88\x01\x01B\f\n" +
+	"\tlearnt_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampH\x00R\blearntAt\x88\x01\x01\x12I\n" +
+	"\x10last_reviewed_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampH\x01R\x0elastReviewedAt\x88\x01\x01\x123\n" +
+	"\fmemory_state\x18\n" +
+	" \x01(\v2\x10.api.MemoryStateR\vmemoryState\x129\n" +
+	"\x0elearning_state\x18\v \x01(\v2\x12.api.LearningStateR\rlearningState\x12\x16\n" +
+	"\x06lapses\x18\f \x01(\rR\x06lapses\x12\x14\n" +
+	"\x05leech\x18\r \x01(\bR\x05leech\x12\x1c\n" +
+	"\tsuspended\x18\x0e \x01(\bR\tsuspended\x12B\n" +
+	"\fburied_until\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampH\x02R\vburiedUntil\x88\x01\x01\x12>\n" +
+	"\n" +
+	"created_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampH\x03R\tcreatedAt\x88\x01\x01\x12>\n" +
 	"\n" +
-	"_learnt_at\"\xe1\x02\n" +
+	"updated_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampH\x04R\tupdatedAt\x88\x01\x01\x12O\n" +
+	"\x13started_learning_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampH\x05R\x11startedLearningAt\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\x13 \x03(\tR\x04tags\x12\x12\n" +
+	"\x04deck\x18\x14 \x01(\tR\x04deck\x12\x12\n" +
+	"\x04type\x18\x15 \x01(\tR\x04type\x12\x1d\n" +
+	"\x04note\x18\x16 \x01(\v2\t.api.NoteR\x04note\x12\x1c\n" +
+	"\tdirection\x18\x17 \x01(\tR\tdirection\x12\x18\n" +
+	"\aversion\x18\x18 \x01(\x04R\aversionB\f\n" +
+	"\n" +
+	"_learnt_atB\x13\n" +
+	"\x11_last_reviewed_atB\x0f\n" +
+	"\r_buried_untilB\r\n" +
+	"\v_created_atB\r\n" +
+	"\v_updated_atB\x16\n" +
+	"\x14_started_learning_at\"T\n" +
+	"\x04Note\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x16\n" +
+	"\x06answer\x18\x02 \x01(\tR\x06answer\x12 \n" +
+	"\vexplanation\x18\x03 \x01(\tR\vexplanation\"4\n" +
+	"\n" +
+	"CardFilter\x12\x12\n" +
+	"\x04tags\x18\x01 \x03(\tR\x04tags\x12\x12\n" +
+	"\x04deck\x18\x02 \x01(\tR\x04deck\"K\n" +
+	"\vMemoryState\x12\x1c\n" +
+	"\tstability\x18\x01 \x01(\x01R\tstability\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\x02 \x01(\x01R\n" +
+	"difficulty\"9\n" +
+	"\rLearningState\x12\x14\n" +
+	"\x05phase\x18\x01 \x01(\tR\x05phase\x12\x12\n" +
+	"\x04step\x18\x02 \x01(\rR\x04step\"\x8c\x03\n" +
 	"\x0fWordInformation\x12\x12\n" +
 	"\x04word\x18\x01 \x01(\tR\x04word\x122\n" +
 	"\vTranslation\x18\x02 \x01(\v2\x10.api.TranslationR\vTranslation\x12\x16\n" +
 	"\x06origin\x18\x03 \x01(\tR\x06origin\x12+\n" +
 	"\tphonetics\x18\x04 \x03(\v2\r.api.PhoneticR\tphonetics\x12(\n" +
-	"\bmeanings\x18\x05 \x03(\v2\f.api.MeaningR\bmeanings\x12S\n" +
-	"\x0faudioByLanguage\x18\x06 \x03(\v2).api.WordInformation.AudioByLanguageEntryR\x0faudioByLanguage\x1aB\n" +
-	"\x14AudioByLanguageEntry\x12\x10\n" +
+	"\bmeanings\x18\x05 \x03(\v2\f.api.MeaningR\bmeanings\x12\x14\n" +
+	"\x05notes\x18\a \x01(\tR\x05notes\x12\x18\n" +
+	"\aimageID\x18\b \x01(\tR\aimageID\x12>\n" +
+	"\baudioIDs\x18\t \x03(\v2\".api.WordInformation.AudioIDsEntryR\baudioIDs\x1a;\n" +
+	"\rAudioIDsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\"M\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01J\x04\b\x06\x10\aR\x0faudioByLanguage\"M\n" +
 	"\vTranslation\x12\x1a\n" +
 	"\blanguage\x18\x01 \x01(\tR\blanguage\x12\"\n" +
 	"\fTranslations\x18\x02 \x03(\tR\fTranslations\"\x1e\n" +
@@ -1275,18 +3455,30 @@ const file_api_lale_service_proto_rawDesc = "" +
 	"definition\x12\x18\n" +
 	"\aexample\x18\x02 \x01(\tR\aexample\x12\x1a\n" +
 	"\bsynonyms\x18\x03 \x03(\tR\bsynonyms\x12\x1a\n" +
-	"\bantonyms\x18\x04 \x03(\tR\bantonyms\"E\n" +
+	"\bantonyms\x18\x04 \x03(\tR\bantonyms\"n\n" +
 	"\x0fGetCardsRequest\x12\x16\n" +
 	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x1a\n" +
-	"\blanguage\x18\x02 \x01(\tR\blanguage\"\x8f\x01\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12'\n" +
+	"\x06filter\x18\x03 \x01(\v2\x0f.api.CardFilterR\x06filter\"\x92\x01\n" +
+	"\x16GetCardsForCramRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12\x18\n" +
+	"\acardIDs\x18\x03 \x03(\tR\acardIDs\x12\x14\n" +
+	"\x05order\x18\x04 \x01(\tR\x05order\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\rR\x05limit\"\xc2\x01\n" +
 	"\x11CreateCardRequest\x12\x16\n" +
 	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x1a\n" +
 	"\blanguage\x18\x02 \x01(\tR\blanguage\x12F\n" +
-	"\x13wordInformationList\x18\x03 \x03(\v2\x14.api.WordInformationR\x13wordInformationList\"\x8b\x01\n" +
+	"\x13wordInformationList\x18\x03 \x03(\v2\x14.api.WordInformationR\x13wordInformationList\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x1d\n" +
+	"\x04note\x18\x05 \x01(\v2\t.api.NoteR\x04note\"\xd8\x01\n" +
 	"\x11UpdateCardRequest\x12\x16\n" +
 	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x16\n" +
 	"\x06cardID\x18\x02 \x01(\tR\x06cardID\x12F\n" +
-	"\x13wordInformationList\x18\x04 \x03(\v2\x14.api.WordInformationR\x13wordInformationList\"\\\n" +
+	"\x13wordInformationList\x18\x04 \x03(\v2\x14.api.WordInformationR\x13wordInformationList\x12\x12\n" +
+	"\x04type\x18\x05 \x01(\tR\x04type\x12\x1d\n" +
+	"\x04note\x18\x06 \x01(\v2\t.api.NoteR\x04note\x12\x18\n" +
+	"\aversion\x18\a \x01(\x04R\aversion\"\\\n" +
 	"\x12InspectCardRequest\x12\x16\n" +
 	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x1a\n" +
 	"\blanguage\x18\x02 \x01(\tR\blanguage\x12\x12\n" +
@@ -1297,34 +3489,145 @@ const file_api_lale_service_proto_rawDesc = "" +
 	"\rword_language\x18\x03 \x01(\tR\fwordLanguage\x121\n" +
 	"\x14translation_language\x18\x04 \x01(\tR\x13translationLanguage\"*\n" +
 	"\x12PromptCardResponse\x12\x14\n" +
-	"\x05words\x18\x01 \x03(\tR\x05words\"g\n" +
+	"\x05words\x18\x01 \x03(\tR\x05words\"\xad\x01\n" +
 	"\x10GetCardsResponse\x12\x16\n" +
 	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x1a\n" +
 	"\blanguage\x18\x02 \x01(\tR\blanguage\x12\x1f\n" +
-	"\x05cards\x18\x03 \x03(\v2\t.api.CardR\x05cards\"x\n" +
+	"\x05cards\x18\x03 \x03(\v2\t.api.CardR\x05cards\x12&\n" +
+	"\x0eremainingToday\x18\x04 \x01(\rR\x0eremainingToday\x12\x1c\n" +
+	"\tpostponed\x18\x05 \x01(\rR\tpostponed\"\xfa\x01\n" +
 	"\x1cUpdateCardPerformanceRequest\x12\x16\n" +
 	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x16\n" +
-	"\x06cardID\x18\x02 \x01(\tR\x06cardID\x12(\n" +
-	"\x10is_input_correct\x18\x03 \x01(\bR\x0eisInputCorrect\"]\n" +
+	"\x06cardID\x18\x02 \x01(\tR\x06cardID\x12%\n" +
+	"\vperformance\x18\x04 \x01(\rH\x00R\vperformance\x88\x01\x01\x12=\n" +
+	"\ftimeToAnswer\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\ftimeToAnswer\x12\x1c\n" +
+	"\tdirection\x18\x06 \x01(\tR\tdirectionB\x0e\n" +
+	"\f_performanceJ\x04\b\x03\x10\x04R\x10is_input_correct\"{\n" +
 	"\x1dUpdateCardPerformanceResponse\x12<\n" +
-	"\vnextDueDate\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\vnextDueDate\"i\n" +
+	"\vnextDueDate\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\vnextDueDate\x12\x1c\n" +
+	"\tgraduated\x18\x02 \x01(\bR\tgraduated\"i\n" +
 	"\x13GetSentencesRequest\x12\x16\n" +
 	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x12\n" +
 	"\x04word\x18\x02 \x01(\tR\x04word\x12&\n" +
 	"\x0esentencesCount\x18\x03 \x01(\rR\x0esentencesCount\"4\n" +
 	"\x14GetSentencesResponse\x12\x1c\n" +
-	"\tsentences\x18\x01 \x03(\tR\tsentences\"J\n" +
+	"\tsentences\x18\x01 \x03(\tR\tsentences\"s\n" +
 	"\x14GenerateStoryRequest\x12\x16\n" +
 	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x1a\n" +
-	"\blanguage\x18\x02 \x01(\tR\blanguage\"-\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12'\n" +
+	"\x06filter\x18\x03 \x01(\v2\x0f.api.CardFilterR\x06filter\"-\n" +
 	"\x15GenerateStoryResponse\x12\x14\n" +
-	"\x05story\x18\x01 \x01(\tR\x05story\"C\n" +
+	"\x05story\x18\x01 \x01(\tR\x05story\"@\n" +
+	"\x0eGetCardRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x16\n" +
+	"\x06cardID\x18\x02 \x01(\tR\x06cardID\"C\n" +
 	"\x11DeleteCardRequest\x12\x16\n" +
 	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x16\n" +
 	"\x06cardID\x18\x02 \x01(\tR\x06cardID\"G\n" +
 	"\x15MarkCardLearntRequest\x12\x16\n" +
 	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x16\n" +
-	"\x06cardID\x18\x02 \x01(\tR\x06cardID2\xf5\x05\n" +
+	"\x06cardID\x18\x02 \x01(\tR\x06cardID\"V\n" +
+	"\x10ResetCardRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x16\n" +
+	"\x06cardID\x18\x02 \x01(\tR\x06cardID\x12\x12\n" +
+	"\x04mode\x18\x03 \x01(\tR\x04mode\"b\n" +
+	"\x12SuspendCardRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x16\n" +
+	"\x06cardID\x18\x02 \x01(\tR\x06cardID\x12\x1c\n" +
+	"\tsuspended\x18\x03 \x01(\bR\tsuspended\"Y\n" +
+	"\x0fBuryCardRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x16\n" +
+	"\x06cardID\x18\x02 \x01(\tR\x06cardID\x12\x16\n" +
+	"\x06buried\x18\x03 \x01(\bR\x06buried\"q\n" +
+	"\x15UpdateCardTagsRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x16\n" +
+	"\x06cardID\x18\x02 \x01(\tR\x06cardID\x12\x10\n" +
+	"\x03add\x18\x03 \x03(\tR\x03add\x12\x16\n" +
+	"\x06remove\x18\x04 \x03(\tR\x06remove\"X\n" +
+	"\x12SetCardDeckRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x16\n" +
+	"\x06cardID\x18\x02 \x01(\tR\x06cardID\x12\x12\n" +
+	"\x04deck\x18\x03 \x01(\tR\x04deck\"E\n" +
+	"\x0fGetDecksRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\"K\n" +
+	"\x10GetDecksResponse\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x1f\n" +
+	"\x05decks\x18\x02 \x03(\v2\t.api.DeckR\x05decks\"0\n" +
+	"\x04Deck\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05cards\x18\x02 \x01(\rR\x05cards\"@\n" +
+	"\x12UploadImageRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"C\n" +
+	"\x0fGetImageRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x18\n" +
+	"\aimageID\x18\x02 \x01(\tR\aimageID\"M\n" +
+	"\x05Image\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\vcontentType\x18\x02 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"C\n" +
+	"\x0fGetAudioRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x18\n" +
+	"\aaudioID\x18\x02 \x01(\tR\aaudioID\"B\n" +
+	"\n" +
+	"AudioChunk\x12 \n" +
+	"\vcontentType\x18\x01 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"e\n" +
+	"\vUserProfile\x12\x1a\n" +
+	"\btimeZone\x18\x01 \x01(\tR\btimeZone\x12\x1a\n" +
+	"\bdayStart\x18\x02 \x01(\tR\bdayStart\x12\x1e\n" +
+	"\n" +
+	"directions\x18\x03 \x03(\tR\n" +
+	"directions\"/\n" +
+	"\x15GetUserProfileRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\"^\n" +
+	"\x18UpdateUserProfileRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\x12*\n" +
+	"\aprofile\x18\x02 \x01(\v2\x10.api.UserProfileR\aprofile\"C\n" +
+	"\vDailyLimits\x12\x1a\n" +
+	"\bnewCards\x18\x01 \x01(\rR\bnewCards\x12\x18\n" +
+	"\areviews\x18\x02 \x01(\rR\areviews\"x\n" +
+	"\x18UpdateDailyLimitsRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12(\n" +
+	"\x06limits\x18\x03 \x01(\v2\x10.api.DailyLimitsR\x06limits\"q\n" +
+	"\x10GraduationPolicy\x12&\n" +
+	"\x0ecorrectAnswers\x18\x01 \x01(\rR\x0ecorrectAnswers\x125\n" +
+	"\binterval\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\binterval\"f\n" +
+	"\x1dUpdateGraduationPolicyRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\x12-\n" +
+	"\x06policy\x18\x02 \x01(\v2\x15.api.GraduationPolicyR\x06policy\"G\n" +
+	"\x15UndoLastReviewRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x16\n" +
+	"\x06cardID\x18\x02 \x01(\tR\x06cardID\"\x87\x03\n" +
+	"\x06Review\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06userID\x18\x02 \x01(\tR\x06userID\x12\x16\n" +
+	"\x06cardID\x18\x03 \x01(\tR\x06cardID\x12:\n" +
+	"\n" +
+	"reviewedAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"reviewedAt\x12 \n" +
+	"\vperformance\x18\x05 \x01(\rR\vperformance\x12=\n" +
+	"\ftimeToAnswer\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\ftimeToAnswer\x12E\n" +
+	"\x10previousInterval\x18\a \x01(\v2\x19.google.protobuf.DurationR\x10previousInterval\x12;\n" +
+	"\vnewInterval\x18\b \x01(\v2\x19.google.protobuf.DurationR\vnewInterval\x12\x1c\n" +
+	"\tdirection\x18\t \x01(\tR\tdirection\"\x83\x01\n" +
+	"\x17GetReviewHistoryRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x16\n" +
+	"\x06cardID\x18\x02 \x01(\tR\x06cardID\x12\x1a\n" +
+	"\bpageSize\x18\x03 \x01(\rR\bpageSize\x12\x1c\n" +
+	"\tpageToken\x18\x04 \x01(\tR\tpageToken\"g\n" +
+	"\x18GetReviewHistoryResponse\x12%\n" +
+	"\areviews\x18\x01 \x03(\v2\v.api.ReviewR\areviews\x12$\n" +
+	"\rnextPageToken\x18\x02 \x01(\tR\rnextPageToken\"f\n" +
+	"\"OptimiseSchedulerParametersRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\x12(\n" +
+	"\x0ftargetRetention\x18\x02 \x01(\x01R\x0ftargetRetention\"\xc1\x01\n" +
+	"#OptimiseSchedulerParametersResponse\x12:\n" +
+	"\x18predictedRetentionBefore\x18\x01 \x01(\x01R\x18predictedRetentionBefore\x128\n" +
+	"\x17predictedRetentionAfter\x18\x02 \x01(\x01R\x17predictedRetentionAfter\x12$\n" +
+	"\rreviewsNumber\x18\x03 \x01(\rR\rreviewsNumber2\x8a\x0f\n" +
 	"\vLaleService\x121\n" +
 	"\vInspectCard\x12\x17.api.InspectCardRequest\x1a\t.api.Card\x12=\n" +
 	"\n" +
@@ -1336,12 +3639,34 @@ const file_api_lale_service_proto_rawDesc = "" +
 	"UpdateCard\x12\x16.api.UpdateCardRequest\x1a\t.api.Card\x12^\n" +
 	"\x15UpdateCardPerformance\x12!.api.UpdateCardPerformanceRequest\x1a\".api.UpdateCardPerformanceResponse\x12?\n" +
 	"\x10GetCardsToRepeat\x12\x14.api.GetCardsRequest\x1a\x15.api.GetCardsResponse\x12>\n" +
-	"\x0fGetCardsToLearn\x12\x14.api.GetCardsRequest\x1a\x15.api.GetCardsResponse\x12C\n" +
+	"\x0fGetCardsToLearn\x12\x14.api.GetCardsRequest\x1a\x15.api.GetCardsResponse\x129\n" +
+	"\n" +
+	"GetLeeches\x12\x14.api.GetCardsRequest\x1a\x15.api.GetCardsResponse\x12E\n" +
+	"\x0fGetCardsForCram\x12\x1b.api.GetCardsForCramRequest\x1a\x15.api.GetCardsResponse\x12C\n" +
 	"\fGetSentences\x12\x18.api.GetSentencesRequest\x1a\x19.api.GetSentencesResponse\x12F\n" +
-	"\rGenerateStory\x12\x19.api.GenerateStoryRequest\x1a\x1a.api.GenerateStoryResponse\x12/\n" +
+	"\rGenerateStory\x12\x19.api.GenerateStoryRequest\x1a\x1a.api.GenerateStoryResponse\x12)\n" +
+	"\aGetCard\x12\x13.api.GetCardRequest\x1a\t.api.Card\x12/\n" +
 	"\n" +
 	"DeleteCard\x12\x16.api.DeleteCardRequest\x1a\t.api.Card\x127\n" +
-	"\x0eMarkCardLearnt\x12\x1a.api.MarkCardLearntRequest\x1a\t.api.CardB\"Z github.com/genvmoroz/service/apib\x06proto3"
+	"\x0eMarkCardLearnt\x12\x1a.api.MarkCardLearntRequest\x1a\t.api.Card\x12-\n" +
+	"\tResetCard\x12\x15.api.ResetCardRequest\x1a\t.api.Card\x121\n" +
+	"\vSuspendCard\x12\x17.api.SuspendCardRequest\x1a\t.api.Card\x12+\n" +
+	"\bBuryCard\x12\x14.api.BuryCardRequest\x1a\t.api.Card\x127\n" +
+	"\x0eUpdateCardTags\x12\x1a.api.UpdateCardTagsRequest\x1a\t.api.Card\x121\n" +
+	"\vSetCardDeck\x12\x17.api.SetCardDeckRequest\x1a\t.api.Card\x127\n" +
+	"\bGetDecks\x12\x14.api.GetDecksRequest\x1a\x15.api.GetDecksResponse\x122\n" +
+	"\vUploadImage\x12\x17.api.UploadImageRequest\x1a\n" +
+	".api.Image\x12,\n" +
+	"\bGetImage\x12\x14.api.GetImageRequest\x1a\n" +
+	".api.Image\x123\n" +
+	"\bGetAudio\x12\x14.api.GetAudioRequest\x1a\x0f.api.AudioChunk0\x01\x12>\n" +
+	"\x0eGetUserProfile\x12\x1a.api.GetUserProfileRequest\x1a\x10.api.UserProfile\x12D\n" +
+	"\x11UpdateUserProfile\x12\x1d.api.UpdateUserProfileRequest\x1a\x10.api.UserProfile\x12D\n" +
+	"\x11UpdateDailyLimits\x12\x1d.api.UpdateDailyLimitsRequest\x1a\x10.api.DailyLimits\x12S\n" +
+	"\x16UpdateGraduationPolicy\x12\".api.UpdateGraduationPolicyRequest\x1a\x15.api.GraduationPolicy\x127\n" +
+	"\x0eUndoLastReview\x12\x1a.api.UndoLastReviewRequest\x1a\t.api.Card\x12O\n" +
+	"\x10GetReviewHistory\x12\x1c.api.GetReviewHistoryRequest\x1a\x1d.api.GetReviewHistoryResponse\x12p\n" +
+	"\x1bOptimiseSchedulerParameters\x12'.api.OptimiseSchedulerParametersRequest\x1a(.api.OptimiseSchedulerParametersResponseB\"Z github.com/genvmoroz/service/apib\x06proto3"
 
 var (
 	file_api_lale_service_proto_rawDescOnce sync.Once
@@ -1355,74 +3680,168 @@ func file_api_lale_service_proto_rawDescGZIP() []byte {
 	return file_api_lale_service_proto_rawDescData
 }
 
-var file_api_lale_service_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_api_lale_service_proto_msgTypes = make([]protoimpl.MessageInfo, 54)
 var file_api_lale_service_proto_goTypes = []any{
-	(*Card)(nil),                          // 0: api.Card
-	(*WordInformation)(nil),               // 1: api.WordInformation
-	(*Translation)(nil),                   // 2: api.Translation
-	(*Phonetic)(nil),                      // 3: api.Phonetic
-	(*Meaning)(nil),                       // 4: api.Meaning
-	(*Definition)(nil),                    // 5: api.Definition
-	(*GetCardsRequest)(nil),               // 6: api.GetCardsRequest
-	(*CreateCardRequest)(nil),             // 7: api.CreateCardRequest
-	(*UpdateCardRequest)(nil),             // 8: api.UpdateCardRequest
-	(*InspectCardRequest)(nil),            // 9: api.InspectCardRequest
-	(*PromptCardRequest)(nil),             // 10: api.PromptCardRequest
-	(*PromptCardResponse)(nil),            // 11: api.PromptCardResponse
-	(*GetCardsResponse)(nil),              // 12: api.GetCardsResponse
-	(*UpdateCardPerformanceRequest)(nil),  // 13: api.UpdateCardPerformanceRequest
-	(*UpdateCardPerformanceResponse)(nil), // 14: api.UpdateCardPerformanceResponse
-	(*GetSentencesRequest)(nil),           // 15: api.GetSentencesRequest
-	(*GetSentencesResponse)(nil),          // 16: api.GetSentencesResponse
-	(*GenerateStoryRequest)(nil),          // 17: api.GenerateStoryRequest
-	(*GenerateStoryResponse)(nil),         // 18: api.GenerateStoryResponse
-	(*DeleteCardRequest)(nil),             // 19: api.DeleteCardRequest
-	(*MarkCardLearntRequest)(nil),         // 20: api.MarkCardLearntRequest
-	nil,                                   // 21: api.WordInformation.AudioByLanguageEntry
-	(*timestamppb.Timestamp)(nil),         // 22: google.protobuf.Timestamp
+	(*Card)(nil),                                // 0: api.Card
+	(*Note)(nil),                                // 1: api.Note
+	(*CardFilter)(nil),                          // 2: api.CardFilter
+	(*MemoryState)(nil),                         // 3: api.MemoryState
+	(*LearningState)(nil),                       // 4: api.LearningState
+	(*WordInformation)(nil),                     // 5: api.WordInformation
+	(*Translation)(nil),                         // 6: api.Translation
+	(*Phonetic)(nil),                            // 7: api.Phonetic
+	(*Meaning)(nil),                             // 8: api.Meaning
+	(*Definition)(nil),                          // 9: api.Definition
+	(*GetCardsRequest)(nil),                     // 10: api.GetCardsRequest
+	(*GetCardsForCramRequest)(nil),              // 11: api.GetCardsForCramRequest
+	(*CreateCardRequest)(nil),                   // 12: api.CreateCardRequest
+	(*UpdateCardRequest)(nil),                   // 13: api.UpdateCardRequest
+	(*InspectCardRequest)(nil),                  // 14: api.InspectCardRequest
+	(*PromptCardRequest)(nil),                   // 15: api.PromptCardRequest
+	(*PromptCardResponse)(nil),                  // 16: api.PromptCardResponse
+	(*GetCardsResponse)(nil),                    // 17: api.GetCardsResponse
+	(*UpdateCardPerformanceRequest)(nil),        // 18: api.UpdateCardPerformanceRequest
+	(*UpdateCardPerformanceResponse)(nil),       // 19: api.UpdateCardPerformanceResponse
+	(*GetSentencesRequest)(nil),                 // 20: api.GetSentencesRequest
+	(*GetSentencesResponse)(nil),                // 21: api.GetSentencesResponse
+	(*GenerateStoryRequest)(nil),                // 22: api.GenerateStoryRequest
+	(*GenerateStoryResponse)(nil),               // 23: api.GenerateStoryResponse
+	(*GetCardRequest)(nil),                      // 24: api.GetCardRequest
+	(*DeleteCardRequest)(nil),                   // 25: api.DeleteCardRequest
+	(*MarkCardLearntRequest)(nil),               // 26: api.MarkCardLearntRequest
+	(*ResetCardRequest)(nil),                    // 27: api.ResetCardRequest
+	(*SuspendCardRequest)(nil),                  // 28: api.SuspendCardRequest
+	(*BuryCardRequest)(nil),                     // 29: api.BuryCardRequest
+	(*UpdateCardTagsRequest)(nil),               // 30: api.UpdateCardTagsRequest
+	(*SetCardDeckRequest)(nil),                  // 31: api.SetCardDeckRequest
+	(*GetDecksRequest)(nil),                     // 32: api.GetDecksRequest
+	(*GetDecksResponse)(nil),                    // 33: api.GetDecksResponse
+	(*Deck)(nil),                                // 34: api.Deck
+	(*UploadImageRequest)(nil),                  // 35: api.UploadImageRequest
+	(*GetImageRequest)(nil),                     // 36: api.GetImageRequest
+	(*Image)(nil),                               // 37: api.Image
+	(*GetAudioRequest)(nil),                     // 38: api.GetAudioRequest
+	(*AudioChunk)(nil),                          // 39: api.AudioChunk
+	(*UserProfile)(nil),                         // 40: api.UserProfile
+	(*GetUserProfileRequest)(nil),               // 41: api.GetUserProfileRequest
+	(*UpdateUserProfileRequest)(nil),            // 42: api.UpdateUserProfileRequest
+	(*DailyLimits)(nil),                         // 43: api.DailyLimits
+	(*UpdateDailyLimitsRequest)(nil),            // 44: api.UpdateDailyLimitsRequest
+	(*GraduationPolicy)(nil),                    // 45: api.GraduationPolicy
+	(*UpdateGraduationPolicyRequest)(nil),       // 46: api.UpdateGraduationPolicyRequest
+	(*UndoLastReviewRequest)(nil),               // 47: api.UndoLastReviewRequest
+	(*Review)(nil),                              // 48: api.Review
+	(*GetReviewHistoryRequest)(nil),             // 49: api.GetReviewHistoryRequest
+	(*GetReviewHistoryResponse)(nil),            // 50: api.GetReviewHistoryResponse
+	(*OptimiseSchedulerParametersRequest)(nil),  // 51: api.OptimiseSchedulerParametersRequest
+	(*OptimiseSchedulerParametersResponse)(nil), // 52: api.OptimiseSchedulerParametersResponse
+	nil,                           // 53: api.WordInformation.AudioIDsEntry
+	(*timestamppb.Timestamp)(nil), // 54: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),   // 55: google.protobuf.Duration
 }
 var file_api_lale_service_proto_depIdxs = []int32{
-	1,  // 0: api.Card.wordInformationList:type_name -> api.WordInformation
-	22, // 1: api.Card.nextDueDate:type_name -> google.protobuf.Timestamp
-	22, // 2: api.Card.learnt_at:type_name -> google.protobuf.Timestamp
-	2,  // 3: api.WordInformation.Translation:type_name -> api.Translation
-	3,  // 4: api.WordInformation.phonetics:type_name -> api.Phonetic
-	4,  // 5: api.WordInformation.meanings:type_name -> api.Meaning
-	21, // 6: api.WordInformation.audioByLanguage:type_name -> api.WordInformation.AudioByLanguageEntry
-	5,  // 7: api.Meaning.Definitions:type_name -> api.Definition
-	1,  // 8: api.CreateCardRequest.wordInformationList:type_name -> api.WordInformation
-	1,  // 9: api.UpdateCardRequest.wordInformationList:type_name -> api.WordInformation
-	0,  // 10: api.GetCardsResponse.cards:type_name -> api.Card
-	22, // 11: api.UpdateCardPerformanceResponse.nextDueDate:type_name -> google.protobuf.Timestamp
-	9,  // 12: api.LaleService.InspectCard:input_type -> api.InspectCardRequest
-	10, // 13: api.LaleService.PromptCard:input_type -> api.PromptCardRequest
-	7,  // 14: api.LaleService.CreateCard:input_type -> api.CreateCardRequest
-	6,  // 15: api.LaleService.GetAllCards:input_type -> api.GetCardsRequest
-	8,  // 16: api.LaleService.UpdateCard:input_type -> api.UpdateCardRequest
-	13, // 17: api.LaleService.UpdateCardPerformance:input_type -> api.UpdateCardPerformanceRequest
-	6,  // 18: api.LaleService.GetCardsToRepeat:input_type -> api.GetCardsRequest
-	6,  // 19: api.LaleService.GetCardsToLearn:input_type -> api.GetCardsRequest
-	15, // 20: api.LaleService.GetSentences:input_type -> api.GetSentencesRequest
-	17, // 21: api.LaleService.GenerateStory:input_type -> api.GenerateStoryRequest
-	19, // 22: api.LaleService.DeleteCard:input_type -> api.DeleteCardRequest
-	20, // 23: api.LaleService.MarkCardLearnt:input_type -> api.MarkCardLearntRequest
-	0,  // 24: api.LaleService.InspectCard:output_type -> api.Card
-	11, // 25: api.LaleService.PromptCard:output_type -> api.PromptCardResponse
-	0,  // 26: api.LaleService.CreateCard:output_type -> api.Card
-	12, // 27: api.LaleService.GetAllCards:output_type -> api.GetCardsResponse
-	0,  // 28: api.LaleService.UpdateCard:output_type -> api.Card
-	14, // 29: api.LaleService.UpdateCardPerformance:output_type -> api.UpdateCardPerformanceResponse
-	12, // 30: api.LaleService.GetCardsToRepeat:output_type -> api.GetCardsResponse
-	12, // 31: api.LaleService.GetCardsToLearn:output_type -> api.GetCardsResponse
-	16, // 32: api.LaleService.GetSentences:output_type -> api.GetSentencesResponse
-	18, // 33: api.LaleService.GenerateStory:output_type -> api.GenerateStoryResponse
-	0,  // 34: api.LaleService.DeleteCard:output_type -> api.Card
-	0,  // 35: api.LaleService.MarkCardLearnt:output_type -> api.Card
-	24, // [24:36] is the sub-list for method output_type
-	12, // [12:24] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	5,  // 0: api.Card.wordInformationList:type_name -> api.WordInformation
+	54, // 1: api.Card.nextDueDate:type_name -> google.protobuf.Timestamp
+	54, // 2: api.Card.learnt_at:type_name -> google.protobuf.Timestamp
+	54, // 3: api.Card.last_reviewed_at:type_name -> google.protobuf.Timestamp
+	3,  // 4: api.Card.memory_state:type_name -> api.MemoryState
+	4,  // 5: api.Card.learning_state:type_name -> api.LearningState
+	54, // 6: api.Card.buried_until:type_name -> google.protobuf.Timestamp
+	54, // 7: api.Card.created_at:type_name -> google.protobuf.Timestamp
+	54, // 8: api.Card.updated_at:type_name -> google.protobuf.Timestamp
+	54, // 9: api.Card.started_learning_at:type_name -> google.protobuf.Timestamp
+	1,  // 10: api.Card.note:type_name -> api.Note
+	6,  // 11: api.WordInformation.Translation:type_name -> api.Translation
+	7,  // 12: api.WordInformation.phonetics:type_name -> api.Phonetic
+	8,  // 13: api.WordInformation.meanings:type_name -> api.Meaning
+	53, // 14: api.WordInformation.audioIDs:type_name -> api.WordInformation.AudioIDsEntry
+	9,  // 15: api.Meaning.Definitions:type_name -> api.Definition
+	2,  // 16: api.GetCardsRequest.filter:type_name -> api.CardFilter
+	5,  // 17: api.CreateCardRequest.wordInformationList:type_name -> api.WordInformation
+	1,  // 18: api.CreateCardRequest.note:type_name -> api.Note
+	5,  // 19: api.UpdateCardRequest.wordInformationList:type_name -> api.WordInformation
+	1,  // 20: api.UpdateCardRequest.note:type_name -> api.Note
+	0,  // 21: api.GetCardsResponse.cards:type_name -> api.Card
+	55, // 22: api.UpdateCardPerformanceRequest.timeToAnswer:type_name -> google.protobuf.Duration
+	54, // 23: api.UpdateCardPerformanceResponse.nextDueDate:type_name -> google.protobuf.Timestamp
+	2,  // 24: api.GenerateStoryRequest.filter:type_name -> api.CardFilter
+	34, // 25: api.GetDecksResponse.decks:type_name -> api.Deck
+	40, // 26: api.UpdateUserProfileRequest.profile:type_name -> api.UserProfile
+	43, // 27: api.UpdateDailyLimitsRequest.limits:type_name -> api.DailyLimits
+	55, // 28: api.GraduationPolicy.interval:type_name -> google.protobuf.Duration
+	45, // 29: api.UpdateGraduationPolicyRequest.policy:type_name -> api.GraduationPolicy
+	54, // 30: api.Review.reviewedAt:type_name -> google.protobuf.Timestamp
+	55, // 31: api.Review.timeToAnswer:type_name -> google.protobuf.Duration
+	55, // 32: api.Review.previousInterval:type_name -> google.protobuf.Duration
+	55, // 33: api.Review.newInterval:type_name -> google.protobuf.Duration
+	48, // 34: api.GetReviewHistoryResponse.reviews:type_name -> api.Review
+	14, // 35: api.LaleService.InspectCard:input_type -> api.InspectCardRequest
+	15, // 36: api.LaleService.PromptCard:input_type -> api.PromptCardRequest
+	12, // 37: api.LaleService.CreateCard:input_type -> api.CreateCardRequest
+	10, // 38: api.LaleService.GetAllCards:input_type -> api.GetCardsRequest
+	13, // 39: api.LaleService.UpdateCard:input_type -> api.UpdateCardRequest
+	18, // 40: api.LaleService.UpdateCardPerformance:input_type -> api.UpdateCardPerformanceRequest
+	10, // 41: api.LaleService.GetCardsToRepeat:input_type -> api.GetCardsRequest
+	10, // 42: api.LaleService.GetCardsToLearn:input_type -> api.GetCardsRequest
+	10, // 43: api.LaleService.GetLeeches:input_type -> api.GetCardsRequest
+	11, // 44: api.LaleService.GetCardsForCram:input_type -> api.GetCardsForCramRequest
+	20, // 45: api.LaleService.GetSentences:input_type -> api.GetSentencesRequest
+	22, // 46: api.LaleService.GenerateStory:input_type -> api.GenerateStoryRequest
+	24, // 47: api.LaleService.GetCard:input_type -> api.GetCardRequest
+	25, // 48: api.LaleService.DeleteCard:input_type -> api.DeleteCardRequest
+	26, // 49: api.LaleService.MarkCardLearnt:input_type -> api.MarkCardLearntRequest
+	27, // 50: api.LaleService.ResetCard:input_type -> api.ResetCardRequest
+	28, // 51: api.LaleService.SuspendCard:input_type -> api.SuspendCardRequest
+	29, // 52: api.LaleService.BuryCard:input_type -> api.BuryCardRequest
+	30, // 53: api.LaleService.UpdateCardTags:input_type -> api.UpdateCardTagsRequest
+	31, // 54: api.LaleService.SetCardDeck:input_type -> api.SetCardDeckRequest
+	32, // 55: api.LaleService.GetDecks:input_type -> api.GetDecksRequest
+	35, // 56: api.LaleService.UploadImage:input_type -> api.UploadImageRequest
+	36, // 57: api.LaleService.GetImage:input_type -> api.GetImageRequest
+	38, // 58: api.LaleService.GetAudio:input_type -> api.GetAudioRequest
+	41, // 59: api.LaleService.GetUserProfile:input_type -> api.GetUserProfileRequest
+	42, // 60: api.LaleService.UpdateUserProfile:input_type -> api.UpdateUserProfileRequest
+	44, // 61: api.LaleService.UpdateDailyLimits:input_type -> api.UpdateDailyLimitsRequest
+	46, // 62: api.LaleService.UpdateGraduationPolicy:input_type -> api.UpdateGraduationPolicyRequest
+	47, // 63: api.LaleService.UndoLastReview:input_type -> api.UndoLastReviewRequest
+	49, // 64: api.LaleService.GetReviewHistory:input_type -> api.GetReviewHistoryRequest
+	51, // 65: api.LaleService.OptimiseSchedulerParameters:input_type -> api.OptimiseSchedulerParametersRequest
+	0,  // 66: api.LaleService.InspectCard:output_type -> api.Card
+	16, // 67: api.LaleService.PromptCard:output_type -> api.PromptCardResponse
+	0,  // 68: api.LaleService.CreateCard:output_type -> api.Card
+	17, // 69: api.LaleService.GetAllCards:output_type -> api.GetCardsResponse
+	0,  // 70: api.LaleService.UpdateCard:output_type -> api.Card
+	19, // 71: api.LaleService.UpdateCardPerformance:output_type -> api.UpdateCardPerformanceResponse
+	17, // 72: api.LaleService.GetCardsToRepeat:output_type -> api.GetCardsResponse
+	17, // 73: api.LaleService.GetCardsToLearn:output_type -> api.GetCardsResponse
+	17, // 74: api.LaleService.GetLeeches:output_type -> api.GetCardsResponse
+	17, // 75: api.LaleService.GetCardsForCram:output_type -> api.GetCardsResponse
+	21, // 76: api.LaleService.GetSentences:output_type -> api.GetSentencesResponse
+	23, // 77: api.LaleService.GenerateStory:output_type -> api.GenerateStoryResponse
+	0,  // 78: api.LaleService.GetCard:output_type -> api.Card
+	0,  // 79: api.LaleService.DeleteCard:output_type -> api.Card
+	0,  // 80: api.LaleService.MarkCardLearnt:output_type -> api.Card
+	0,  // 81: api.LaleService.ResetCard:output_type -> api.Card
+	0,  // 82: api.LaleService.SuspendCard:output_type -> api.Card
+	0,  // 83: api.LaleService.BuryCard:output_type -> api.Card
+	0,  // 84: api.LaleService.UpdateCardTags:output_type -> api.Card
+	0,  // 85: api.LaleService.SetCardDeck:output_type -> api.Card
+	33, // 86: api.LaleService.GetDecks:output_type -> api.GetDecksResponse
+	37, // 87: api.LaleService.UploadImage:output_type -> api.Image
+	37, // 88: api.LaleService.GetImage:output_type -> api.Image
+	39, // 89: api.LaleService.GetAudio:output_type -> api.AudioChunk
+	40, // 90: api.LaleService.GetUserProfile:output_type -> api.UserProfile
+	40, // 91: api.LaleService.UpdateUserProfile:output_type -> api.UserProfile
+	43, // 92: api.LaleService.UpdateDailyLimits:output_type -> api.DailyLimits
+	45, // 93: api.LaleService.UpdateGraduationPolicy:output_type -> api.GraduationPolicy
+	0,  // 94: api.LaleService.UndoLastReview:output_type -> api.Card
+	50, // 95: api.LaleService.GetReviewHistory:output_type -> api.GetReviewHistoryResponse
+	52, // 96: api.LaleService.OptimiseSchedulerParameters:output_type -> api.OptimiseSchedulerParametersResponse
+	66, // [66:97] is the sub-list for method output_type
+	35, // [35:66] is the sub-list for method input_type
+	35, // [35:35] is the sub-list for extension type_name
+	35, // [35:35] is the sub-list for extension extendee
+	0,  // [0:35] is the sub-list for field type_name
 }
 
 func init() { file_api_lale_service_proto_init() }
@@ -1431,13 +3850,14 @@ func file_api_lale_service_proto_init() {
 		return
 	}
 	file_api_lale_service_proto_msgTypes[0].OneofWrappers = []any{}
+	file_api_lale_service_proto_msgTypes[18].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_lale_service_proto_rawDesc), len(file_api_lale_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   22,
+			NumMessages:   54,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,18 +19,37 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	LaleService_InspectCard_FullMethodName           = "/api.LaleService/InspectCard"
-	LaleService_PromptCard_FullMethodName            = "/api.LaleService/PromptCard"
-	LaleService_CreateCard_FullMethodName            = "/api.LaleService/CreateCard"
-	LaleService_GetAllCards_FullMethodName           = "/api.LaleService/GetAllCards"
-	LaleService_UpdateCard_FullMethodName            = "/api.LaleService/UpdateCard"
-	LaleService_UpdateCardPerformance_FullMethodName = "/api.LaleService/UpdateCardPerformance"
-	LaleService_GetCardsToRepeat_FullMethodName      = "/api.LaleService/GetCardsToRepeat"
-	LaleService_GetCardsToLearn_FullMethodName       = "/api.LaleService/GetCardsToLearn"
-	LaleService_GetSentences_FullMethodName          = "/api.LaleService/GetSentences"
-	LaleService_GenerateStory_FullMethodName         = "/api.LaleService/GenerateStory"
-	LaleService_DeleteCard_FullMethodName            = "/api.LaleService/DeleteCard"
-	LaleService_MarkCardLearnt_FullMethodName        = "/api.LaleService/MarkCardLearnt"
+	LaleService_InspectCard_FullMethodName                 = "/api.LaleService/InspectCard"
+	LaleService_PromptCard_FullMethodName                  = "/api.LaleService/PromptCard"
+	LaleService_CreateCard_FullMethodName                  = "/api.LaleService/CreateCard"
+	LaleService_GetAllCards_FullMethodName                 = "/api.LaleService/GetAllCards"
+	LaleService_UpdateCard_FullMethodName                  = "/api.LaleService/UpdateCard"
+	LaleService_UpdateCardPerformance_FullMethodName       = "/api.LaleService/UpdateCardPerformance"
+	LaleService_GetCardsToRepeat_FullMethodName            = "/api.LaleService/GetCardsToRepeat"
+	LaleService_GetCardsToLearn_FullMethodName             = "/api.LaleService/GetCardsToLearn"
+	LaleService_GetLeeches_FullMethodName                  = "/api.LaleService/GetLeeches"
+	LaleService_GetCardsForCram_FullMethodName             = "/api.LaleService/GetCardsForCram"
+	LaleService_GetSentences_FullMethodName                = "/api.LaleService/GetSentences"
+	LaleService_GenerateStory_FullMethodName               = "/api.LaleService/GenerateStory"
+	LaleService_GetCard_FullMethodName                     = "/api.LaleService/GetCard"
+	LaleService_DeleteCard_FullMethodName                  = "/api.LaleService/DeleteCard"
+	LaleService_MarkCardLearnt_FullMethodName              = "/api.LaleService/MarkCardLearnt"
+	LaleService_ResetCard_FullMethodName                   = "/api.LaleService/ResetCard"
+	LaleService_SuspendCard_FullMethodName                 = "/api.LaleService/SuspendCard"
+	LaleService_BuryCard_FullMethodName                    = "/api.LaleService/BuryCard"
+	LaleService_UpdateCardTags_FullMethodName              = "/api.LaleService/UpdateCardTags"
+	LaleService_SetCardDeck_FullMethodName                 = "/api.LaleService/SetCardDeck"
+	LaleService_GetDecks_FullMethodName                    = "/api.LaleService/GetDecks"
+	LaleService_UploadImage_FullMethodName                 = "/api.LaleService/UploadImage"
+	LaleService_GetImage_FullMethodName                    = "/api.LaleService/GetImage"
+	LaleService_GetAudio_FullMethodName                    = "/api.LaleService/GetAudio"
+	LaleService_GetUserProfile_FullMethodName              = "/api.LaleService/GetUserProfile"
+	LaleService_UpdateUserProfile_FullMethodName           = "/api.LaleService/UpdateUserProfile"
+	LaleService_UpdateDailyLimits_FullMethodName           = "/api.LaleService/UpdateDailyLimits"
+	LaleService_UpdateGraduationPolicy_FullMethodName      = "/api.LaleService/UpdateGraduationPolicy"
+	LaleService_UndoLastReview_FullMethodName              = "/api.LaleService/UndoLastReview"
+	LaleService_GetReviewHistory_FullMethodName            = "/api.LaleService/GetReviewHistory"
+	LaleService_OptimiseSchedulerParameters_FullMethodName = "/api.LaleService/OptimiseSchedulerParameters"
 )
 
 // LaleServiceClient is the client API for LaleService service.
@@ -45,10 +64,30 @@ type LaleServiceClient interface {
 	UpdateCardPerformance(ctx context.Context, in *UpdateCardPerformanceRequest, opts ...grpc.CallOption) (*UpdateCardPerformanceResponse, error)
 	GetCardsToRepeat(ctx context.Context, in *GetCardsRequest, opts ...grpc.CallOption) (*GetCardsResponse, error)
 	GetCardsToLearn(ctx context.Context, in *GetCardsRequest, opts ...grpc.CallOption) (*GetCardsResponse, error)
+	// the cards lapsed the number of times set by the leech threshold, the most lapsed first
+	GetLeeches(ctx context.Context, in *GetCardsRequest, opts ...grpc.CallOption) (*GetCardsResponse, error)
+	GetCardsForCram(ctx context.Context, in *GetCardsForCramRequest, opts ...grpc.CallOption) (*GetCardsResponse, error)
 	GetSentences(ctx context.Context, in *GetSentencesRequest, opts ...grpc.CallOption) (*GetSentencesResponse, error)
 	GenerateStory(ctx context.Context, in *GenerateStoryRequest, opts ...grpc.CallOption) (*GenerateStoryResponse, error)
+	GetCard(ctx context.Context, in *GetCardRequest, opts ...grpc.CallOption) (*Card, error)
 	DeleteCard(ctx context.Context, in *DeleteCardRequest, opts ...grpc.CallOption) (*Card, error)
 	MarkCardLearnt(ctx context.Context, in *MarkCardLearntRequest, opts ...grpc.CallOption) (*Card, error)
+	ResetCard(ctx context.Context, in *ResetCardRequest, opts ...grpc.CallOption) (*Card, error)
+	SuspendCard(ctx context.Context, in *SuspendCardRequest, opts ...grpc.CallOption) (*Card, error)
+	BuryCard(ctx context.Context, in *BuryCardRequest, opts ...grpc.CallOption) (*Card, error)
+	UpdateCardTags(ctx context.Context, in *UpdateCardTagsRequest, opts ...grpc.CallOption) (*Card, error)
+	SetCardDeck(ctx context.Context, in *SetCardDeckRequest, opts ...grpc.CallOption) (*Card, error)
+	GetDecks(ctx context.Context, in *GetDecksRequest, opts ...grpc.CallOption) (*GetDecksResponse, error)
+	UploadImage(ctx context.Context, in *UploadImageRequest, opts ...grpc.CallOption) (*Image, error)
+	GetImage(ctx context.Context, in *GetImageRequest, opts ...grpc.CallOption) (*Image, error)
+	GetAudio(ctx context.Context, in *GetAudioRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AudioChunk], error)
+	GetUserProfile(ctx context.Context, in *GetUserProfileRequest, opts ...grpc.CallOption) (*UserProfile, error)
+	UpdateUserProfile(ctx context.Context, in *UpdateUserProfileRequest, opts ...grpc.CallOption) (*UserProfile, error)
+	UpdateDailyLimits(ctx context.Context, in *UpdateDailyLimitsRequest, opts ...grpc.CallOption) (*DailyLimits, error)
+	UpdateGraduationPolicy(ctx context.Context, in *UpdateGraduationPolicyRequest, opts ...grpc.CallOption) (*GraduationPolicy, error)
+	UndoLastReview(ctx context.Context, in *UndoLastReviewRequest, opts ...grpc.CallOption) (*Card, error)
+	GetReviewHistory(ctx context.Context, in *GetReviewHistoryRequest, opts ...grpc.CallOption) (*GetReviewHistoryResponse, error)
+	OptimiseSchedulerParameters(ctx context.Context, in *OptimiseSchedulerParametersRequest, opts ...grpc.CallOption) (*OptimiseSchedulerParametersResponse, error)
 }
 
 type laleServiceClient struct {
@@ -139,6 +178,26 @@ func (c *laleServiceClient) GetCardsToLearn(ctx context.Context, in *GetCardsReq
 	return out, nil
 }
 
+func (c *laleServiceClient) GetLeeches(ctx context.Context, in *GetCardsRequest, opts ...grpc.CallOption) (*GetCardsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCardsResponse)
+	err := c.cc.Invoke(ctx, LaleService_GetLeeches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *laleServiceClient) GetCardsForCram(ctx context.Context, in *GetCardsForCramRequest, opts ...grpc.CallOption) (*GetCardsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCardsResponse)
+	err := c.cc.Invoke(ctx, LaleService_GetCardsForCram_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *laleServiceClient) GetSentences(ctx context.Context, in *GetSentencesRequest, opts ...grpc.CallOption) (*GetSentencesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetSentencesResponse)
@@ -159,6 +218,16 @@ func (c *laleServiceClient) GenerateStory(ctx context.Context, in *GenerateStory
 	return out, nil
 }
 
+func (c *laleServiceClient) GetCard(ctx context.Context, in *GetCardRequest, opts ...grpc.CallOption) (*Card, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Card)
+	err := c.cc.Invoke(ctx, LaleService_GetCard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *laleServiceClient) DeleteCard(ctx context.Context, in *DeleteCardRequest, opts ...grpc.CallOption) (*Card, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Card)
@@ -179,6 +248,175 @@ func (c *laleServiceClient) MarkCardLearnt(ctx context.Context, in *MarkCardLear
 	return out, nil
 }
 
+func (c *laleServiceClient) ResetCard(ctx context.Context, in *ResetCardRequest, opts ...grpc.CallOption) (*Card, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Card)
+	err := c.cc.Invoke(ctx, LaleService_ResetCard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *laleServiceClient) SuspendCard(ctx context.Context, in *SuspendCardRequest, opts ...grpc.CallOption) (*Card, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Card)
+	err := c.cc.Invoke(ctx, LaleService_SuspendCard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *laleServiceClient) BuryCard(ctx context.Context, in *BuryCardRequest, opts ...grpc.CallOption) (*Card, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Card)
+	err := c.cc.Invoke(ctx, LaleService_BuryCard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *laleServiceClient) UpdateCardTags(ctx context.Context, in *UpdateCardTagsRequest, opts ...grpc.CallOption) (*Card, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Card)
+	err := c.cc.Invoke(ctx, LaleService_UpdateCardTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *laleServiceClient) SetCardDeck(ctx context.Context, in *SetCardDeckRequest, opts ...grpc.CallOption) (*Card, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Card)
+	err := c.cc.Invoke(ctx, LaleService_SetCardDeck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *laleServiceClient) GetDecks(ctx context.Context, in *GetDecksRequest, opts ...grpc.CallOption) (*GetDecksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDecksResponse)
+	err := c.cc.Invoke(ctx, LaleService_GetDecks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *laleServiceClient) UploadImage(ctx context.Context, in *UploadImageRequest, opts ...grpc.CallOption) (*Image, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Image)
+	err := c.cc.Invoke(ctx, LaleService_UploadImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *laleServiceClient) GetImage(ctx context.Context, in *GetImageRequest, opts ...grpc.CallOption) (*Image, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Image)
+	err := c.cc.Invoke(ctx, LaleService_GetImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *laleServiceClient) GetAudio(ctx context.Context, in *GetAudioRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AudioChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LaleService_ServiceDesc.Streams[0], LaleService_GetAudio_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetAudioRequest, AudioChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LaleService_GetAudioClient = grpc.ServerStreamingClient[AudioChunk]
+
+func (c *laleServiceClient) GetUserProfile(ctx context.Context, in *GetUserProfileRequest, opts ...grpc.CallOption) (*UserProfile, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserProfile)
+	err := c.cc.Invoke(ctx, LaleService_GetUserProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *laleServiceClient) UpdateUserProfile(ctx context.Context, in *UpdateUserProfileRequest, opts ...grpc.CallOption) (*UserProfile, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserProfile)
+	err := c.cc.Invoke(ctx, LaleService_UpdateUserProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *laleServiceClient) UpdateDailyLimits(ctx context.Context, in *UpdateDailyLimitsRequest, opts ...grpc.CallOption) (*DailyLimits, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DailyLimits)
+	err := c.cc.Invoke(ctx, LaleService_UpdateDailyLimits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *laleServiceClient) UpdateGraduationPolicy(ctx context.Context, in *UpdateGraduationPolicyRequest, opts ...grpc.CallOption) (*GraduationPolicy, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GraduationPolicy)
+	err := c.cc.Invoke(ctx, LaleService_UpdateGraduationPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *laleServiceClient) UndoLastReview(ctx context.Context, in *UndoLastReviewRequest, opts ...grpc.CallOption) (*Card, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Card)
+	err := c.cc.Invoke(ctx, LaleService_UndoLastReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *laleServiceClient) GetReviewHistory(ctx context.Context, in *GetReviewHistoryRequest, opts ...grpc.CallOption) (*GetReviewHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReviewHistoryResponse)
+	err := c.cc.Invoke(ctx, LaleService_GetReviewHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *laleServiceClient) OptimiseSchedulerParameters(ctx context.Context, in *OptimiseSchedulerParametersRequest, opts ...grpc.CallOption) (*OptimiseSchedulerParametersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OptimiseSchedulerParametersResponse)
+	err := c.cc.Invoke(ctx, LaleService_OptimiseSchedulerParameters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LaleServiceServer is the server API for LaleService service.
 // All implementations must embed UnimplementedLaleServiceServer
 // for forward compatibility.
@@ -191,10 +429,30 @@ type LaleServiceServer interface {
 	UpdateCardPerformance(context.Context, *UpdateCardPerformanceRequest) (*UpdateCardPerformanceResponse, error)
 	GetCardsToRepeat(context.Context, *GetCardsRequest) (*GetCardsResponse, error)
 	GetCardsToLearn(context.Context, *GetCardsRequest) (*GetCardsResponse, error)
+	// the cards lapsed the number of times set by the leech threshold, the most lapsed first
+	GetLeeches(context.Context, *GetCardsRequest) (*GetCardsResponse, error)
+	GetCardsForCram(context.Context, *GetCardsForCramRequest) (*GetCardsResponse, error)
 	GetSentences(context.Context, *GetSentencesRequest) (*GetSentencesResponse, error)
 	GenerateStory(context.Context, *GenerateStoryRequest) (*GenerateStoryResponse, error)
+	GetCard(context.Context, *GetCardRequest) (*Card, error)
 	DeleteCard(context.Context, *DeleteCardRequest) (*Card, error)
 	MarkCardLearnt(context.Context, *MarkCardLearntRequest) (*Card, error)
+	ResetCard(context.Context, *ResetCardRequest) (*Card, error)
+	SuspendCard(context.Context, *SuspendCardRequest) (*Card, error)
+	BuryCard(context.Context, *BuryCardRequest) (*Card, error)
+	UpdateCardTags(context.Context, *UpdateCardTagsRequest) (*Card, error)
+	SetCardDeck(context.Context, *SetCardDeckRequest) (*Card, error)
+	GetDecks(context.Context, *GetDecksRequest) (*GetDecksResponse, error)
+	UploadImage(context.Context, *UploadImageRequest) (*Image, error)
+	GetImage(context.Context, *GetImageRequest) (*Image, error)
+	GetAudio(*GetAudioRequest, grpc.ServerStreamingServer[AudioChunk]) error
+	GetUserProfile(context.Context, *GetUserProfileRequest) (*UserProfile, error)
+	UpdateUserProfile(context.Context, *UpdateUserProfileRequest) (*UserProfile, error)
+	UpdateDailyLimits(context.Context, *UpdateDailyLimitsRequest) (*DailyLimits, error)
+	UpdateGraduationPolicy(context.Context, *UpdateGraduationPolicyRequest) (*GraduationPolicy, error)
+	UndoLastReview(context.Context, *UndoLastReviewRequest) (*Card, error)
+	GetReviewHistory(context.Context, *GetReviewHistoryRequest) (*GetReviewHistoryResponse, error)
+	OptimiseSchedulerParameters(context.Context, *OptimiseSchedulerParametersRequest) (*OptimiseSchedulerParametersResponse, error)
 	mustEmbedUnimplementedLaleServiceServer()
 }
 
@@ -229,18 +487,75 @@ func (UnimplementedLaleServiceServer) GetCardsToRepeat(context.Context, *GetCard
 func (UnimplementedLaleServiceServer) GetCardsToLearn(context.Context, *GetCardsRequest) (*GetCardsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetCardsToLearn not implemented")
 }
+func (UnimplementedLaleServiceServer) GetLeeches(context.Context, *GetCardsRequest) (*GetCardsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLeeches not implemented")
+}
+func (UnimplementedLaleServiceServer) GetCardsForCram(context.Context, *GetCardsForCramRequest) (*GetCardsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCardsForCram not implemented")
+}
 func (UnimplementedLaleServiceServer) GetSentences(context.Context, *GetSentencesRequest) (*GetSentencesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetSentences not implemented")
 }
 func (UnimplementedLaleServiceServer) GenerateStory(context.Context, *GenerateStoryRequest) (*GenerateStoryResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GenerateStory not implemented")
 }
+func (UnimplementedLaleServiceServer) GetCard(context.Context, *GetCardRequest) (*Card, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCard not implemented")
+}
 func (UnimplementedLaleServiceServer) DeleteCard(context.Context, *DeleteCardRequest) (*Card, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteCard not implemented")
 }
 func (UnimplementedLaleServiceServer) MarkCardLearnt(context.Context, *MarkCardLearntRequest) (*Card, error) {
 	return nil, status.Error(codes.Unimplemented, "method MarkCardLearnt not implemented")
 }
+func (UnimplementedLaleServiceServer) ResetCard(context.Context, *ResetCardRequest) (*Card, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResetCard not implemented")
+}
+func (UnimplementedLaleServiceServer) SuspendCard(context.Context, *SuspendCardRequest) (*Card, error) {
+	return nil, status.Error(codes.Unimplemented, "method SuspendCard not implemented")
+}
+func (UnimplementedLaleServiceServer) BuryCard(context.Context, *BuryCardRequest) (*Card, error) {
+	return nil, status.Error(codes.Unimplemented, "method BuryCard not implemented")
+}
+func (UnimplementedLaleServiceServer) UpdateCardTags(context.Context, *UpdateCardTagsRequest) (*Card, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateCardTags not implemented")
+}
+func (UnimplementedLaleServiceServer) SetCardDeck(context.Context, *SetCardDeckRequest) (*Card, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetCardDeck not implemented")
+}
+func (UnimplementedLaleServiceServer) GetDecks(context.Context, *GetDecksRequest) (*GetDecksResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDecks not implemented")
+}
+func (UnimplementedLaleServiceServer) UploadImage(context.Context, *UploadImageRequest) (*Image, error) {
+	return nil, status.Error(codes.Unimplemented, "method UploadImage not implemented")
+}
+func (UnimplementedLaleServiceServer) GetImage(context.Context, *GetImageRequest) (*Image, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetImage not implemented")
+}
+func (UnimplementedLaleServiceServer) GetAudio(*GetAudioRequest, grpc.ServerStreamingServer[AudioChunk]) error {
+	return status.Error(codes.Unimplemented, "method GetAudio not implemented")
+}
+func (UnimplementedLaleServiceServer) GetUserProfile(context.Context, *GetUserProfileRequest) (*UserProfile, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUserProfile not implemented")
+}
+func (UnimplementedLaleServiceServer) UpdateUserProfile(context.Context, *UpdateUserProfileRequest) (*UserProfile, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateUserProfile not implemented")
+}
+func (UnimplementedLaleServiceServer) UpdateDailyLimits(context.Context, *UpdateDailyLimitsRequest) (*DailyLimits, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateDailyLimits not implemented")
+}
+func (UnimplementedLaleServiceServer) UpdateGraduationPolicy(context.Context, *UpdateGraduationPolicyRequest) (*GraduationPolicy, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateGraduationPolicy not implemented")
+}
+func (UnimplementedLaleServiceServer) UndoLastReview(context.Context, *UndoLastReviewRequest) (*Card, error) {
+	return nil, status.Error(codes.Unimplemented, "method UndoLastReview not implemented")
+}
+func (UnimplementedLaleServiceServer) GetReviewHistory(context.Context, *GetReviewHistoryRequest) (*GetReviewHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetReviewHistory not implemented")
+}
+func (UnimplementedLaleServiceServer) OptimiseSchedulerParameters(context.Context, *OptimiseSchedulerParametersRequest) (*OptimiseSchedulerParametersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method OptimiseSchedulerParameters not implemented")
+}
 func (UnimplementedLaleServiceServer) mustEmbedUnimplementedLaleServiceServer() {}
 func (UnimplementedLaleServiceServer) testEmbeddedByValue()                     {}
 
@@ -406,6 +721,42 @@ func _LaleService_GetCardsToLearn_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LaleService_GetLeeches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LaleServiceServer).GetLeeches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LaleService_GetLeeches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LaleServiceServer).GetLeeches(ctx, req.(*GetCardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LaleService_GetCardsForCram_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCardsForCramRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LaleServiceServer).GetCardsForCram(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LaleService_GetCardsForCram_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LaleServiceServer).GetCardsForCram(ctx, req.(*GetCardsForCramRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _LaleService_GetSentences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetSentencesRequest)
 	if err := dec(in); err != nil {
@@ -442,6 +793,24 @@ func _LaleService_GenerateStory_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LaleService_GetCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LaleServiceServer).GetCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LaleService_GetCard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LaleServiceServer).GetCard(ctx, req.(*GetCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _LaleService_DeleteCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteCardRequest)
 	if err := dec(in); err != nil {
@@ -478,6 +847,287 @@ func _LaleService_MarkCardLearnt_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LaleService_ResetCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LaleServiceServer).ResetCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LaleService_ResetCard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LaleServiceServer).ResetCard(ctx, req.(*ResetCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LaleService_SuspendCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuspendCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LaleServiceServer).SuspendCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LaleService_SuspendCard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LaleServiceServer).SuspendCard(ctx, req.(*SuspendCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LaleService_BuryCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuryCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LaleServiceServer).BuryCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LaleService_BuryCard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LaleServiceServer).BuryCard(ctx, req.(*BuryCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LaleService_UpdateCardTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCardTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LaleServiceServer).UpdateCardTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LaleService_UpdateCardTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LaleServiceServer).UpdateCardTags(ctx, req.(*UpdateCardTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LaleService_SetCardDeck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCardDeckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LaleServiceServer).SetCardDeck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LaleService_SetCardDeck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LaleServiceServer).SetCardDeck(ctx, req.(*SetCardDeckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LaleService_GetDecks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDecksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LaleServiceServer).GetDecks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LaleService_GetDecks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LaleServiceServer).GetDecks(ctx, req.(*GetDecksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LaleService_UploadImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LaleServiceServer).UploadImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LaleService_UploadImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LaleServiceServer).UploadImage(ctx, req.(*UploadImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LaleService_GetImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LaleServiceServer).GetImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LaleService_GetImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LaleServiceServer).GetImage(ctx, req.(*GetImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LaleService_GetAudio_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetAudioRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LaleServiceServer).GetAudio(m, &grpc.GenericServerStream[GetAudioRequest, AudioChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LaleService_GetAudioServer = grpc.ServerStreamingServer[AudioChunk]
+
+func _LaleService_GetUserProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LaleServiceServer).GetUserProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LaleService_GetUserProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LaleServiceServer).GetUserProfile(ctx, req.(*GetUserProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LaleService_UpdateUserProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LaleServiceServer).UpdateUserProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LaleService_UpdateUserProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LaleServiceServer).UpdateUserProfile(ctx, req.(*UpdateUserProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LaleService_UpdateDailyLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDailyLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LaleServiceServer).UpdateDailyLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LaleService_UpdateDailyLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LaleServiceServer).UpdateDailyLimits(ctx, req.(*UpdateDailyLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LaleService_UpdateGraduationPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGraduationPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LaleServiceServer).UpdateGraduationPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LaleService_UpdateGraduationPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LaleServiceServer).UpdateGraduationPolicy(ctx, req.(*UpdateGraduationPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LaleService_UndoLastReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndoLastReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LaleServiceServer).UndoLastReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LaleService_UndoLastReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LaleServiceServer).UndoLastReview(ctx, req.(*UndoLastReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LaleService_GetReviewHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReviewHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LaleServiceServer).GetReviewHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LaleService_GetReviewHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LaleServiceServer).GetReviewHistory(ctx, req.(*GetReviewHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LaleService_OptimiseSchedulerParameters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OptimiseSchedulerParametersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LaleServiceServer).OptimiseSchedulerParameters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LaleService_OptimiseSchedulerParameters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LaleServiceServer).OptimiseSchedulerParameters(ctx, req.(*OptimiseSchedulerParametersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // LaleService_ServiceDesc is the grpc.ServiceDesc for LaleService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -517,6 +1167,14 @@ var LaleService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetCardsToLearn",
 			Handler:    _LaleService_GetCardsToLearn_Handler,
 		},
+		{
+			MethodName: "GetLeeches",
+			Handler:    _LaleService_GetLeeches_Handler,
+		},
+		{
+			MethodName: "GetCardsForCram",
+			Handler:    _LaleService_GetCardsForCram_Handler,
+		},
 		{
 			MethodName: "GetSentences",
 			Handler:    _LaleService_GetSentences_Handler,
@@ -525,6 +1183,10 @@ var LaleService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GenerateStory",
 			Handler:    _LaleService_GenerateStory_Handler,
 		},
+		{
+			MethodName: "GetCard",
+			Handler:    _LaleService_GetCard_Handler,
+		},
 		{
 			MethodName: "DeleteCard",
 			Handler:    _LaleService_DeleteCard_Handler,
@@ -533,7 +1195,73 @@ var LaleService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "MarkCardLearnt",
 			Handler:    _LaleService_MarkCardLearnt_Handler,
 		},
+		{
+			MethodName: "ResetCard",
+			Handler:    _LaleService_ResetCard_Handler,
+		},
+		{
+			MethodName: "SuspendCard",
+			Handler:    _LaleService_SuspendCard_Handler,
+		},
+		{
+			MethodName: "BuryCard",
+			Handler:    _LaleService_BuryCard_Handler,
+		},
+		{
+			MethodName: "UpdateCardTags",
+			Handler:    _LaleService_UpdateCardTags_Handler,
+		},
+		{
+			MethodName: "SetCardDeck",
+			Handler:    _LaleService_SetCardDeck_Handler,
+		},
+		{
+			MethodName: "GetDecks",
+			Handler:    _LaleService_GetDecks_Handler,
+		},
+		{
+			MethodName: "UploadImage",
+			Handler:    _LaleService_UploadImage_Handler,
+		},
+		{
+			MethodName: "GetImage",
+			Handler:    _LaleService_GetImage_Handler,
+		},
+		{
+			MethodName: "GetUserProfile",
+			Handler:    _LaleService_GetUserProfile_Handler,
+		},
+		{
+			MethodName: "UpdateUserProfile",
+			Handler:    _LaleService_UpdateUserProfile_Handler,
+		},
+		{
+			MethodName: "UpdateDailyLimits",
+			Handler:    _LaleService_UpdateDailyLimits_Handler,
+		},
+		{
+			MethodName: "UpdateGraduationPolicy",
+			Handler:    _LaleService_UpdateGraduationPolicy_Handler,
+		},
+		{
+			MethodName: "UndoLastReview",
+			Handler:    _LaleService_UndoLastReview_Handler,
+		},
+		{
+			MethodName: "GetReviewHistory",
+			Handler:    _LaleService_GetReviewHistory_Handler,
+		},
+		{
+			MethodName: "OptimiseSchedulerParameters",
+			Handler:    _LaleService_OptimiseSchedulerParameters_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetAudio",
+			Handler:       _LaleService_GetAudio_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/lale-service.proto",
 }
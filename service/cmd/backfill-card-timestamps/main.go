@@ -0,0 +1,60 @@
+// Backfill-card-timestamps is a one-off migration setting the created, updated and started learning times
+// of the cards stored before the timestamps have been tracked. The times are derived from the object IDs
+// of the card documents, the review log and the cards' schedule.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/genvmoroz/lale/service/internal/observability"
+	"github.com/genvmoroz/lale/service/internal/options"
+	"github.com/genvmoroz/lale/service/internal/repo/card"
+	"github.com/genvmoroz/lale/service/internal/repo/review"
+	"github.com/kelseyhightower/envconfig"
+	"github.com/sirupsen/logrus"
+)
+
+// config is the part of the service config the migration reads the cards and the review log with.
+type config struct {
+	CardRepo   card.Config
+	ReviewRepo options.ReviewRepoConfig
+}
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	updated, err := run(ctx)
+	if err != nil {
+		logrus.Fatalf("backfill card timestamps: %s", err.Error())
+	}
+
+	logrus.Infof("backfilled the timestamps of %d cards", updated)
+}
+
+func run(ctx context.Context) (int, error) {
+	var cfg config
+	if err := envconfig.Process("APP", &cfg); err != nil {
+		return 0, fmt.Errorf("load config: %w", err)
+	}
+
+	metrics := observability.NewMetrics(observability.DefaultConfig())
+	client, err := card.NewClient(ctx, cfg.CardRepo, metrics.Mongo)
+	if err != nil {
+		return 0, fmt.Errorf("create mongo client: %w", err)
+	}
+
+	firstReviews, err := review.NewRepo(client, review.Config{
+		Database:   cfg.CardRepo.Database,
+		Collection: cfg.ReviewRepo.Collection,
+	}).GetFirstReviewTimes(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get first review times: %w", err)
+	}
+
+	return card.NewRepo(client, cfg.CardRepo).BackfillTimestamps(ctx, firstReviews)
+}
@@ -0,0 +1,78 @@
+// Dedupe-card-words is a one-off migration merging the cards of a user in a language sharing a word, so the unique
+// word index the service creates at startup can be built. Run it with -dry-run first to review the merges.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/genvmoroz/lale/service/internal/observability"
+	"github.com/genvmoroz/lale/service/internal/repo/card"
+	"github.com/genvmoroz/lale/service/pkg/entity"
+	"github.com/kelseyhightower/envconfig"
+	"github.com/sirupsen/logrus"
+)
+
+// config is the part of the service config the migration reads the cards with.
+type config struct {
+	CardRepo card.Config
+}
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "report the merges without changing the cards")
+	flag.Parse()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	merges, err := run(ctx, *dryRun)
+	for _, merge := range merges {
+		logrus.Infof("card %s (%v) of user %s takes %s",
+			merge.Kept.ID, words(merge.Kept), merge.Kept.UserID, describe(merge.Merged))
+	}
+	if err != nil {
+		logrus.Fatalf("dedupe card words: %s", err.Error())
+	}
+
+	if *dryRun {
+		logrus.Infof("%d merges to be done, nothing has been changed", len(merges))
+		return
+	}
+	logrus.Infof("done %d merges", len(merges))
+}
+
+func run(ctx context.Context, dryRun bool) ([]card.Merge, error) {
+	var cfg config
+	if err := envconfig.Process("APP", &cfg); err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+
+	metrics := observability.NewMetrics(observability.DefaultConfig())
+	client, err := card.NewClient(ctx, cfg.CardRepo, metrics.Mongo)
+	if err != nil {
+		return nil, fmt.Errorf("create mongo client: %w", err)
+	}
+
+	return card.NewRepo(client, cfg.CardRepo).DedupeWords(ctx, dryRun)
+}
+
+func describe(cards []entity.Card) string {
+	out := make([]string, 0, len(cards))
+	for _, c := range cards {
+		out = append(out, fmt.Sprintf("%s (%v)", c.ID, words(c)))
+	}
+	return strings.Join(out, ", ")
+}
+
+func words(c entity.Card) []string {
+	out := make([]string, 0, len(c.WordInformationList))
+	for _, word := range c.WordInformationList {
+		out = append(out, word.Word)
+	}
+	return out
+}
@@ -0,0 +1,60 @@
+// Move-card-audio is a one-off migration moving the audio embedded in the words of the cards to the audio store.
+// The words refer to the audio by the content-addressed IDs afterwards, so the words sounding the same share it.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/genvmoroz/lale/service/internal/dependency"
+	"github.com/genvmoroz/lale/service/internal/observability"
+	"github.com/genvmoroz/lale/service/internal/options"
+	"github.com/genvmoroz/lale/service/internal/repo/card"
+	"github.com/genvmoroz/lale/service/pkg/entity"
+	"github.com/kelseyhightower/envconfig"
+	"github.com/sirupsen/logrus"
+)
+
+// config is the part of the service config the migration reads the cards and stores the audio with.
+type config struct {
+	CardRepo   card.Config
+	AudioStore options.AudioStoreConfig
+}
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	updated, err := run(ctx)
+	if err != nil {
+		logrus.Fatalf("move card audio: %s", err.Error())
+	}
+
+	logrus.Infof("moved the audio of %d cards", updated)
+}
+
+func run(ctx context.Context) (int, error) {
+	var cfg config
+	if err := envconfig.Process("APP", &cfg); err != nil {
+		return 0, fmt.Errorf("load config: %w", err)
+	}
+
+	metrics := observability.NewMetrics(observability.DefaultConfig())
+	client, err := card.NewClient(ctx, cfg.CardRepo, metrics.Mongo)
+	if err != nil {
+		return 0, fmt.Errorf("create mongo client: %w", err)
+	}
+
+	audioStore, err := dependency.NewAudioStore(cfg.AudioStore, client.Database(cfg.CardRepo.Database))
+	if err != nil {
+		return 0, fmt.Errorf("create audio store: %w", err)
+	}
+
+	return card.NewRepo(client, cfg.CardRepo).MoveAudio(ctx, func(ctx context.Context, data []byte) (string, error) {
+		id := entity.AudioID(data)
+		return id, audioStore.Put(ctx, id, data)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	_ "time/tzdata" // user time zones are resolved without relying on the tzdata of the image
 
 	"github.com/genvmoroz/lale/service/internal/dependency"
 	"github.com/genvmoroz/lale/service/internal/grpc"
@@ -0,0 +1,227 @@
+// Simulator compares the scheduling algorithms on the cards and the review log of a user: it simulates
+// the user reviewing the cards for a number of days under every algorithm and prints the daily workload,
+// the expected retention and the backlog as CSV or JSON.
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"syscall"
+	"time"
+	_ "time/tzdata" // user time zones are resolved without relying on the tzdata of the image
+
+	"github.com/genvmoroz/lale/service/internal/algo"
+	"github.com/genvmoroz/lale/service/internal/observability"
+	"github.com/genvmoroz/lale/service/internal/options"
+	"github.com/genvmoroz/lale/service/internal/repo/card"
+	"github.com/genvmoroz/lale/service/internal/repo/review"
+	"github.com/genvmoroz/lale/service/internal/repo/user"
+	"github.com/genvmoroz/lale/service/internal/simulator"
+	"github.com/genvmoroz/lale/service/pkg/entity"
+	"github.com/kelseyhightower/envconfig"
+	"github.com/spf13/cobra"
+)
+
+type (
+	// config is the part of the service config the simulator reads the user's data with.
+	config struct {
+		CardRepo   card.Config
+		UserRepo   options.UserRepoConfig
+		ReviewRepo options.ReviewRepoConfig
+	}
+
+	flags struct {
+		userID           string
+		algorithms       []string
+		days             int
+		newCardsPerDay   int
+		maxReviewsPerDay int
+		desiredRetention float64
+		seed             uint64
+		format           string
+	}
+
+	// result is the simulation of an algorithm.
+	result struct {
+		Algorithm string          `json:"algorithm"`
+		Days      []simulator.Day `json:"days"`
+	}
+)
+
+const (
+	formatCSV  = "csv"
+	formatJSON = "json"
+
+	defaultDays             = 365
+	defaultNewCardsPerDay   = 20
+	defaultDesiredRetention = 0.9
+	defaultSeed             = 1
+)
+
+// schedulers return the algorithms the simulator compares, an alternative AnkiAlgo implementation
+// is compared by adding it here.
+func schedulers(desiredRetention float64) map[string]simulator.NewScheduler {
+	return map[string]simulator.NewScheduler{
+		algo.AlgorithmAnki: func(now func() time.Time) simulator.Scheduler {
+			return algo.NewAnki(now, desiredRetention)
+		},
+		algo.AlgorithmFSRS: func(now func() time.Time) simulator.Scheduler {
+			return algo.NewFSRS(now, desiredRetention)
+		},
+	}
+}
+
+func main() {
+	var f flags
+	cmd := &cobra.Command{
+		Use:   "simulator",
+		Short: "Simulate the reviews of a user's cards under the scheduling algorithms",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return run(cmd.Context(), f, cmd.OutOrStdout())
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&f.userID, "user", "", "ID of the user whose cards are simulated")
+	cmd.Flags().StringSliceVar(&f.algorithms, "algorithms", []string{algo.AlgorithmAnki, algo.AlgorithmFSRS},
+		"Algorithms to compare")
+	cmd.Flags().IntVar(&f.days, "days", defaultDays, "Number of days to simulate")
+	cmd.Flags().IntVar(&f.newCardsPerDay, "new-per-day", defaultNewCardsPerDay, "New cards introduced per day")
+	cmd.Flags().IntVar(&f.maxReviewsPerDay, "max-reviews-per-day", 0, "Due cards reviewed per day, 0 is unlimited")
+	cmd.Flags().Float64Var(&f.desiredRetention, "desired-retention", defaultDesiredRetention,
+		"Desired retention the algorithms schedule at")
+	cmd.Flags().Uint64Var(&f.seed, "seed", defaultSeed, "Seed of the simulated recalls")
+	cmd.Flags().StringVar(&f.format, "format", formatCSV, "Output format, csv or json")
+	if err := cmd.MarkFlagRequired("user"); err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if err := cmd.ExecuteContext(ctx); err != nil {
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, f flags, out io.Writer) error {
+	if f.format != formatCSV && f.format != formatJSON {
+		return fmt.Errorf("unknown format: %s", f.format)
+	}
+	available := schedulers(f.desiredRetention)
+	for _, name := range f.algorithms {
+		if _, ok := available[name]; !ok {
+			return fmt.Errorf("unknown algorithm: %s", name)
+		}
+	}
+
+	u, cards, reviews, err := loadUserData(ctx, f.userID)
+	if err != nil {
+		return err
+	}
+
+	cfg := simulator.Config{
+		Days:             f.days,
+		NewCardsPerDay:   f.newCardsPerDay,
+		MaxReviewsPerDay: f.maxReviewsPerDay,
+		Start:            u.Profile.StartOfDay(time.Now()),
+	}
+	recall := algo.NewRecallModel(u.SchedulerParameters)
+
+	results := make([]result, 0, len(f.algorithms))
+	for _, name := range f.algorithms {
+		// every algorithm is simulated with the same recalls drawn
+		random := rand.New(rand.NewPCG(f.seed, f.seed)) //nolint:gosec // reproducible simulation, not security
+		s := simulator.NewSimulator(cfg, recall, random.Float64)
+		results = append(results, result{
+			Algorithm: name,
+			Days:      s.Simulate(u, slices.Clone(cards), reviews, available[name]),
+		})
+	}
+
+	if f.format == formatJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(results)
+	}
+	return writeCSV(out, results)
+}
+
+// loadUserData reads the user, the user's cards and the review log from the repos of the service.
+func loadUserData(ctx context.Context, userID string) (entity.User, []entity.Card, []entity.Review, error) {
+	var cfg config
+	if err := envconfig.Process("APP", &cfg); err != nil {
+		return entity.User{}, nil, nil, fmt.Errorf("load config: %w", err)
+	}
+
+	metrics := observability.NewMetrics(observability.DefaultConfig())
+	client, err := card.NewClient(ctx, cfg.CardRepo, metrics.Mongo)
+	if err != nil {
+		return entity.User{}, nil, nil, fmt.Errorf("create mongo client: %w", err)
+	}
+
+	u, found, err := user.NewRepo(client, user.Config{
+		Database:   cfg.CardRepo.Database,
+		Collection: cfg.UserRepo.Collection,
+	}).GetUser(ctx, userID)
+	if err != nil {
+		return entity.User{}, nil, nil, fmt.Errorf("get user: %w", err)
+	}
+	if !found {
+		u = entity.User{ID: userID}
+	}
+
+	cards, err := card.NewRepo(client, cfg.CardRepo).GetCardsForUser(ctx, userID)
+	if err != nil {
+		return entity.User{}, nil, nil, fmt.Errorf("get cards: %w", err)
+	}
+	if len(cards) == 0 {
+		return entity.User{}, nil, nil, errors.New("the user has no cards")
+	}
+
+	reviews, err := review.NewRepo(client, review.Config{
+		Database:   cfg.CardRepo.Database,
+		Collection: cfg.ReviewRepo.Collection,
+	}).GetReviewsForUser(ctx, userID, time.Time{})
+	if err != nil {
+		return entity.User{}, nil, nil, fmt.Errorf("get reviews: %w", err)
+	}
+
+	return u, cards, reviews, nil
+}
+
+func writeCSV(out io.Writer, results []result) error {
+	w := csv.NewWriter(out)
+	header := []string{"algorithm", "day", "date", "new_cards", "reviews", "lapses", "backlog", "expected_retention"}
+	if err := w.Write(header); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+	for _, r := range results {
+		for _, d := range r.Days {
+			record := []string{
+				r.Algorithm,
+				strconv.Itoa(d.Day),
+				d.Date.Format(time.DateOnly),
+				strconv.Itoa(d.NewCards),
+				strconv.Itoa(d.Reviews),
+				strconv.Itoa(d.Lapses),
+				strconv.Itoa(d.Backlog),
+				strconv.FormatFloat(d.ExpectedRetention, 'f', 4, 64),
+			}
+			if err := w.Write(record); err != nil {
+				return fmt.Errorf("write %s day %d: %w", r.Algorithm, d.Day, err)
+			}
+		}
+	}
+	w.Flush()
+
+	return w.Error()
+}
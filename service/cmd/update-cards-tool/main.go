@@ -192,6 +192,7 @@ func updateCard(ctx context.Context, card *api.Card, conn api.LaleServiceClient)
 		UserID:              card.GetUserID(),
 		CardID:              card.GetId(),
 		WordInformationList: card.GetWordInformationList(),
+		Version:             card.GetVersion(),
 	}
 	_, err := conn.UpdateCard(ctx, req)
 	if err != nil {
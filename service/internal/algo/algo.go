@@ -3,46 +3,117 @@ package algo
 import (
 	"math"
 	"time"
+
+	"github.com/genvmoroz/lale/service/pkg/entity"
+)
+
+const (
+	AlgorithmAnki = "anki"
+	AlgorithmFSRS = "fsrs"
 )
 
+type Config struct {
+	Algorithm        string  `envconfig:"APP_SCHEDULER_ALGORITHM" default:"anki"`
+	DesiredRetention float64 `envconfig:"APP_SCHEDULER_DESIRED_RETENTION" default:"0.9"`
+	// LearningSteps schedule the new cards until they graduate, RelearningSteps schedule the forgotten cards,
+	// empty steps schedule the cards in days right away.
+	LearningSteps   []time.Duration `envconfig:"APP_SCHEDULER_LEARNING_STEPS" default:"10m,1h"`
+	RelearningSteps []time.Duration `envconfig:"APP_SCHEDULER_RELEARNING_STEPS" default:"10m"`
+	// LeechThreshold is the number of lapses making a card a leech, zero disables the leech detection.
+	LeechThreshold uint32 `envconfig:"APP_SCHEDULER_LEECH_THRESHOLD" default:"8"`
+	// LeechAction is either tag to flag the leeches or suspend to flag and suspend them.
+	LeechAction string `envconfig:"APP_SCHEDULER_LEECH_ACTION" default:"tag"`
+	// Fuzz moves the due dates to a random day around them.
+	Fuzz bool `envconfig:"APP_SCHEDULER_FUZZ" default:"false"`
+	// LoadBalancing moves the due dates to the day around them with the fewest cards due.
+	LoadBalancing bool `envconfig:"APP_SCHEDULER_LOAD_BALANCING" default:"false"`
+	// MaintenanceInterval is the interval the learnt cards are checked for retention at,
+	// MaintenanceDailyLimit is the number of them checked per day, zero disables the checks.
+	MaintenanceInterval   time.Duration `envconfig:"APP_SCHEDULER_MAINTENANCE_INTERVAL" default:"4320h"`
+	MaintenanceDailyLimit uint32        `envconfig:"APP_SCHEDULER_MAINTENANCE_DAILY_LIMIT" default:"0"`
+}
+
 type Anki struct {
-	now func() time.Time
+	now              func() time.Time
+	desiredRetention float64
 }
 
-func NewAnki(now func() time.Time) *Anki {
-	return &Anki{now: now}
+func NewAnki(now func() time.Time, desiredRetention float64) *Anki {
+	return &Anki{now: now, desiredRetention: desiredRetention}
 }
 
 const day = 24 * time.Hour
 
-func (a Anki) CalculateNextDueDate(performance uint32, consecutiveCorrectAnswersNumber uint32) time.Time {
-	next := a.now().
-		Add(
-			a.calculateShift(
-				float64(performance),
-				float64(consecutiveCorrectAnswersNumber),
+// DefaultAnkiParameters returns the parameters of the SuperMemo-2 like interval formula.
+func DefaultAnkiParameters() entity.AnkiParameters {
+	return entity.AnkiParameters{
+		IntervalDays:        6,
+		DifficultyIntercept: -0.8,
+		DifficultySlope:     0.28,
+		DifficultyCurvature: 0.02,
+	}
+}
+
+// DefaultParameters returns the parameters used for the users who haven't optimised their own yet.
+func (a Anki) DefaultParameters() entity.SchedulerParameters {
+	return defaultParameters(a.desiredRetention)
+}
+
+// CalculateNextDueDate schedules the card to the start of the user's day the interval ends on.
+func (a Anki) CalculateNextDueDate(
+	user entity.User,
+	performance uint32,
+	card entity.Card,
+) (time.Time, entity.MemoryState) {
+	next := user.Profile.StartOfDay(
+		a.now().
+			Add(
+				durationFromDays(
+					ankiIntervalDays(
+						ankiParameters(user.SchedulerParameters),
+						float64(performance),
+						float64(card.ConsecutiveCorrectAnswersNumber),
+					),
+				),
 			),
-		).Truncate(day)
+	)
 
 	if next.Before(a.now()) {
-		return a.now().
-			Add(day).
-			Truncate(day)
+		return user.Profile.StartOfDay(a.now().Add(day)), entity.MemoryState{}
 	}
-	return next
+	return next, entity.MemoryState{}
 }
 
-//nolint:mnd // magic numbers are used for calculations
-func (Anki) calculateShift(performance float64, correctAnswers float64) time.Duration {
+func ankiIntervalDays(params entity.AnkiParameters, performance float64, correctAnswers float64) float64 {
 	if correctAnswers <= 1 {
-		return durationFromDays(1)
+		return 1
 	}
 
-	difficulty := -0.8 + 0.28*performance + 0.02*performance*performance
+	difficulty := params.DifficultyIntercept +
+		params.DifficultySlope*performance +
+		params.DifficultyCurvature*performance*performance
+
+	return params.IntervalDays * math.Pow(difficulty, correctAnswers-1)
+}
+
+func ankiParameters(params entity.SchedulerParameters) entity.AnkiParameters {
+	if params.Anki == (entity.AnkiParameters{}) {
+		return DefaultAnkiParameters()
+	}
+	return params.Anki
+}
 
-	return durationFromDays(6 * math.Pow(difficulty, correctAnswers-1))
+func defaultParameters(desiredRetention float64) entity.SchedulerParameters {
+	weights := DefaultFSRSWeights()
+	return entity.SchedulerParameters{
+		Anki:             DefaultAnkiParameters(),
+		FSRSWeights:      weights[:],
+		DesiredRetention: desiredRetention,
+	}
 }
 
+// durationFromDays converts the interval in days to a duration, the interval is capped at maxInterval
+// before the conversion, so a long streak never overflows the duration.
 func durationFromDays(days float64) time.Duration {
-	return time.Duration(days) * day
+	return time.Duration(min(days, maxInterval)) * day
 }
@@ -2,11 +2,11 @@ package algo_test
 
 import (
 	"maps"
-	"reflect"
 	"testing"
 	"time"
 
 	"github.com/genvmoroz/lale/service/internal/algo"
+	"github.com/genvmoroz/lale/service/pkg/entity"
 )
 
 func TestAnkiCalculateNextDueDate(t *testing.T) {
@@ -99,15 +99,29 @@ func TestAnkiCalculateNextDueDate(t *testing.T) {
 			input: input{performance: 5, correctAnswers: 10},
 			want:  want{nextDueDate: testNowTime.Add(14 * 24 * time.Hour).Truncate(24 * time.Hour)},
 		},
+		"200 correct answer, 5 performance": {
+			// the interval of the streak overflows time.Duration, it's capped at 100 years
+			field: field{now: testNow},
+			input: input{performance: 5, correctAnswers: 200},
+			want:  want{nextDueDate: testNowTime.Add(36500 * 24 * time.Hour).Truncate(24 * time.Hour)},
+		},
 	}
 	for name, testcase := range maps.All(testcases) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			a := algo.NewAnki(testcase.field.now)
+			a := algo.NewAnki(testcase.field.now, 0.9)
 
-			got := a.CalculateNextDueDate(testcase.input.performance, testcase.input.correctAnswers)
-			if !reflect.DeepEqual(got, testcase.want.nextDueDate) {
+			card := entity.Card{ConsecutiveCorrectAnswersNumber: testcase.input.correctAnswers}
+			got, memoryState := a.CalculateNextDueDate(
+				entity.User{SchedulerParameters: a.DefaultParameters()},
+				testcase.input.performance,
+				card,
+			)
+			if !memoryState.IsZero() {
+				t.Fatalf("CalculateNextDueDate() memory state = %v, want zero", memoryState)
+			}
+			if !got.Equal(testcase.want.nextDueDate) {
 				t.Fatalf("CalculateNextDueDate() = %v, want %v", got, testcase.want.nextDueDate)
 			}
 		})
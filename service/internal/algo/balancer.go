@@ -0,0 +1,123 @@
+package algo
+
+import (
+	"math"
+	"time"
+
+	"github.com/genvmoroz/lale/service/pkg/entity"
+)
+
+// Balancer moves the computed due dates within a few days around them, so the cards studied together
+// don't pile up on the same future days. Fuzz picks a random day of the window, load balancing picks
+// the day with the fewest cards already due, both together pick a random day among the least loaded ones.
+type Balancer struct {
+	fuzz          bool
+	loadBalancing bool
+	intN          func(n int) int
+}
+
+const (
+	minFuzzedIntervalDays = 2.5
+	minFuzzedDueDays      = 2
+)
+
+type fuzzRange struct {
+	start, end, factor float64
+}
+
+// fuzzRanges return the share of the interval added to the fuzz window, e.g. every day of the interval
+// between 7 and 20 days widens the window by 0.1 day in both directions.
+//
+//nolint:mnd // the table of the fuzz ranges
+func fuzzRanges() [3]fuzzRange {
+	return [...]fuzzRange{
+		{start: 2.5, end: 7, factor: 0.15},
+		{start: 7, end: 20, factor: 0.1},
+		{start: 20, end: math.Inf(1), factor: 0.05},
+	}
+}
+
+// NewBalancer creates the balancer, intN returns a random number in [0, n).
+func NewBalancer(fuzz, loadBalancing bool, intN func(n int) int) *Balancer {
+	return &Balancer{
+		fuzz:          fuzz,
+		loadBalancing: loadBalancing,
+		intN:          intN,
+	}
+}
+
+// Window returns the first and the last day the card reviewed at reviewedAt and scheduled to the due date
+// may be moved to. Both are the due date if the balancer is disabled or the interval is too short to move it.
+func (b Balancer) Window(user entity.User, reviewedAt, due time.Time) (time.Time, time.Time) {
+	if !b.fuzz && !b.loadBalancing {
+		return due, due
+	}
+
+	intervalDays := daysBetween(user.Profile.StartOfDay(reviewedAt), due)
+	if intervalDays < minFuzzedIntervalDays {
+		return due, due
+	}
+
+	delta := 1.0
+	for _, r := range fuzzRanges() {
+		delta += r.factor * max(min(intervalDays, r.end)-r.start, 0)
+	}
+
+	before := int(math.Round(min(delta, intervalDays-minFuzzedDueDays)))
+	after := int(math.Round(delta))
+
+	return user.Profile.StartOfDay(due.AddDate(0, 0, -before)), user.Profile.StartOfDay(due.AddDate(0, 0, after))
+}
+
+// Balance picks the due date within the window [from, to], dueDates are the due dates of the user's
+// other cards falling within the window.
+func (b Balancer) Balance(user entity.User, due, from, to time.Time, dueDates []time.Time) time.Time {
+	var days []time.Time
+	for d := from; !d.After(to); d = user.Profile.StartOfDay(d.AddDate(0, 0, 1)) {
+		days = append(days, d)
+	}
+	if len(days) < 2 { //nolint:mnd // nothing to pick from a single day
+		return due
+	}
+
+	if b.loadBalancing {
+		days = leastLoadedDays(user, days, dueDates)
+	}
+
+	if b.fuzz {
+		return days[b.intN(len(days))]
+	}
+
+	return closestDay(days, due)
+}
+
+func leastLoadedDays(user entity.User, days []time.Time, dueDates []time.Time) []time.Time {
+	loads := make(map[int64]int, len(days))
+	for _, dueDate := range dueDates {
+		loads[user.Profile.StartOfDay(dueDate).Unix()]++
+	}
+
+	minLoad := math.MaxInt
+	var least []time.Time
+	for _, d := range days {
+		switch load := loads[d.Unix()]; {
+		case load < minLoad:
+			minLoad, least = load, []time.Time{d}
+		case load == minLoad:
+			least = append(least, d)
+		}
+	}
+
+	return least
+}
+
+func closestDay(days []time.Time, due time.Time) time.Time {
+	closest := days[0]
+	for _, d := range days[1:] {
+		if d.Sub(due).Abs() < closest.Sub(due).Abs() {
+			closest = d
+		}
+	}
+
+	return closest
+}
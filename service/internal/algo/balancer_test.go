@@ -0,0 +1,83 @@
+package algo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/genvmoroz/lale/service/internal/algo"
+	"github.com/genvmoroz/lale/service/pkg/entity"
+)
+
+func TestBalancerWindow(t *testing.T) {
+	t.Parallel()
+
+	reviewedAt := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	today := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	testcases := map[string]struct {
+		fuzz         bool
+		intervalDays int
+		wantBefore   int
+		wantAfter    int
+	}{
+		"disabled":       {fuzz: false, intervalDays: 30},
+		"short interval": {fuzz: true, intervalDays: 2},
+		"week":           {fuzz: true, intervalDays: 7, wantBefore: 2, wantAfter: 2},
+		"month":          {fuzz: true, intervalDays: 30, wantBefore: 3, wantAfter: 3},
+		"three days":     {fuzz: true, intervalDays: 3, wantBefore: 1, wantAfter: 1},
+	}
+	for name, testcase := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			b := algo.NewBalancer(testcase.fuzz, false, func(int) int { return 0 })
+			due := today.AddDate(0, 0, testcase.intervalDays)
+
+			from, to := b.Window(entity.User{}, reviewedAt, due)
+			if want := due.AddDate(0, 0, -testcase.wantBefore); !from.Equal(want) {
+				t.Fatalf("Window() from = %v, want %v", from, want)
+			}
+			if want := due.AddDate(0, 0, testcase.wantAfter); !to.Equal(want) {
+				t.Fatalf("Window() to = %v, want %v", to, want)
+			}
+		})
+	}
+}
+
+func TestBalancerBalance(t *testing.T) {
+	t.Parallel()
+
+	due := time.Date(2024, time.March, 8, 0, 0, 0, 0, time.UTC)
+	from, to := due.AddDate(0, 0, -2), due.AddDate(0, 0, 2)
+	dueDates := []time.Time{
+		due.AddDate(0, 0, -2), due.AddDate(0, 0, -2),
+		due.AddDate(0, 0, -1),
+		due, due, due,
+		due.AddDate(0, 0, 1),
+		due.AddDate(0, 0, 2), due.AddDate(0, 0, 2).Add(5 * time.Hour),
+	}
+
+	testcases := map[string]struct {
+		fuzz          bool
+		loadBalancing bool
+		want          time.Time
+	}{
+		"disabled":       {want: due},
+		"fuzz":           {fuzz: true, want: to},
+		"load balancing": {loadBalancing: true, want: due.AddDate(0, 0, -1)},
+		"both":           {fuzz: true, loadBalancing: true, want: due.AddDate(0, 0, 1)},
+	}
+	for name, testcase := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// picks the last of the days to choose from
+			b := algo.NewBalancer(testcase.fuzz, testcase.loadBalancing, func(n int) int { return n - 1 })
+
+			got := b.Balance(entity.User{}, due, from, to, dueDates)
+			if !got.Equal(testcase.want) {
+				t.Fatalf("Balance() = %v, want %v", got, testcase.want)
+			}
+		})
+	}
+}
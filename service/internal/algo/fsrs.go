@@ -0,0 +1,227 @@
+package algo
+
+import (
+	"math"
+	"time"
+
+	"github.com/genvmoroz/lale/service/pkg/entity"
+)
+
+// FSRS implements the Free Spaced Repetition Scheduler (v4.5). Every card is modelled with
+// a memory stability and difficulty, the next review is scheduled for the moment the predicted
+// recall probability (retrievability) drops to the desired retention.
+type FSRS struct {
+	now              func() time.Time
+	desiredRetention float64
+}
+
+// fsrsModel is the FSRS memory model with the weights and the desired retention of a user.
+type fsrsModel struct {
+	weights          FSRSWeights
+	desiredRetention float64
+}
+
+// FSRSWeights are the 17 FSRS-4.5 model parameters.
+type FSRSWeights [17]float64
+
+type fsrsRating int
+
+const (
+	ratingAgain fsrsRating = iota + 1
+	ratingHard
+	ratingGood
+	ratingEasy
+)
+
+const (
+	fsrsDecay  = -0.5
+	fsrsFactor = 19.0 / 81.0 // makes the retrievability equal 90% when elapsed days equal the stability
+
+	minDifficulty = 1
+	maxDifficulty = 10
+	minStability  = 0.1
+	// maxInterval caps the intervals of every scheduler in days, 100 years.
+	maxInterval = 36500
+
+	passingPerformance = 3
+)
+
+// DefaultFSRSWeights returns the FSRS-4.5 weights fitted on the open review dataset.
+func DefaultFSRSWeights() FSRSWeights {
+	return FSRSWeights{
+		0.4872, 1.4003, 3.7145, 13.8206, 5.1618, 1.2298, 0.8975, 0.031, 1.6474,
+		0.1367, 1.0461, 2.1072, 0.0793, 0.3246, 1.587, 0.2272, 2.8755,
+	}
+}
+
+func NewFSRS(now func() time.Time, desiredRetention float64) *FSRS {
+	return &FSRS{
+		now:              now,
+		desiredRetention: desiredRetention,
+	}
+}
+
+// DefaultParameters returns the parameters used for the users who haven't optimised their own yet.
+func (f FSRS) DefaultParameters() entity.SchedulerParameters {
+	return defaultParameters(f.desiredRetention)
+}
+
+// CalculateNextDueDate schedules the card to the start of the user's day the interval ends on.
+func (f FSRS) CalculateNextDueDate(
+	user entity.User,
+	performance uint32,
+	card entity.Card,
+) (time.Time, entity.MemoryState) {
+	now := f.now()
+	model := newFSRSModel(user.SchedulerParameters, f.desiredRetention)
+	rating := toFSRSRating(performance)
+
+	state, lastReviewedAt := card.MemoryState, card.LastReviewedAt
+	if state.IsZero() && !card.NextDueDate.IsZero() {
+		state, lastReviewedAt = model.seedMemoryState(card, performance, now)
+	}
+
+	var next entity.MemoryState
+	if state.IsZero() {
+		next = model.initialMemoryState(rating)
+	} else {
+		elapsedDays := max(daysBetween(lastReviewedAt, now), 0)
+		next = model.nextMemoryState(state, model.retrievability(elapsedDays, state.Stability), rating)
+	}
+
+	return user.Profile.StartOfDay(now.Add(durationFromDays(model.nextInterval(next.Stability)))), next
+}
+
+// newFSRSModel builds the model from the user parameters, falling back to the defaults for the unset ones.
+func newFSRSModel(params entity.SchedulerParameters, defaultRetention float64) fsrsModel {
+	model := fsrsModel{
+		weights:          DefaultFSRSWeights(),
+		desiredRetention: defaultRetention,
+	}
+	if len(params.FSRSWeights) == len(model.weights) {
+		copy(model.weights[:], params.FSRSWeights)
+	}
+	if params.DesiredRetention > 0 && params.DesiredRetention < 1 {
+		model.desiredRetention = params.DesiredRetention
+	}
+
+	return model
+}
+
+// seedMemoryState estimates the memory state of a card scheduled before FSRS was enabled.
+// Since FSRS schedules the interval equal to the stability at 90% retention, the stability is
+// taken from the card's scheduled interval, but not lower than the stability reached by
+// answering "good" on time for the whole consecutive correct answers streak.
+// It's the lazy migration of the cards to FSRS: the seeded state is stored with the answer, so a card
+// is seeded once. The cards having no last review time are taken as reviewed one stability before their
+// due date, so the time an overdue card has waited counts as elapsed.
+func (f fsrsModel) seedMemoryState(card entity.Card, performance uint32, now time.Time) (entity.MemoryState, time.Time) {
+	streak := card.ConsecutiveCorrectAnswersNumber
+	if performance >= passingPerformance && streak > 0 {
+		streak-- // the streak already includes the answer being scheduled
+	}
+
+	state := f.initialMemoryState(ratingGood)
+	for range streak {
+		state = f.nextMemoryState(state, f.desiredRetention, ratingGood)
+	}
+
+	scheduledFrom := card.LastReviewedAt
+	if scheduledFrom.IsZero() {
+		scheduledFrom = now
+	}
+	state.Stability = max(state.Stability, daysBetween(scheduledFrom, card.NextDueDate))
+
+	lastReviewedAt := card.LastReviewedAt
+	if lastReviewedAt.IsZero() {
+		lastReviewedAt = card.NextDueDate.Add(-durationFromFractionalDays(state.Stability))
+	}
+
+	return state, lastReviewedAt
+}
+
+func (f fsrsModel) initialMemoryState(rating fsrsRating) entity.MemoryState {
+	return entity.MemoryState{
+		Stability:  max(f.weights[rating-1], minStability),
+		Difficulty: f.initialDifficulty(rating),
+	}
+}
+
+//nolint:mnd // weight indexes and constants of the FSRS formulas
+func (f fsrsModel) nextMemoryState(state entity.MemoryState, retrievability float64, rating fsrsRating) entity.MemoryState {
+	w := f.weights
+
+	difficulty := state.Difficulty - w[6]*float64(rating-ratingGood)
+	difficulty = w[7]*f.initialDifficulty(ratingGood) + (1-w[7])*difficulty
+
+	var stability float64
+	if rating == ratingAgain {
+		stability = w[11] *
+			math.Pow(state.Difficulty, -w[12]) *
+			(math.Pow(state.Stability+1, w[13]) - 1) *
+			math.Exp(w[14]*(1-retrievability))
+		stability = min(stability, state.Stability)
+	} else {
+		hardPenalty, easyBonus := 1.0, 1.0
+		switch rating {
+		case ratingHard:
+			hardPenalty = w[15]
+		case ratingEasy:
+			easyBonus = w[16]
+		case ratingAgain, ratingGood:
+		}
+		stability = state.Stability * (1 +
+			math.Exp(w[8])*
+				(11-state.Difficulty)*
+				math.Pow(state.Stability, -w[9])*
+				(math.Exp(w[10]*(1-retrievability))-1)*
+				hardPenalty*
+				easyBonus)
+	}
+
+	return entity.MemoryState{
+		Stability:  max(stability, minStability),
+		Difficulty: clampDifficulty(difficulty),
+	}
+}
+
+//nolint:mnd // weight indexes of the FSRS formulas
+func (f fsrsModel) initialDifficulty(rating fsrsRating) float64 {
+	return clampDifficulty(f.weights[4] - f.weights[5]*float64(rating-ratingGood))
+}
+
+// retrievability is the probability to recall a card with the given stability after elapsedDays.
+func (fsrsModel) retrievability(elapsedDays, stability float64) float64 {
+	return math.Pow(1+fsrsFactor*elapsedDays/stability, fsrsDecay)
+}
+
+// nextInterval returns the number of whole days until the retrievability drops to the desired retention.
+func (f fsrsModel) nextInterval(stability float64) float64 {
+	interval := stability / fsrsFactor * (math.Pow(f.desiredRetention, 1/fsrsDecay) - 1)
+	return min(max(math.Round(interval), 1), maxInterval)
+}
+
+func toFSRSRating(performance uint32) fsrsRating {
+	switch {
+	case performance < passingPerformance:
+		return ratingAgain
+	case performance == passingPerformance:
+		return ratingHard
+	case performance == passingPerformance+1:
+		return ratingGood
+	default:
+		return ratingEasy
+	}
+}
+
+func clampDifficulty(difficulty float64) float64 {
+	return min(max(difficulty, minDifficulty), maxDifficulty)
+}
+
+func durationFromFractionalDays(days float64) time.Duration {
+	return time.Duration(days * float64(day))
+}
+
+func daysBetween(from, to time.Time) float64 {
+	return float64(to.Sub(from)) / float64(day)
+}
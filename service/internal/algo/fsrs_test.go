@@ -0,0 +1,148 @@
+package algo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/genvmoroz/lale/service/internal/algo"
+	"github.com/genvmoroz/lale/service/pkg/entity"
+)
+
+func TestFSRSCalculateNextDueDateNewCard(t *testing.T) {
+	t.Parallel()
+
+	var (
+		testNowTime = time.Now()
+		testNow     = func() time.Time { return testNowTime }
+	)
+
+	testcases := map[string]struct {
+		performance uint32
+		wantDays    int
+	}{
+		"again": {performance: 0, wantDays: 1},
+		"hard":  {performance: 3, wantDays: 1},
+		"good":  {performance: 4, wantDays: 4},
+		"easy":  {performance: 5, wantDays: 14},
+	}
+	for name, testcase := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			f := algo.NewFSRS(testNow, 0.9)
+			user := entity.User{SchedulerParameters: f.DefaultParameters()}
+
+			got, memoryState := f.CalculateNextDueDate(user, testcase.performance, entity.Card{})
+			want := testNowTime.Add(time.Duration(testcase.wantDays) * 24 * time.Hour).Truncate(24 * time.Hour)
+			if !got.Equal(want) {
+				t.Fatalf("CalculateNextDueDate() = %v, want %v", got, want)
+			}
+			if memoryState.IsZero() {
+				t.Fatalf("CalculateNextDueDate() returned zero memory state")
+			}
+		})
+	}
+}
+
+func TestFSRSCalculateNextDueDateReviewedCard(t *testing.T) {
+	t.Parallel()
+
+	var (
+		testNowTime = time.Now()
+		testNow     = func() time.Time { return testNowTime }
+	)
+
+	// reviewed exactly when the recall probability dropped to 90%
+	card := entity.Card{
+		ConsecutiveCorrectAnswersNumber: 3,
+		NextDueDate:                     testNowTime,
+		LastReviewedAt:                  testNowTime.Add(-10 * 24 * time.Hour),
+		MemoryState:                     entity.MemoryState{Stability: 10, Difficulty: 5},
+	}
+
+	f := algo.NewFSRS(testNow, 0.9)
+	user := entity.User{SchedulerParameters: f.DefaultParameters()}
+
+	_, good := f.CalculateNextDueDate(user, 4, card)
+	if good.Stability <= card.MemoryState.Stability {
+		t.Fatalf("good answer stability = %v, want greater than %v", good.Stability, card.MemoryState.Stability)
+	}
+
+	_, easy := f.CalculateNextDueDate(user, 5, card)
+	if easy.Stability <= good.Stability {
+		t.Fatalf("easy answer stability = %v, want greater than %v", easy.Stability, good.Stability)
+	}
+	if easy.Difficulty >= good.Difficulty {
+		t.Fatalf("easy answer difficulty = %v, want less than %v", easy.Difficulty, good.Difficulty)
+	}
+
+	_, again := f.CalculateNextDueDate(user, 0, card)
+	if again.Stability >= card.MemoryState.Stability {
+		t.Fatalf("failed answer stability = %v, want less than %v", again.Stability, card.MemoryState.Stability)
+	}
+
+	strict := algo.NewFSRS(testNow, 0.95)
+	strictUser := entity.User{SchedulerParameters: strict.DefaultParameters()}
+	strictDue, _ := strict.CalculateNextDueDate(strictUser, 4, card)
+	due, _ := f.CalculateNextDueDate(user, 4, card)
+	if !strictDue.Before(due) {
+		t.Fatalf("due date for 95%% retention = %v, want before %v", strictDue, due)
+	}
+}
+
+func TestFSRSCalculateNextDueDateSeedsScheduledCard(t *testing.T) {
+	t.Parallel()
+
+	var (
+		testNowTime = time.Now()
+		testNow     = func() time.Time { return testNowTime }
+	)
+
+	// scheduled by the Anki-like algorithm, so it has no memory state yet
+	card := entity.Card{
+		ConsecutiveCorrectAnswersNumber: 4,
+		NextDueDate:                     testNowTime.Add(20 * 24 * time.Hour),
+	}
+
+	f := algo.NewFSRS(testNow, 0.9)
+	user := entity.User{SchedulerParameters: f.DefaultParameters()}
+
+	got, memoryState := f.CalculateNextDueDate(user, 4, card)
+	if memoryState.Stability < 20 {
+		t.Fatalf("seeded stability = %v, want at least the scheduled 20 days", memoryState.Stability)
+	}
+	if want := card.NextDueDate.Truncate(24 * time.Hour); got.Before(want) {
+		t.Fatalf("CalculateNextDueDate() = %v, want not before %v", got, want)
+	}
+}
+
+func TestFSRSCalculateNextDueDateSeedsOverdueCard(t *testing.T) {
+	t.Parallel()
+
+	var (
+		testNowTime = time.Now()
+		testNow     = func() time.Time { return testNowTime }
+	)
+
+	f := algo.NewFSRS(testNow, 0.9)
+	user := entity.User{SchedulerParameters: f.DefaultParameters()}
+
+	// scheduled by the Anki-like algorithm with no last review time, the due date has passed 30 days ago
+	overdue := entity.Card{
+		ConsecutiveCorrectAnswersNumber: 4,
+		NextDueDate:                     testNowTime.Add(-30 * 24 * time.Hour),
+	}
+	onTime := overdue
+	onTime.NextDueDate = testNowTime
+
+	gotOverdue, overdueState := f.CalculateNextDueDate(user, 4, overdue)
+	if !gotOverdue.After(testNowTime) {
+		t.Fatalf("CalculateNextDueDate() = %v, want after %v", gotOverdue, testNowTime)
+	}
+
+	_, onTimeState := f.CalculateNextDueDate(user, 4, onTime)
+	if overdueState.Stability <= onTimeState.Stability {
+		t.Fatalf("overdue stability = %v, want above the on time stability %v, the card has been recalled later",
+			overdueState.Stability, onTimeState.Stability)
+	}
+}
@@ -0,0 +1,45 @@
+package algo
+
+import (
+	"github.com/genvmoroz/lale/service/pkg/entity"
+)
+
+// Leech actions tell what is done to a card once it becomes a leech.
+const (
+	LeechActionTag     = "tag"
+	LeechActionSuspend = "suspend"
+)
+
+// Leeches detect the cards forgotten again and again, repeating such cards wastes time,
+// they are better rewritten.
+type Leeches struct {
+	threshold uint32
+	suspend   bool
+}
+
+// NewLeeches creates the detector marking the cards lapsed threshold times, zero threshold disables it.
+func NewLeeches(threshold uint32, suspend bool) *Leeches {
+	return &Leeches{
+		threshold: threshold,
+		suspend:   suspend,
+	}
+}
+
+// Check marks the card just lapsed a leech once its lapses reach the threshold and again every half
+// of the threshold after, e.g. at 8, 12 and 16 lapses, so a suspended leech is suspended again if it keeps
+// lapsing after being resumed. It reports whether the card has been marked.
+func (l Leeches) Check(card *entity.Card) bool {
+	if l.threshold == 0 || card.Lapses < l.threshold {
+		return false
+	}
+	if (card.Lapses-l.threshold)%max(l.threshold/2, 1) != 0 { //nolint:mnd // every half of the threshold
+		return false
+	}
+
+	card.Leech = true
+	if l.suspend {
+		card.Suspended = true
+	}
+
+	return true
+}
@@ -0,0 +1,42 @@
+package algo_test
+
+import (
+	"testing"
+
+	"github.com/genvmoroz/lale/service/internal/algo"
+	"github.com/genvmoroz/lale/service/pkg/entity"
+)
+
+func TestLeechesCheck(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		threshold     uint32
+		suspend       bool
+		lapses        uint32
+		wantLeech     bool
+		wantSuspended bool
+	}{
+		"below threshold":           {threshold: 8, lapses: 7},
+		"threshold reached":         {threshold: 8, lapses: 8, wantLeech: true},
+		"between the checks":        {threshold: 8, lapses: 10},
+		"half threshold after":      {threshold: 8, lapses: 12, wantLeech: true},
+		"suspended":                 {threshold: 8, suspend: true, lapses: 8, wantLeech: true, wantSuspended: true},
+		"disabled":                  {threshold: 0, lapses: 8},
+		"threshold of single lapse": {threshold: 1, lapses: 3, wantLeech: true},
+	}
+	for name, testcase := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			card := entity.Card{Lapses: testcase.lapses}
+			marked := algo.NewLeeches(testcase.threshold, testcase.suspend).Check(&card)
+			if marked != testcase.wantLeech || card.Leech != testcase.wantLeech {
+				t.Fatalf("Check() = %v, leech = %v, want %v", marked, card.Leech, testcase.wantLeech)
+			}
+			if card.Suspended != testcase.wantSuspended {
+				t.Fatalf("Check() suspended = %v, want %v", card.Suspended, testcase.wantSuspended)
+			}
+		})
+	}
+}
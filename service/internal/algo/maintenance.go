@@ -0,0 +1,49 @@
+package algo
+
+import (
+	"time"
+
+	"github.com/genvmoroz/lale/service/pkg/entity"
+)
+
+// Maintenance brings the learnt cards back for a retention check on a long interval, a few cards a day,
+// so the words forgotten after being learnt are found and repeated again. A card passing the check stays learnt
+// until the next check, a card failing it is no longer learnt and is scheduled as a lapsed card.
+type Maintenance struct {
+	now        func() time.Time
+	interval   time.Duration
+	dailyLimit uint32
+}
+
+// NewMaintenance creates the maintenance checking the learnt cards every interval, at most dailyLimit cards
+// a day, zero interval or daily limit disables the checks.
+func NewMaintenance(now func() time.Time, interval time.Duration, dailyLimit uint32) *Maintenance {
+	return &Maintenance{
+		now:        now,
+		interval:   interval,
+		dailyLimit: dailyLimit,
+	}
+}
+
+// Due reports whether the learnt card is due for the check, it is once the interval has passed since the card
+// has been learnt or checked the last time.
+func (m Maintenance) Due(user entity.User, card entity.Card) bool {
+	if m.interval <= 0 || m.dailyLimit == 0 {
+		return false
+	}
+	if !card.Learnt || card.Suspended || card.Buried() {
+		return false
+	}
+
+	return !m.NextCheck(user, card.LastChecked()).After(m.now())
+}
+
+// NextCheck returns the start of the user's day the card checked at checkedAt is due for the next check on.
+func (m Maintenance) NextCheck(user entity.User, checkedAt time.Time) time.Time {
+	return user.Profile.StartOfDay(checkedAt.Add(m.interval))
+}
+
+// DailyLimit returns the number of the learnt cards checked per day.
+func (m Maintenance) DailyLimit() uint32 {
+	return m.dailyLimit
+}
@@ -0,0 +1,68 @@
+package algo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/genvmoroz/lale/service/internal/algo"
+	"github.com/genvmoroz/lale/service/pkg/entity"
+)
+
+func TestMaintenanceDue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.September, 1, 12, 0, 0, 0, time.UTC)
+	interval := 90 * 24 * time.Hour
+
+	testcases := map[string]struct {
+		interval   time.Duration
+		dailyLimit uint32
+		card       entity.Card
+		want       bool
+	}{
+		"learnt long ago": {
+			interval:   interval,
+			dailyLimit: 5,
+			card:       entity.Card{Learnt: true, LearntAt: now.Add(-interval)},
+			want:       true,
+		},
+		"learnt recently": {
+			interval:   interval,
+			dailyLimit: 5,
+			card:       entity.Card{Learnt: true, LearntAt: now.AddDate(0, 0, -10)},
+		},
+		"checked recently": {
+			interval:   interval,
+			dailyLimit: 5,
+			card: entity.Card{
+				Learnt:         true,
+				LearntAt:       now.Add(-2 * interval),
+				LastReviewedAt: now.AddDate(0, 0, -10),
+			},
+		},
+		"not learnt": {
+			interval:   interval,
+			dailyLimit: 5,
+			card:       entity.Card{LastReviewedAt: now.Add(-interval)},
+		},
+		"suspended": {
+			interval:   interval,
+			dailyLimit: 5,
+			card:       entity.Card{Learnt: true, LearntAt: now.Add(-interval), Suspended: true},
+		},
+		"disabled": {
+			interval: interval,
+			card:     entity.Card{Learnt: true, LearntAt: now.Add(-interval)},
+		},
+	}
+	for name, testcase := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			m := algo.NewMaintenance(func() time.Time { return now }, testcase.interval, testcase.dailyLimit)
+			if got := m.Due(entity.User{}, testcase.card); got != testcase.want {
+				t.Fatalf("Due() = %v, want %v", got, testcase.want)
+			}
+		})
+	}
+}
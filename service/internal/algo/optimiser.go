@@ -0,0 +1,266 @@
+package algo
+
+import (
+	"errors"
+	"math"
+	"slices"
+
+	"github.com/genvmoroz/lale/service/pkg/entity"
+)
+
+// Optimiser fits the scheduler parameters of a user to the review history. The history is used to fit
+// the FSRS memory model of the user first, the fitted model then predicts the retention of any parameters:
+// the average probability to recall a card at the reviews these parameters would have scheduled.
+type Optimiser struct {
+	algorithm        string
+	desiredRetention float64
+}
+
+const (
+	optimisationIterations = 50
+	initialStepFraction    = 0.1
+	minRecallProbability   = 1e-6
+)
+
+var errNoRepeatedReviews = errors.New("review history has no repeated reviews of any card")
+
+func NewOptimiser(algorithm string, desiredRetention float64) *Optimiser {
+	return &Optimiser{
+		algorithm:        algorithm,
+		desiredRetention: desiredRetention,
+	}
+}
+
+// Optimise fits the FSRS weights to the reviews and tunes the Anki parameters to schedule the reviews
+// at the target retention, the predicted retention is reported for the algorithm the optimiser is configured with.
+func (o Optimiser) Optimise(
+	params entity.SchedulerParameters,
+	reviews []entity.Review,
+	targetRetention float64,
+) (entity.SchedulerOptimisation, error) {
+	histories := groupReviewsByCard(reviews)
+	if !slices.ContainsFunc(histories, func(history []entity.Review) bool { return len(history) > 1 }) {
+		return entity.SchedulerOptimisation{}, errNoRepeatedReviews
+	}
+
+	model := fitMemoryModel(histories, targetRetention)
+
+	optimised := params
+	optimised.FSRSWeights = slices.Clone(model.weights[:])
+	optimised.DesiredRetention = targetRetention
+	optimised.Anki = o.fitAnkiParameters(ankiParameters(params), model, histories, targetRetention)
+
+	return entity.SchedulerOptimisation{
+		Parameters:               optimised,
+		PredictedRetentionBefore: o.predictRetention(params, model, histories),
+		PredictedRetentionAfter:  o.predictRetention(optimised, model, histories),
+	}, nil
+}
+
+func (o Optimiser) fitAnkiParameters(
+	start entity.AnkiParameters,
+	model fsrsModel,
+	histories [][]entity.Review,
+	targetRetention float64,
+) entity.AnkiParameters {
+	toParameters := func(point []float64) entity.SchedulerParameters {
+		return entity.SchedulerParameters{
+			Anki: entity.AnkiParameters{
+				IntervalDays:        point[0],
+				DifficultyIntercept: point[1],
+				DifficultySlope:     point[2],
+				DifficultyCurvature: point[3],
+			},
+		}
+	}
+	anki := Optimiser{algorithm: AlgorithmAnki}
+	loss := func(point []float64) float64 {
+		return math.Pow(anki.predictRetention(toParameters(point), model, histories)-targetRetention, 2)
+	}
+
+	// the non-negative slope and curvature keep the difficulty non-decreasing in the performance rating
+	//nolint:mnd // bounds of the Anki parameters
+	point := minimise(
+		loss,
+		[]float64{start.IntervalDays, start.DifficultyIntercept, start.DifficultySlope, start.DifficultyCurvature},
+		[]float64{1, -2, 0, 0},
+		[]float64{30, 1, 1, 0.2},
+	)
+
+	return toParameters(point).Anki
+}
+
+// predictRetention returns the average recall probability the model predicts at the reviews scheduled with the parameters.
+func (o Optimiser) predictRetention(params entity.SchedulerParameters, model fsrsModel, histories [][]entity.Review) float64 {
+	var (
+		sum   float64
+		count int
+	)
+	for _, history := range histories {
+		states := model.memoryStates(history)
+		for i, interval := range o.scheduledIntervals(params, history)[:len(history)-1] {
+			sum += model.retrievability(interval, states[i].Stability)
+			count++
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+
+	return sum / float64(count)
+}
+
+// scheduledIntervals returns the interval in days the parameters schedule after every review of the history.
+func (o Optimiser) scheduledIntervals(params entity.SchedulerParameters, history []entity.Review) []float64 {
+	intervals := make([]float64, len(history))
+
+	switch o.algorithm {
+	case AlgorithmFSRS:
+		model := newFSRSModel(params, o.desiredRetention)
+		for i, state := range model.memoryStates(history) {
+			intervals[i] = model.nextInterval(state.Stability)
+		}
+	default:
+		anki := ankiParameters(params)
+		var streak uint32
+		for i, review := range history {
+			if review.Performance >= passingPerformance {
+				streak++
+			} else {
+				streak = 0
+			}
+			intervals[i] = max(math.Floor(ankiIntervalDays(anki, float64(review.Performance), float64(streak))), 1)
+		}
+	}
+
+	return intervals
+}
+
+// fitMemoryModel looks for the FSRS weights predicting the answers of the history with the minimal log loss.
+func fitMemoryModel(histories [][]entity.Review, desiredRetention float64) fsrsModel {
+	lower, upper := fsrsWeightsBounds()
+	start := DefaultFSRSWeights()
+
+	toModel := func(point []float64) fsrsModel {
+		return fsrsModel{weights: FSRSWeights(point), desiredRetention: desiredRetention}
+	}
+	loss := func(point []float64) float64 {
+		return toModel(point).logLoss(histories)
+	}
+
+	return toModel(minimise(loss, start[:], lower[:], upper[:]))
+}
+
+// fsrsWeightsBounds returns the ranges the FSRS-4.5 optimiser keeps every weight within.
+//
+//nolint:mnd // bounds of the FSRS weights
+func fsrsWeightsBounds() (FSRSWeights, FSRSWeights) {
+	lower := FSRSWeights{
+		0.1, 0.1, 0.1, 0.1, 1, 0.1, 0.1, 0, 0,
+		0.1, 0.01, 0.5, 0.01, 0.01, 0.01, 0, 1,
+	}
+	upper := FSRSWeights{
+		100, 100, 100, 100, 10, 5, 5, 0.5, 3,
+		0.8, 2.5, 5, 0.2, 0.9, 2, 1, 4,
+	}
+
+	return lower, upper
+}
+
+func (m fsrsModel) logLoss(histories [][]entity.Review) float64 {
+	var loss float64
+	for _, history := range histories {
+		states := m.memoryStates(history)
+		for i := 1; i < len(history); i++ {
+			elapsedDays := max(daysBetween(history[i-1].ReviewedAt, history[i].ReviewedAt), 0)
+			recall := m.retrievability(elapsedDays, states[i-1].Stability)
+			recall = min(max(recall, minRecallProbability), 1-minRecallProbability)
+
+			if history[i].Performance >= passingPerformance {
+				loss -= math.Log(recall)
+			} else {
+				loss -= math.Log(1 - recall)
+			}
+		}
+	}
+
+	return loss
+}
+
+// memoryStates returns the memory state of a card after every review of its history.
+func (m fsrsModel) memoryStates(history []entity.Review) []entity.MemoryState {
+	states := make([]entity.MemoryState, len(history))
+	for i, review := range history {
+		rating := toFSRSRating(review.Performance)
+		if i == 0 {
+			states[i] = m.initialMemoryState(rating)
+			continue
+		}
+
+		elapsedDays := max(daysBetween(history[i-1].ReviewedAt, review.ReviewedAt), 0)
+		states[i] = m.nextMemoryState(states[i-1], m.retrievability(elapsedDays, states[i-1].Stability), rating)
+	}
+
+	return states
+}
+
+// groupReviewsByCard splits the reviews into the chronological review histories of every card,
+// each direction of a card has its own history as it has its own schedule.
+// The answers given in the learning steps are left out, the memory model predicts the recall after days.
+func groupReviewsByCard(reviews []entity.Review) [][]entity.Review {
+	indexes := make(map[string]int)
+	var histories [][]entity.Review
+	for _, review := range reviews {
+		if review.CardBefore != nil && review.CardBefore.Learning.InSteps() {
+			continue
+		}
+		key := review.CardID + "/" + string(review.Direction.OrProduction())
+		index, ok := indexes[key]
+		if !ok {
+			index = len(histories)
+			indexes[key] = index
+			histories = append(histories, nil)
+		}
+		histories[index] = append(histories[index], review)
+	}
+
+	for _, history := range histories {
+		slices.SortStableFunc(history, func(a, b entity.Review) int {
+			return a.ReviewedAt.Compare(b.ReviewedAt)
+		})
+	}
+
+	return histories
+}
+
+// minimise looks for the point within the bounds with the minimal loss by coordinate descent,
+// the step of a coordinate is halved every time moving along it doesn't decrease the loss.
+func minimise(loss func([]float64) float64, start, lower, upper []float64) []float64 {
+	point := make([]float64, len(start))
+	steps := make([]float64, len(start))
+	for i := range start {
+		point[i] = min(max(start[i], lower[i]), upper[i])
+		steps[i] = (upper[i] - lower[i]) * initialStepFraction
+	}
+
+	best := loss(point)
+	for range optimisationIterations {
+		for i := range point {
+			improved := false
+			for _, direction := range [...]float64{1, -1} {
+				previous := point[i]
+				point[i] = min(max(previous+direction*steps[i], lower[i]), upper[i])
+				if value := loss(point); value < best {
+					best, improved = value, true
+					break
+				}
+				point[i] = previous
+			}
+			if !improved {
+				steps[i] /= 2
+			}
+		}
+	}
+
+	return point
+}
@@ -0,0 +1,91 @@
+package algo_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/genvmoroz/lale/service/internal/algo"
+	"github.com/genvmoroz/lale/service/pkg/entity"
+)
+
+func TestOptimiserOptimise(t *testing.T) {
+	t.Parallel()
+
+	const targetRetention = 0.85
+
+	reviews := testReviewHistory()
+
+	for _, algorithm := range []string{algo.AlgorithmAnki, algo.AlgorithmFSRS} {
+		t.Run(algorithm, func(t *testing.T) {
+			t.Parallel()
+
+			o := algo.NewOptimiser(algorithm, 0.9)
+			params := algo.NewAnki(time.Now, 0.9).DefaultParameters()
+
+			got, err := o.Optimise(params, reviews, targetRetention)
+			if err != nil {
+				t.Fatalf("Optimise() error = %v", err)
+			}
+
+			if len(got.Parameters.FSRSWeights) != len(algo.FSRSWeights{}) {
+				t.Fatalf("Optimise() FSRS weights number = %d, want %d", len(got.Parameters.FSRSWeights), len(algo.FSRSWeights{}))
+			}
+			if got.Parameters.DesiredRetention != targetRetention {
+				t.Fatalf("Optimise() desired retention = %v, want %v", got.Parameters.DesiredRetention, targetRetention)
+			}
+
+			before := math.Abs(got.PredictedRetentionBefore - targetRetention)
+			after := math.Abs(got.PredictedRetentionAfter - targetRetention)
+			if after > before {
+				t.Fatalf("Optimise() predicted retention %v is further from the target than %v",
+					got.PredictedRetentionAfter, got.PredictedRetentionBefore)
+			}
+			if algorithm == algo.AlgorithmFSRS && after > 0.05 {
+				t.Fatalf("Optimise() predicted retention = %v, want about %v", got.PredictedRetentionAfter, targetRetention)
+			}
+		})
+	}
+}
+
+func TestOptimiserOptimiseWithoutRepeatedReviews(t *testing.T) {
+	t.Parallel()
+
+	reviews := []entity.Review{
+		{CardID: "1", ReviewedAt: time.Now(), Performance: 4},
+		{CardID: "2", ReviewedAt: time.Now(), Performance: 4},
+	}
+
+	o := algo.NewOptimiser(algo.AlgorithmFSRS, 0.9)
+	if _, err := o.Optimise(entity.SchedulerParameters{}, reviews, 0.9); err == nil {
+		t.Fatalf("Optimise() error = nil, want error")
+	}
+}
+
+// testReviewHistory returns the reviews of a user forgetting every third card reviewed after a long interval.
+func testReviewHistory() []entity.Review {
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	intervals := []int{0, 1, 3, 8, 20, 45}
+
+	var reviews []entity.Review
+	for card := range 30 {
+		reviewedAt := start
+		for i, interval := range intervals {
+			reviewedAt = reviewedAt.Add(time.Duration(interval) * 24 * time.Hour)
+
+			performance := uint32(4)
+			if interval >= 20 && (card+i)%3 == 0 {
+				performance = 1
+			}
+			reviews = append(reviews, entity.Review{
+				ID:          fmt.Sprintf("%d-%d", card, i),
+				CardID:      fmt.Sprint(card),
+				ReviewedAt:  reviewedAt,
+				Performance: performance,
+			})
+		}
+	}
+
+	return reviews
+}
@@ -0,0 +1,71 @@
+package algo
+
+import (
+	"slices"
+	"time"
+
+	"github.com/genvmoroz/lale/service/pkg/entity"
+)
+
+// RecallModel is the FSRS memory model of a user taken as the ground truth of what the user remembers,
+// it tells how likely a card is recalled whichever algorithm schedules it, e.g. to compare the algorithms
+// in a simulation.
+type RecallModel struct {
+	model fsrsModel
+}
+
+// NewRecallModel creates the model with the user's FSRS weights, the default ones if the user has none.
+func NewRecallModel(params entity.SchedulerParameters) *RecallModel {
+	return &RecallModel{model: newFSRSModel(params, params.DesiredRetention)}
+}
+
+// Retrievability returns the probability to recall the card in the memory state at the given moment,
+// the card has been reviewed the last time at lastReviewedAt.
+func (m RecallModel) Retrievability(state entity.MemoryState, lastReviewedAt, at time.Time) float64 {
+	if state.IsZero() {
+		return 0
+	}
+
+	return m.model.retrievability(max(daysBetween(lastReviewedAt, at), 0), state.Stability)
+}
+
+// Next returns the memory state of the card answered with the performance at the given moment,
+// the zero state is the state of a card never answered before.
+func (m RecallModel) Next(state entity.MemoryState, lastReviewedAt, at time.Time, performance uint32) entity.MemoryState {
+	rating := toFSRSRating(performance)
+	if state.IsZero() {
+		return m.model.initialMemoryState(rating)
+	}
+
+	return m.model.nextMemoryState(state, m.Retrievability(state, lastReviewedAt, at), rating)
+}
+
+// MemoryState estimates the memory state of the card and the time it has been reviewed the last time.
+// The reviews of the card are replayed if there are any, otherwise the memory state is taken from the card
+// or seeded from its schedule. The zero state is returned for a card never answered.
+func (m RecallModel) MemoryState(card entity.Card, reviews []entity.Review) (entity.MemoryState, time.Time) {
+	reviews = slices.SortedFunc(slices.Values(reviews), func(a, b entity.Review) int {
+		return a.ReviewedAt.Compare(b.ReviewedAt)
+	})
+
+	var (
+		state          entity.MemoryState
+		lastReviewedAt time.Time
+	)
+	for _, review := range reviews {
+		state = m.Next(state, lastReviewedAt, review.ReviewedAt, review.Performance)
+		lastReviewedAt = review.ReviewedAt
+	}
+	if !state.IsZero() {
+		return state, lastReviewedAt
+	}
+
+	switch {
+	case card.NextDueDate.IsZero():
+		return entity.MemoryState{}, time.Time{}
+	case !card.MemoryState.IsZero() && !card.LastReviewedAt.IsZero():
+		return card.MemoryState, card.LastReviewedAt
+	default:
+		return m.model.seedMemoryState(card, 0, card.NextDueDate)
+	}
+}
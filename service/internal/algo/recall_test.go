@@ -0,0 +1,39 @@
+package algo_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/genvmoroz/lale/service/internal/algo"
+	"github.com/genvmoroz/lale/service/pkg/entity"
+)
+
+func TestRecallModel(t *testing.T) {
+	t.Parallel()
+
+	m := algo.NewRecallModel(entity.SchedulerParameters{})
+	reviewedAt := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	state := m.Next(entity.MemoryState{}, time.Time{}, reviewedAt, 4)
+	if state.IsZero() {
+		t.Fatal("Next() of a new card = zero state, want the initial state")
+	}
+
+	stabilityDays := time.Duration(state.Stability * float64(24*time.Hour))
+	if got := m.Retrievability(state, reviewedAt, reviewedAt.Add(stabilityDays)); math.Abs(got-0.9) > 1e-9 {
+		t.Fatalf("Retrievability() after the stability = %v, want 0.9", got)
+	}
+
+	replayed, lastReviewedAt := m.MemoryState(
+		entity.Card{NextDueDate: reviewedAt.AddDate(0, 0, 3)},
+		[]entity.Review{{ReviewedAt: reviewedAt, Performance: 4}},
+	)
+	if replayed != state || !lastReviewedAt.Equal(reviewedAt) {
+		t.Fatalf("MemoryState() = %+v at %v, want %+v at %v", replayed, lastReviewedAt, state, reviewedAt)
+	}
+
+	if got, _ := m.MemoryState(entity.Card{}, nil); !got.IsZero() {
+		t.Fatalf("MemoryState() of a new card = %+v, want zero state", got)
+	}
+}
@@ -0,0 +1,69 @@
+package algo
+
+import (
+	"time"
+
+	"github.com/genvmoroz/lale/service/pkg/entity"
+)
+
+// LearningSteps schedule the new cards and the forgotten ones within minutes or hours, e.g. 10m then 1h,
+// until they are answered correctly at every step and graduate to the scheduler's intervals in days.
+// Again returns a card to the first step, hard repeats the current step, good moves it to the next step
+// and easy graduates it at once.
+type LearningSteps struct {
+	now        func() time.Time
+	learning   []time.Duration
+	relearning []time.Duration
+}
+
+func NewLearningSteps(now func() time.Time, learning, relearning []time.Duration) *LearningSteps {
+	return &LearningSteps{
+		now:        now,
+		learning:   learning,
+		relearning: relearning,
+	}
+}
+
+// Next returns the step of the card answered with the performance.
+func (s LearningSteps) Next(card entity.Card, performance uint32) entity.LearningStep {
+	switch {
+	case card.Learning.InSteps():
+		return s.nextStep(card.Learning, performance)
+	case card.NextDueDate.IsZero() && len(s.learning) > 0:
+		return s.nextStep(entity.LearningState{Phase: entity.LearningPhaseLearning}, performance)
+	case performance < passingPerformance && len(s.relearning) > 0:
+		return entity.LearningStep{
+			Due:      s.now().Add(s.relearning[0]),
+			State:    entity.LearningState{Phase: entity.LearningPhaseRelearning},
+			Schedule: true,
+		}
+	default:
+		return entity.LearningStep{Schedule: true}
+	}
+}
+
+func (s LearningSteps) nextStep(state entity.LearningState, performance uint32) entity.LearningStep {
+	steps := s.learning
+	if state.Phase == entity.LearningPhaseRelearning {
+		steps = s.relearning
+	}
+
+	index := int(state.Step)
+	switch toFSRSRating(performance) {
+	case ratingAgain:
+		index = 0
+	case ratingHard:
+	case ratingGood:
+		index++
+	case ratingEasy:
+		index = len(steps)
+	}
+	if index >= len(steps) {
+		return entity.LearningStep{Schedule: true}
+	}
+
+	return entity.LearningStep{
+		Due:   s.now().Add(steps[index]),
+		State: entity.LearningState{Phase: state.Phase, Step: uint32(index)}, //nolint:gosec // index of a step
+	}
+}
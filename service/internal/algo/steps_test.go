@@ -0,0 +1,99 @@
+package algo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/genvmoroz/lale/service/internal/algo"
+	"github.com/genvmoroz/lale/service/pkg/entity"
+)
+
+func TestLearningStepsNext(t *testing.T) {
+	t.Parallel()
+
+	var (
+		testNowTime = time.Now()
+		testNow     = func() time.Time { return testNowTime }
+	)
+
+	learning := func(step uint32) entity.LearningState {
+		return entity.LearningState{Phase: entity.LearningPhaseLearning, Step: step}
+	}
+	relearning := func(step uint32) entity.LearningState {
+		return entity.LearningState{Phase: entity.LearningPhaseRelearning, Step: step}
+	}
+	reviewCard := entity.Card{NextDueDate: testNowTime, ConsecutiveCorrectAnswersNumber: 3}
+
+	testcases := map[string]struct {
+		card        entity.Card
+		performance uint32
+		want        entity.LearningStep
+	}{
+		"new card again": {
+			card:        entity.Card{},
+			performance: 0,
+			want:        entity.LearningStep{Due: testNowTime.Add(10 * time.Minute), State: learning(0)},
+		},
+		"new card good": {
+			card:        entity.Card{},
+			performance: 4,
+			want:        entity.LearningStep{Due: testNowTime.Add(time.Hour), State: learning(1)},
+		},
+		"new card easy": {
+			card:        entity.Card{},
+			performance: 5,
+			want:        entity.LearningStep{Schedule: true},
+		},
+		"learning hard": {
+			card:        entity.Card{NextDueDate: testNowTime, Learning: learning(1)},
+			performance: 3,
+			want:        entity.LearningStep{Due: testNowTime.Add(time.Hour), State: learning(1)},
+		},
+		"learning again": {
+			card:        entity.Card{NextDueDate: testNowTime, Learning: learning(1)},
+			performance: 1,
+			want:        entity.LearningStep{Due: testNowTime.Add(10 * time.Minute), State: learning(0)},
+		},
+		"learning graduates": {
+			card:        entity.Card{NextDueDate: testNowTime, Learning: learning(1)},
+			performance: 4,
+			want:        entity.LearningStep{Schedule: true},
+		},
+		"review lapses": {
+			card:        reviewCard,
+			performance: 0,
+			want:        entity.LearningStep{Due: testNowTime.Add(5 * time.Minute), State: relearning(0), Schedule: true},
+		},
+		"review passes": {
+			card:        reviewCard,
+			performance: 4,
+			want:        entity.LearningStep{Schedule: true},
+		},
+		"relearning graduates": {
+			card:        entity.Card{NextDueDate: testNowTime, Learning: relearning(0)},
+			performance: 4,
+			want:        entity.LearningStep{Schedule: true},
+		},
+	}
+	for name, testcase := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			s := algo.NewLearningSteps(testNow, []time.Duration{10 * time.Minute, time.Hour}, []time.Duration{5 * time.Minute})
+			if got := s.Next(testcase.card, testcase.performance); got != testcase.want {
+				t.Fatalf("Next() = %+v, want %+v", got, testcase.want)
+			}
+		})
+	}
+}
+
+func TestLearningStepsNextWithoutSteps(t *testing.T) {
+	t.Parallel()
+
+	s := algo.NewLearningSteps(time.Now, nil, nil)
+	for _, card := range []entity.Card{{}, {NextDueDate: time.Now()}} {
+		if got := s.Next(card, 0); got != (entity.LearningStep{Schedule: true}) {
+			t.Fatalf("Next() = %+v, want the answer scheduled by the algorithm", got)
+		}
+	}
+}
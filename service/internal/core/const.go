@@ -1,5 +1,34 @@
 package core
 
+// Performance rating is the recall quality a user reports for a card on the 0-5 scale,
+// e.g. again (0), hard (3), good (4), easy (5).
 const (
 	MaxAllowedPerformanceRating = 5
+	// MinPassingPerformanceRating is the lowest rating counted as a correct answer,
+	// anything below it resets the consecutive correct answers streak.
+	MinPassingPerformanceRating = 3
+)
+
+// Review history page sizes, zero page size in a request means the default one.
+const (
+	DefaultReviewHistoryPageSize = 50
+	MaxReviewHistoryPageSize     = 500
+)
+
+// MinReviewsNumberToOptimise is the size of the review history the scheduler parameters are fitted to at least.
+const MinReviewsNumberToOptimise = 100
+
+// Daily limits of the users who haven't set their own for a language.
+const (
+	DefaultDailyNewCardsLimit = 20
+	DefaultDailyReviewsLimit  = 200
+)
+
+// Limits of the user-authored content of the words.
+const (
+	// MaxImageSize keeps an image in a single gRPC message, the default message size limit is 4MB.
+	MaxImageSize   = 3 << 20
+	MaxNotesLength = 2000
+	// MaxNoteLength limits the text, the answer and the explanation of a phrase, cloze or grammar card together.
+	MaxNoteLength = 4000
 )
@@ -7,6 +7,28 @@ import (
 	"golang.org/x/text/language"
 )
 
+// CramOrder tells the order the cards are crammed in.
+type CramOrder string
+
+const (
+	// CramOrderHardest puts the most lapsed cards first, then the most difficult ones.
+	CramOrderHardest CramOrder = "hardest"
+	// CramOrderRecent puts the most recently added cards first.
+	CramOrderRecent CramOrder = "recent"
+	// CramOrderRandom shuffles the cards.
+	CramOrderRandom CramOrder = "random"
+)
+
+// ResetMode tells how a card is returned to the study.
+type ResetMode string
+
+const (
+	// ResetModeRelearn makes the card due for the repetition today.
+	ResetModeRelearn ResetMode = "relearn"
+	// ResetModeForget makes the card new, so it's learnt from scratch.
+	ResetModeForget ResetMode = "forget"
+)
+
 type (
 	InspectCardRequest struct {
 		UserID   string
@@ -26,9 +48,12 @@ type (
 	}
 
 	CreateCardRequest struct {
-		UserID              string
-		Language            language.Tag
+		UserID   string
+		Language language.Tag
+		// Type is the type of the card, the empty type creates a word card.
+		Type                entity.NoteType
 		WordInformationList []entity.WordInformation
+		Note                entity.Note
 	}
 
 	DeleteCardRequest struct {
@@ -36,36 +61,193 @@ type (
 		CardID string
 	}
 
+	GetCardRequest struct {
+		UserID string
+		CardID string
+	}
+
 	MarkCardLearntRequest struct {
 		UserID string
 		CardID string
 	}
 
+	ResetCardRequest struct {
+		UserID string
+		CardID string
+		Mode   ResetMode
+	}
+
+	SuspendCardRequest struct {
+		UserID string
+		CardID string
+		// Suspended suspends the card if set, resumes it otherwise.
+		Suspended bool
+	}
+
+	UpdateCardTagsRequest struct {
+		UserID string
+		CardID string
+		// Add are the tags to tag the card with, the tags the card already has are skipped.
+		Add []string
+		// Remove are the tags to remove from the card.
+		Remove []string
+	}
+
+	SetCardDeckRequest struct {
+		UserID string
+		CardID string
+		// Deck is the name of the deck to move the card to, empty removes the card from its deck.
+		Deck string
+	}
+
+	GetDecksRequest struct {
+		UserID string
+		// Language selects the decks of the cards in the language, language.Und selects all the decks.
+		Language language.Tag
+	}
+
+	GetDecksResponse struct {
+		UserID string
+		Decks  []Deck
+	}
+
+	// Deck is a named group of the user's cards.
+	Deck struct {
+		Name  string
+		Cards uint32
+	}
+
+	UploadImageRequest struct {
+		UserID string
+		Data   []byte
+	}
+
+	GetImageRequest struct {
+		UserID  string
+		ImageID string
+	}
+
+	GetAudioRequest struct {
+		UserID  string
+		AudioID string
+	}
+
+	BuryCardRequest struct {
+		UserID string
+		CardID string
+		// Buried hides the card until the start of the user's next day if set, shows it again otherwise.
+		Buried bool
+	}
+
 	GetCardsRequest struct {
 		UserID   string
 		Language language.Tag
+		// Filter narrows the cards down to a deck or tags, the daily limits still count all the cards
+		// in the language.
+		Filter entity.CardFilter
+	}
+
+	GetCardsForCramRequest struct {
+		UserID string
+		// Language selects the cards in the language, language.Und selects the cards in all languages.
+		Language language.Tag
+		// CardIDs narrow the cards down to a subset, empty selects all the cards.
+		CardIDs []string
+		// Order is the order of the cards, empty is CramOrderRandom.
+		Order CramOrder
+		// Limit caps the number of cards returned, zero returns all of them.
+		Limit uint32
 	}
 
 	GetCardsResponse struct {
 		UserID   string
 		Language language.Tag
 		Cards    []entity.Card
+		// RemainingToday is the number of cards the daily limit still allows to study today,
+		// the returned cards included.
+		RemainingToday uint32
+		// Postponed is the number of cards held back until tomorrow by the daily limit.
+		Postponed uint32
 	}
 
 	UpdateCardRequest struct {
-		UserID              string
-		CardID              string
+		UserID string
+		CardID string
+		// Type is the type the card is rewritten to, the empty type rewrites it to a word card.
+		Type                entity.NoteType
 		WordInformationList []entity.WordInformation
+		Note                entity.Note
+		// Version is the version of the card the update is based on, 0 skips the check.
+		Version uint64
 	}
 
 	UpdateCardPerformanceRequest struct {
-		UserID         string
-		CardID         string
-		IsInputCorrect bool
+		UserID       string
+		CardID       string
+		Performance  uint32
+		TimeToAnswer time.Duration
+		// Direction is the direction the card has been answered in, production if empty.
+		Direction entity.ReviewDirection
+	}
+
+	GetUserProfileRequest struct {
+		UserID string
+	}
+
+	UpdateUserProfileRequest struct {
+		UserID  string
+		Profile entity.Profile
+	}
+
+	UpdateDailyLimitsRequest struct {
+		UserID   string
+		Language language.Tag
+		Limits   entity.DailyLimits
+	}
+
+	UpdateGraduationPolicyRequest struct {
+		UserID string
+		Policy entity.GraduationPolicy
+	}
+
+	UndoLastReviewRequest struct {
+		UserID string
+		// CardID selects the card to undo the last review of, empty undoes the last review of the user.
+		CardID string
+	}
+
+	GetReviewHistoryRequest struct {
+		UserID string
+		// CardID narrows the history down to a single card, empty returns the reviews of all the user's cards.
+		CardID    string
+		PageSize  uint32
+		PageToken string
+	}
+
+	GetReviewHistoryResponse struct {
+		// Reviews are ordered from the newest to the oldest.
+		Reviews []entity.Review
+		// NextPageToken is empty on the last page.
+		NextPageToken string
 	}
 
 	UpdateCardPerformanceResponse struct {
 		NextDueDate time.Time
+		// Graduated is set if the answer has made the card learnt by the user's graduation policy.
+		Graduated bool
+	}
+
+	OptimiseSchedulerParametersRequest struct {
+		UserID string
+		// TargetRetention is the recall probability to schedule the reviews at,
+		// zero keeps the desired retention of the current parameters.
+		TargetRetention float64
+	}
+
+	OptimiseSchedulerParametersResponse struct {
+		PredictedRetentionBefore float64
+		PredictedRetentionAfter  float64
+		ReviewsNumber            uint32
 	}
 
 	GetSentencesRequest struct {
@@ -81,6 +263,8 @@ type (
 	GenerateStoryRequest struct {
 		UserID   string
 		Language language.Tag
+		// Filter narrows the words of the story down to the cards in a deck or having tags.
+		Filter entity.CardFilter
 	}
 
 	GenerateStoryResponse struct {
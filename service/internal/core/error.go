@@ -44,3 +44,14 @@ func NewFailedPreconditionError() error {
 func IsFailedPreconditionError(err error) bool {
 	return errors.Is(err, errFailedPrecondition)
 }
+
+var errConflict = fmt.Errorf("conflict")
+
+// NewConflictError is returned when the card has been changed by another request since it has been read.
+func NewConflictError() error {
+	return errConflict
+}
+
+func IsConflictError(err error) bool {
+	return errors.Is(err, errConflict)
+}
@@ -8,7 +8,10 @@ import (
 	"fmt"
 	"maps"
 	"math/rand/v2"
+	"net/http"
+	"path"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -24,10 +27,93 @@ import (
 type (
 	CardRepo interface {
 		GetCardsByWords(ctx context.Context, userID string, words []string) ([]entity.Card, error)
-		WordsExist(ctx context.Context, userID string, words []string) (bool, error)
-		GetCardsForUser(ctx context.Context, userID string) ([]entity.Card, error)
+		// WordsExist reports whether the user has a card in the language having any of the words, ignoring the case.
+		WordsExist(ctx context.Context, userID string, lang language.Tag, words []string) (bool, error)
+		// GetCardByID returns the user's card, the second value reports whether the card has been found.
+		GetCardByID(ctx context.Context, userID, cardID string) (entity.Card, bool, error)
+		// FindByWord returns the user's cards having the word, or the phrase cards of the phrase, ignoring the case.
+		FindByWord(ctx context.Context, userID, word string) ([]entity.Card, error)
+		// GetCards returns the user's cards in the language matching the filter, language.Und returns the cards
+		// in all languages.
+		GetCards(ctx context.Context, userID string, lang language.Tag, filter entity.CardFilter) ([]entity.Card, error)
+		// GetCardsByIDs returns the user's cards in the language having the IDs, empty cardIDs return all the cards
+		// in the language, language.Und returns the cards in all languages.
+		GetCardsByIDs(ctx context.Context, userID string, lang language.Tag, cardIDs []string) ([]entity.Card, error)
+		// GetScheduledCards returns the user's cards in the language answered at least once and not learnt,
+		// the ones matching the filter.
+		GetScheduledCards(
+			ctx context.Context, userID string, lang language.Tag, filter entity.CardFilter,
+		) ([]entity.Card, error)
+		// GetNewCards returns the user's cards in the language never answered yet, neither learnt nor suspended.
+		GetNewCards(ctx context.Context, userID string, lang language.Tag) ([]entity.Card, error)
+		// GetDueCards returns the user's cards in the language which may be due before the given time
+		// in production or in the directions, neither learnt nor suspended.
+		GetDueCards(
+			ctx context.Context, userID string, lang language.Tag, before time.Time, directions []entity.ReviewDirection,
+		) ([]entity.Card, error)
+		// GetLearntCards returns the user's learnt cards in the language, the suspended ones are left out.
+		GetLearntCards(ctx context.Context, userID string, lang language.Tag) ([]entity.Card, error)
+		// GetLeeches returns the user's leeches in the language, language.Und returns all the leeches.
+		GetLeeches(ctx context.Context, userID string, lang language.Tag) ([]entity.Card, error)
+		// GetDecks returns the deck of every user's card in a deck in the language, language.Und returns
+		// the decks of the cards in all languages.
+		GetDecks(ctx context.Context, userID string, lang language.Tag) ([]string, error)
+		// SaveCards stores the cards with their versions incremented, entity.ErrVersionConflict is returned
+		// if a card has been changed or deleted since it has been read, entity.ErrDuplicateWord if another
+		// card of the user in the language has a word of a card.
 		SaveCards(ctx context.Context, cards []entity.Card) error
 		DeleteCard(ctx context.Context, cardID string) error
+		// GetDueDates returns the due dates of the user's cards to repeat scheduled within [from, to),
+		// the learnt and the suspended cards and the excluded card are left out.
+		GetDueDates(ctx context.Context, userID, excludedCardID string, from, to time.Time) ([]time.Time, error)
+	}
+
+	UserRepo interface {
+		// GetUser returns the user with the given ID, the second value reports whether the user has been found.
+		GetUser(ctx context.Context, userID string) (entity.User, bool, error)
+		SaveUser(ctx context.Context, user entity.User) error
+	}
+
+	ReviewRepo interface {
+		AddReview(ctx context.Context, review entity.Review) error
+		// GetDailyUsage counts the cards of the user in the language studied since the start of the day.
+		GetDailyUsage(ctx context.Context, userID string, lang language.Tag, since time.Time) (entity.DailyUsage, error)
+		// GetReviewsForUser returns the reviews done since the given time, zero since returns all the reviews.
+		GetReviewsForUser(ctx context.Context, userID string, since time.Time) ([]entity.Review, error)
+		// GetReviews returns a page of the user's reviews from the newest to the oldest,
+		// the reviews of a single card if cardID is not empty.
+		GetReviews(ctx context.Context, userID, cardID string, skip, limit int64) ([]entity.Review, error)
+		// MarkReviewUndone marks the review undone, the review is kept in the log but left out of the reads.
+		MarkReviewUndone(ctx context.Context, reviewID string) error
+	}
+
+	LearningSteps interface {
+		// Next returns the step of the card answered with the performance.
+		Next(card entity.Card, performance uint32) entity.LearningStep
+	}
+
+	LeechDetector interface {
+		// Check marks the card just lapsed a leech if it has lapsed too many times,
+		// it reports whether the card has been marked.
+		Check(card *entity.Card) bool
+	}
+
+	MaintenanceScheduler interface {
+		// Due reports whether the learnt card is due for a retention check.
+		Due(user entity.User, card entity.Card) bool
+		// NextCheck returns the day the card checked at checkedAt is due for the next check on.
+		NextCheck(user entity.User, checkedAt time.Time) time.Time
+		// DailyLimit returns the number of the learnt cards checked per day.
+		DailyLimit() uint32
+	}
+
+	DueDateBalancer interface {
+		// Window returns the first and the last day the card reviewed at reviewedAt and scheduled
+		// to the due date may be moved to, both are the due date if the card mustn't be moved.
+		Window(user entity.User, reviewedAt, due time.Time) (time.Time, time.Time)
+		// Balance picks the due date within the window [from, to],
+		// dueDates are the due dates of the user's other cards falling within the window.
+		Balance(user entity.User, due, from, to time.Time, dueDates []time.Time) time.Time
 	}
 
 	SessionRepo interface {
@@ -35,8 +121,31 @@ type (
 		CloseSession(userID string) error
 	}
 
+	// Transactor runs the operation in a transaction, the repos called with the context passed to the operation
+	// take part in it, so their changes are committed together or not at all.
+	Transactor interface {
+		InTransaction(ctx context.Context, operation func(ctx context.Context) error) error
+	}
+
 	AnkiAlgo interface { // todo: rename it, the name should not be pointing to the Anki algorithm
-		CalculateNextDueDate(performance uint32, consecutiveCorrectAnswersNumber uint32) time.Time
+		// CalculateNextDueDate schedules the card answered with the given performance,
+		// the card already counts the answer in its consecutive correct answers number.
+		// The scheduler parameters and the calendar are taken from the user.
+		CalculateNextDueDate(
+			user entity.User,
+			performance uint32,
+			card entity.Card,
+		) (time.Time, entity.MemoryState)
+		// DefaultParameters returns the parameters of the users who haven't optimised their own.
+		DefaultParameters() entity.SchedulerParameters
+	}
+
+	SchedulerOptimiser interface {
+		Optimise(
+			params entity.SchedulerParameters,
+			reviews []entity.Review,
+			targetRetention float64,
+		) (entity.SchedulerOptimisation, error)
 	}
 
 	// todo: rename it to something like AI Generator
@@ -54,13 +163,32 @@ type (
 		ToSpeech(ctx context.Context, req speech.ToSpeechRequest) ([]byte, error)
 	}
 
+	// BlobStore keeps the binary objects too large to be stored in the cards, e.g. the images and the audio.
+	BlobStore interface {
+		Put(ctx context.Context, key string, data []byte) error
+		// Get returns the object stored under the key, the second value reports whether it has been found.
+		Get(ctx context.Context, key string) ([]byte, bool, error)
+		// Delete removes the object stored under the key, a missing object is not an error.
+		Delete(ctx context.Context, key string) error
+	}
+
 	Service struct {
 		cardRepo         CardRepo
+		userRepo         UserRepo
+		reviewRepo       ReviewRepo
+		transactor       Transactor
 		sessionRepo      SessionRepo
 		aiHelper         AIHelper
 		ankiAlgo         AnkiAlgo
+		learningSteps    LearningSteps
+		leeches          LeechDetector
+		maintenance      MaintenanceScheduler
+		balancer         DueDateBalancer
+		optimiser        SchedulerOptimiser
 		dictionary       Dictionary
 		textToSpeechRepo TextToSpeechRepo
+		blobStore        BlobStore
+		audioStore       BlobStore
 
 		validator validator
 	}
@@ -79,15 +207,34 @@ const (
 // only british english and american english are supported now.
 func NewService(
 	cardRepo CardRepo,
+	userRepo UserRepo,
+	reviewRepo ReviewRepo,
+	transactor Transactor,
 	sessionRepo SessionRepo,
 	aiHelper AIHelper,
 	anki AnkiAlgo,
+	learningSteps LearningSteps,
+	leeches LeechDetector,
+	maintenance MaintenanceScheduler,
+	balancer DueDateBalancer,
+	optimiser SchedulerOptimiser,
 	dictionary Dictionary,
 	textToSpeechRepo TextToSpeechRepo,
+	blobStore BlobStore,
+	audioStore BlobStore,
 ) (*Service, error) {
 	if lo.IsNil(cardRepo) {
 		return nil, errors.New("card repo is required")
 	}
+	if lo.IsNil(userRepo) {
+		return nil, errors.New("user repo is required")
+	}
+	if lo.IsNil(reviewRepo) {
+		return nil, errors.New("review repo is required")
+	}
+	if lo.IsNil(transactor) {
+		return nil, errors.New("transactor is required")
+	}
 	if lo.IsNil(sessionRepo) {
 		return nil, errors.New("session repo is required")
 	}
@@ -97,20 +244,51 @@ func NewService(
 	if lo.IsNil(anki) {
 		return nil, errors.New("anki algo is required")
 	}
+	if lo.IsNil(learningSteps) {
+		return nil, errors.New("learning steps are required")
+	}
+	if lo.IsNil(leeches) {
+		return nil, errors.New("leech detector is required")
+	}
+	if lo.IsNil(maintenance) {
+		return nil, errors.New("maintenance scheduler is required")
+	}
+	if lo.IsNil(balancer) {
+		return nil, errors.New("due date balancer is required")
+	}
+	if lo.IsNil(optimiser) {
+		return nil, errors.New("scheduler optimiser is required")
+	}
 	if lo.IsNil(dictionary) {
 		return nil, errors.New("dictionary is required")
 	}
 	if lo.IsNil(textToSpeechRepo) {
 		return nil, errors.New("textToSpeechRepo is required")
 	}
+	if lo.IsNil(blobStore) {
+		return nil, errors.New("blob store is required")
+	}
+	if lo.IsNil(audioStore) {
+		return nil, errors.New("audio store is required")
+	}
 
 	return &Service{
 		cardRepo:         cardRepo,
+		userRepo:         userRepo,
+		reviewRepo:       reviewRepo,
+		transactor:       transactor,
 		sessionRepo:      sessionRepo,
 		aiHelper:         aiHelper,
 		ankiAlgo:         anki,
+		learningSteps:    learningSteps,
+		leeches:          leeches,
+		maintenance:      maintenance,
+		balancer:         balancer,
+		optimiser:        optimiser,
 		dictionary:       dictionary,
 		textToSpeechRepo: textToSpeechRepo,
+		blobStore:        blobStore,
+		audioStore:       audioStore,
 		validator:        validator{},
 	}, nil
 }
@@ -143,12 +321,12 @@ func (s *Service) InspectCard(ctx context.Context, req InspectCardRequest) (enti
 	defer closeSession()
 
 	logger.FromContext(ctx).
-		Debug("get all cards for user")
-	cards, err := s.cardRepo.GetCardsForUser(ctx, req.UserID)
+		Debug("find cards by word")
+	cards, err := s.cardRepo.FindByWord(ctx, req.UserID, req.Word)
 	if err != nil {
 		return entity.Card{}, logAndReturnError(
 			ctx,
-			fmt.Sprintf("get cards: %s", err.Error()),
+			fmt.Sprintf("find cards by word: %s", err.Error()),
 			map[string]any{logFieldUserID: req.UserID},
 		)
 	}
@@ -160,7 +338,8 @@ func (s *Service) InspectCard(ctx context.Context, req InspectCardRequest) (enti
 					return strings.EqualFold(item.Word, req.Word)
 				},
 			)
-			return wordFound
+			phraseFound := item.NoteType() == entity.NoteTypePhrase && strings.EqualFold(item.Note.Text, req.Word)
+			return wordFound || phraseFound
 		},
 	)
 	if found {
@@ -243,27 +422,36 @@ func (s *Service) CreateCard(ctx context.Context, req CreateCardRequest) (entity
 	}
 	defer closeSession()
 
-	logger.FromContext(ctx).
-		Debug("check if words already exist")
-	exist, err := s.cardRepo.WordsExist(ctx, req.UserID, extractWords(req.WordInformationList))
-	if err != nil {
-		return entity.Card{}, logAndReturnError(
-			ctx,
-			fmt.Sprintf("check if words already exist: %s", err.Error()),
-			map[string]any{logFieldUserID: req.UserID},
-		)
-	}
-	if exist {
+	noteType := cmp.Or(req.Type, entity.NoteTypeWords)
+	if noteType == entity.NoteTypeWords {
 		logger.FromContext(ctx).
-			Debug("cards with words already exist")
-		return entity.Card{}, fmt.Errorf("%w: words %v", NewAlreadyExistsError(), extractWords(req.WordInformationList))
+			Debug("check if words already exist")
+		var exist bool
+		exist, err = s.cardRepo.WordsExist(ctx, req.UserID, req.Language, extractWords(req.WordInformationList))
+		if err != nil {
+			return entity.Card{}, logAndReturnError(
+				ctx,
+				fmt.Sprintf("check if words already exist: %s", err.Error()),
+				map[string]any{logFieldUserID: req.UserID},
+			)
+		}
+		if exist {
+			logger.FromContext(ctx).
+				Debug("cards with words already exist")
+			return entity.Card{}, fmt.Errorf("%w: words %v", NewAlreadyExistsError(), extractWords(req.WordInformationList))
+		}
 	}
 
+	now := time.Now().UTC()
 	card := entity.Card{
 		ID:                  uuid.NewString(),
 		UserID:              req.UserID,
 		Language:            req.Language,
+		Type:                noteType,
 		WordInformationList: req.WordInformationList,
+		Note:                req.Note,
+		CreatedAt:           now,
+		UpdatedAt:           now,
 	}
 
 	logger.FromContext(ctx).
@@ -283,15 +471,8 @@ func (s *Service) CreateCard(ctx context.Context, req CreateCardRequest) (entity
 		return entity.Card{}, fmt.Errorf("enrich words with audio: %w", err)
 	}
 
-	logger.
-		FromContext(ctx).
-		Debug("save card")
-	if err = s.cardRepo.SaveCards(ctx, []entity.Card{card}); err != nil {
-		return entity.Card{}, logAndReturnError(
-			ctx,
-			fmt.Sprintf("save card: %s", err.Error()),
-			map[string]any{logFieldUserID: req.UserID},
-		)
+	if err = s.saveCard(ctx, &card); err != nil {
+		return entity.Card{}, err
 	}
 
 	return card, nil
@@ -317,8 +498,8 @@ func (s *Service) GetAllCards(ctx context.Context, req GetCardsRequest) (GetCard
 	defer closeSession()
 
 	logger.FromContext(ctx).
-		Debug("get all cards for user")
-	cards, err := s.cardRepo.GetCardsForUser(ctx, req.UserID)
+		Debug("get cards in language")
+	cards, err := s.cardRepo.GetCards(ctx, req.UserID, req.Language, req.Filter)
 	if err != nil {
 		return GetCardsResponse{}, logAndReturnError(
 			ctx,
@@ -327,24 +508,138 @@ func (s *Service) GetAllCards(ctx context.Context, req GetCardsRequest) (GetCard
 		)
 	}
 
-	apiCards := make([]entity.Card, 0, len(cards))
+	return GetCardsResponse{
+		UserID:   req.UserID,
+		Language: req.Language,
+		Cards:    cards,
+	}, nil
+}
+
+// GetLeeches returns the user's leeches in the language, all the leeches if the language is not set,
+// the cards lapsed the most go first.
+func (s *Service) GetLeeches(ctx context.Context, req GetCardsRequest) (GetCardsResponse, error) {
+	if err := s.validator.ValidateGetCardsRequest(req); err != nil {
+		return GetCardsResponse{}, fmt.Errorf("%w: %w", NewValidationError(), err)
+	}
+
+	ctx = createContextWithCorrelationLogger(ctx,
+		map[string]any{
+			logFieldUserID:   req.UserID,
+			logFieldLanguage: req.Language.String(),
+			logFieldRequest:  "GetLeeches",
+		},
+	)
+
+	closeSession, err := s.createUserSession(ctx, req.UserID)
+	if err != nil {
+		return GetCardsResponse{}, fmt.Errorf("create user session: %w", err)
+	}
+	defer closeSession()
+
+	logger.FromContext(ctx).
+		Debug("get leeches")
+	cards, err := s.cardRepo.GetLeeches(ctx, req.UserID, req.Language)
+	if err != nil {
+		return GetCardsResponse{}, logAndReturnError(
+			ctx,
+			fmt.Sprintf("get leeches: %s", err.Error()),
+			map[string]any{logFieldUserID: req.UserID},
+		)
+	}
+
+	logger.FromContext(ctx).
+		Debug("filter leeches out")
+	leeches := lo.Filter(cards,
+		func(item entity.Card, _ int) bool {
+			return req.Filter.Matches(item)
+		},
+	)
+	slices.SortStableFunc(leeches, func(a, b entity.Card) int {
+		return cmp.Compare(b.Lapses, a.Lapses)
+	})
+
+	return GetCardsResponse{
+		UserID:   req.UserID,
+		Language: req.Language,
+		Cards:    leeches,
+	}, nil
+}
+
+// GetCardsForCram returns the cards to drill regardless of their schedule, including the learnt,
+// the suspended and the buried ones. Cramming doesn't change the schedule, the answers aren't recorded.
+func (s *Service) GetCardsForCram(ctx context.Context, req GetCardsForCramRequest) (GetCardsResponse, error) {
+	if err := s.validator.ValidateGetCardsForCramRequest(req); err != nil {
+		return GetCardsResponse{}, fmt.Errorf("%w: %w", NewValidationError(), err)
+	}
+
+	ctx = createContextWithCorrelationLogger(ctx,
+		map[string]any{
+			logFieldUserID:   req.UserID,
+			logFieldLanguage: req.Language.String(),
+			"Order":          req.Order,
+			logFieldRequest:  "GetCardsForCram",
+		},
+	)
+
+	closeSession, err := s.createUserSession(ctx, req.UserID)
+	if err != nil {
+		return GetCardsResponse{}, fmt.Errorf("create user session: %w", err)
+	}
+	defer closeSession()
 
 	logger.FromContext(ctx).
-		Debug("filter cards out by language")
-	for _, card := range cards {
-		if len(strings.TrimSpace(req.Language.String())) == 0 ||
-			strings.EqualFold(card.Language.String(), req.Language.String()) {
-			apiCards = append(apiCards, card)
-		}
+		Debug("get cards to cram")
+	toCram, err := s.cardRepo.GetCardsByIDs(ctx, req.UserID, req.Language, req.CardIDs)
+	if err != nil {
+		return GetCardsResponse{}, logAndReturnError(
+			ctx,
+			fmt.Sprintf("get cards: %s", err.Error()),
+			map[string]any{logFieldUserID: req.UserID},
+		)
+	}
+
+	sortCardsForCram(toCram, req.Order)
+	if req.Limit > 0 && len(toCram) > int(req.Limit) {
+		toCram = toCram[:req.Limit]
 	}
 
+	shuffleWordsInCards(toCram)
+
 	return GetCardsResponse{
 		UserID:   req.UserID,
 		Language: req.Language,
-		Cards:    apiCards,
+		Cards:    toCram,
 	}, nil
 }
 
+// sortCardsForCram sorts the cards in the order.
+func sortCardsForCram(cards []entity.Card, order CramOrder) {
+	switch order {
+	case CramOrderHardest:
+		slices.SortStableFunc(cards, func(a, b entity.Card) int {
+			return cmp.Or(
+				cmp.Compare(b.Lapses, a.Lapses),
+				cmp.Compare(b.MemoryState.Difficulty, a.MemoryState.Difficulty),
+				cmp.Compare(a.ConsecutiveCorrectAnswersNumber, b.ConsecutiveCorrectAnswersNumber),
+			)
+		})
+	case CramOrderRecent:
+		sortByCreatedAtDesc(cards)
+	case CramOrderRandom, "":
+		rand.Shuffle(len(cards), func(i, j int) {
+			cards[i], cards[j] = cards[j], cards[i]
+		})
+	}
+}
+
+// sortByCreatedAtDesc puts the latest created cards first, the cards created before the creation time has been
+// tracked keep their order at the end.
+func sortByCreatedAtDesc(cards []entity.Card) {
+	slices.SortStableFunc(cards, func(a, b entity.Card) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+}
+
 func (s *Service) UpdateCardPerformance(
 	ctx context.Context,
 	req UpdateCardPerformanceRequest,
@@ -355,10 +650,11 @@ func (s *Service) UpdateCardPerformance(
 
 	ctx = createContextWithCorrelationLogger(ctx,
 		map[string]any{
-			logFieldUserID:   req.UserID,
-			logFieldCardID:   req.CardID,
-			"IsInputCorrect": req.IsInputCorrect,
-			logFieldRequest:  "UpdateCardPerformance",
+			logFieldUserID:  req.UserID,
+			logFieldCardID:  req.CardID,
+			"Performance":   req.Performance,
+			"Direction":     req.Direction,
+			logFieldRequest: "UpdateCardPerformance",
 		},
 	)
 
@@ -368,35 +664,31 @@ func (s *Service) UpdateCardPerformance(
 	}
 	defer closeSession()
 
-	logger.FromContext(ctx).
-		Debug("get all cards for user")
-	cards, err := s.cardRepo.GetCardsForUser(ctx, req.UserID)
+	stored, err := s.getCard(ctx, req.UserID, req.CardID)
+	if err != nil {
+		return UpdateCardPerformanceResponse{}, err
+	}
+	card := &stored
+
+	user, err := s.getUser(ctx, req.UserID)
 	if err != nil {
 		return UpdateCardPerformanceResponse{}, logAndReturnError(
 			ctx,
-			fmt.Sprintf("get cards: %s", err.Error()),
+			fmt.Sprintf("get user: %s", err.Error()),
 			map[string]any{logFieldUserID: req.UserID},
 		)
 	}
 
-	var card *entity.Card
-	for _, c := range cards {
-		c := c
-		if c.ID == req.CardID {
-			logger.FromContext(ctx).
-				Debug("card found")
-			card = &c
-			break
-		}
-	}
-
-	if card == nil {
+	direction := req.Direction.OrProduction()
+	if !card.SupportsDirection(direction) {
 		logger.FromContext(ctx).
-			Debug("card not found")
-		return UpdateCardPerformanceResponse{}, fmt.Errorf("%w: card ID %s", NewNotFoundError(), req.CardID)
+			Debug("direction not supported by the card")
+		return UpdateCardPerformanceResponse{}, fmt.Errorf("%w: card %s can't be reviewed in direction %s",
+			NewFailedPreconditionError(), card.ID, direction)
 	}
 
-	if card.Learnt {
+	// a learnt card is answered only when it's due for the retention check, which is done in production
+	if card.Learnt && (direction != entity.DirectionProduction || !s.maintenance.Due(user, *card)) {
 		logger.FromContext(ctx).
 			Debug("card already learnt")
 		return UpdateCardPerformanceResponse{}, fmt.Errorf("%w: card already learnt", NewFailedPreconditionError())
@@ -405,36 +697,169 @@ func (s *Service) UpdateCardPerformance(
 	logger.FromContext(ctx).
 		Debug("calculate next due date")
 
-	card.AddAnswer(req.IsInputCorrect)
-	consecutiveCorrectAnswersNumber := card.GetConsecutiveCorrectAnswersNumber()
-
-	// todo: remove this line after testing
-	// const performanceDivider = 2
-	// performance := consecutiveCorrectAnswersNumber / performanceDivider
-	performance := consecutiveCorrectAnswersNumber
-	if performance > MaxAllowedPerformanceRating {
-		performance = MaxAllowedPerformanceRating
+	// every direction is scheduled on its own, the card in the direction has the schedule of the direction
+	view := card.InDirection(direction)
+	reviewedAt := time.Now().UTC()
+	var previousInterval time.Duration
+	if !view.LastReviewedAt.IsZero() && !view.NextDueDate.IsZero() {
+		previousInterval = view.NextDueDate.Sub(view.LastReviewedAt)
 	}
-	nextDueDate := s.ankiAlgo.CalculateNextDueDate(performance, consecutiveCorrectAnswersNumber)
-	card.NextDueDate = nextDueDate
 
-	logger.FromContext(ctx).
-		Debug("save card")
-	if err = s.cardRepo.SaveCards(ctx, []entity.Card{*card}); err != nil {
+	cardBefore := view.SchedulingState()
+	nextDueDate, err := s.scheduleAnswerOrCheck(ctx, user, &view, req.Performance, reviewedAt)
+	if err != nil {
 		return UpdateCardPerformanceResponse{}, logAndReturnError(
 			ctx,
-			fmt.Sprintf("save card: %s", err.Error()),
+			fmt.Sprintf("schedule answer: %s", err.Error()),
 			map[string]any{logFieldUserID: req.UserID},
 		)
 	}
+	view.NextDueDate = nextDueDate
+	view.LastReviewedAt = reviewedAt
+	if view.StartedLearningAt.IsZero() {
+		view.StartedLearningAt = reviewedAt
+	}
+
+	// the card is learnt by its production schedule, the other directions follow it
+	graduated := direction == entity.DirectionProduction && user.Graduation.Graduates(view, reviewedAt)
+	if graduated {
+		logger.FromContext(ctx).
+			WithField("ConsecutiveCorrectAnswersNumber", view.ConsecutiveCorrectAnswersNumber).
+			WithField("NextDueDate", view.NextDueDate).
+			Info("card graduated")
+		view.Learnt = true
+		view.LearntAt = user.Profile.Now()
+	}
+
+	card.SetDirectionSchedule(direction, view)
+	card.UpdatedAt = reviewedAt
+
+	review := entity.Review{
+		ID:          uuid.NewString(),
+		UserID:      req.UserID,
+		CardID:      req.CardID,
+		ReviewedAt:  reviewedAt,
+		Performance: req.Performance,
+
+		TimeToAnswer:     req.TimeToAnswer,
+		PreviousInterval: previousInterval,
+		NewInterval:      nextDueDate.Sub(reviewedAt),
+		CardBefore:       &cardBefore,
+		Direction:        direction,
+		Language:         card.Language.String(),
+	}
+	// the review is logged together with the card, so every answer saved has its review
+	// and the card before it to be undone to, and no review is left of an answer not saved
+	if err = s.transactor.InTransaction(ctx, func(txCtx context.Context) error {
+		logger.FromContext(txCtx).
+			Debug("add review")
+		if addErr := s.reviewRepo.AddReview(txCtx, review); addErr != nil {
+			return logAndReturnError(
+				txCtx,
+				fmt.Sprintf("add review: %s", addErr.Error()),
+				map[string]any{logFieldUserID: req.UserID},
+			)
+		}
+
+		return s.saveCard(txCtx, card)
+	}); err != nil {
+		return UpdateCardPerformanceResponse{}, err
+	}
 
 	return UpdateCardPerformanceResponse{
 		NextDueDate: nextDueDate,
+		Graduated:   graduated,
 	}, nil
 }
 
-func (s *Service) UpdateCard(ctx context.Context, req UpdateCardRequest) (entity.Card, error) {
-	if err := s.validator.ValidateUpdateCardRequest(req); err != nil {
+// scheduleAnswer updates the card answered with the performance and returns its next due date. The cards
+// in the learning steps are due within the day, the others are scheduled by the algorithm in days.
+func (s *Service) scheduleAnswer(
+	ctx context.Context,
+	user entity.User,
+	card *entity.Card,
+	performance uint32,
+	reviewedAt time.Time,
+) (time.Time, error) {
+	step := s.learningSteps.Next(*card, performance)
+	card.Learning = step.State
+	if !step.Schedule {
+		return step.Due, nil
+	}
+
+	lapsesBefore := card.Lapses
+	card.AddAnswer(performance >= MinPassingPerformanceRating)
+	if card.Lapses > lapsesBefore && s.leeches.Check(card) {
+		logger.FromContext(ctx).
+			WithField("Lapses", card.Lapses).
+			WithField("Suspended", card.Suspended).
+			Info("card became a leech")
+	}
+	nextDueDate, memoryState := s.ankiAlgo.CalculateNextDueDate(user, performance, *card)
+	card.MemoryState = memoryState
+	if !step.Due.IsZero() {
+		return step.Due, nil
+	}
+
+	return s.balanceDueDate(ctx, user, card.ID, reviewedAt, nextDueDate)
+}
+
+// scheduleAnswerOrCheck schedules the answer, see scheduleAnswer. A learnt card answered correctly passes
+// the retention check and stays learnt until the next check, a learnt card answered wrong is no longer learnt
+// and is scheduled as a lapsed card.
+func (s *Service) scheduleAnswerOrCheck(
+	ctx context.Context,
+	user entity.User,
+	card *entity.Card,
+	performance uint32,
+	reviewedAt time.Time,
+) (time.Time, error) {
+	if !card.Learnt {
+		return s.scheduleAnswer(ctx, user, card, performance, reviewedAt)
+	}
+
+	if performance >= MinPassingPerformanceRating {
+		logger.FromContext(ctx).
+			Debug("card passed the retention check")
+		card.AddAnswer(true)
+		return s.maintenance.NextCheck(user, reviewedAt), nil
+	}
+
+	logger.FromContext(ctx).
+		Info("card failed the retention check, returned to the repetition")
+	card.Learnt = false
+	card.LearntAt = time.Time{}
+
+	return s.scheduleAnswer(ctx, user, card, performance, reviewedAt)
+}
+
+// balanceDueDate moves the due date of the card within its window to spread the user's reviews over the days,
+// the card itself is not counted among the reviews, its stored due date is the one being replaced.
+func (s *Service) balanceDueDate(
+	ctx context.Context,
+	user entity.User,
+	cardID string,
+	reviewedAt, due time.Time,
+) (time.Time, error) {
+	from, to := s.balancer.Window(user, reviewedAt, due)
+	if !from.Before(to) {
+		return due, nil
+	}
+
+	logger.FromContext(ctx).
+		Debug("get due dates within the window")
+	dueDates, err := s.cardRepo.GetDueDates(ctx, user.ID, cardID, from, user.Profile.StartOfDay(to.AddDate(0, 0, 1)))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("get due dates: %w", err)
+	}
+
+	return s.balancer.Balance(user, due, from, to, dueDates), nil
+}
+
+// UndoLastReview restores the scheduling state the card had before its last review and marks the review undone.
+// Only the schedule of the direction the card has been reviewed in is restored, the card is returned in the direction.
+func (s *Service) UndoLastReview(ctx context.Context, req UndoLastReviewRequest) (entity.Card, error) {
+	if err := s.validator.ValidateUndoLastReviewRequest(req); err != nil {
 		return entity.Card{}, fmt.Errorf("%w: %w", NewValidationError(), err)
 	}
 
@@ -442,7 +867,7 @@ func (s *Service) UpdateCard(ctx context.Context, req UpdateCardRequest) (entity
 		map[string]any{
 			logFieldUserID:  req.UserID,
 			logFieldCardID:  req.CardID,
-			logFieldRequest: "UpdateCard",
+			logFieldRequest: "UndoLastReview",
 		},
 	)
 
@@ -453,234 +878,1122 @@ func (s *Service) UpdateCard(ctx context.Context, req UpdateCardRequest) (entity
 	defer closeSession()
 
 	logger.FromContext(ctx).
-		Debug("get all cards for user")
-
-	cards, err := s.cardRepo.GetCardsForUser(ctx, req.UserID)
+		Debug("get last review")
+	reviews, err := s.reviewRepo.GetReviews(ctx, req.UserID, req.CardID, 0, 1)
 	if err != nil {
 		return entity.Card{}, logAndReturnError(
 			ctx,
-			fmt.Sprintf("get cards: %s", err.Error()),
+			fmt.Sprintf("get last review: %s", err.Error()),
 			map[string]any{logFieldUserID: req.UserID},
 		)
 	}
-
-	card, found := lo.Find(cards,
-		func(item entity.Card) bool {
-			return item.ID == req.CardID
-		},
-	)
-	if !found {
+	if len(reviews) == 0 {
 		logger.FromContext(ctx).
-			Debug("card not found")
-		return entity.Card{}, fmt.Errorf("%w: card ID %s", NewNotFoundError(), req.CardID)
+			Debug("no reviews found")
+		return entity.Card{}, fmt.Errorf("%w: no reviews to undo", NewNotFoundError())
 	}
-
-	card.WordInformationList = req.WordInformationList
-
-	if err = s.enrichWordsDetailsFromDictionary(card.Language, card.WordInformationList); err != nil {
-		return entity.Card{}, fmt.Errorf("enrich words details from dictionary: %w", err)
+	review := reviews[0]
+	if review.CardBefore == nil {
+		return entity.Card{}, fmt.Errorf("%w: review %s has no card snapshot", NewFailedPreconditionError(), review.ID)
 	}
 
-	if err = s.enrichWordsWithAudio(ctx, card.Language, card.WordInformationList); err != nil {
-		return entity.Card{}, fmt.Errorf("enrich words with audio: %w", err)
+	card, err := s.getCard(ctx, req.UserID, review.CardID)
+	if err != nil {
+		return entity.Card{}, err
 	}
 
-	logger.FromContext(ctx).
-		Debug("save card")
-	if err = s.cardRepo.SaveCards(ctx, []entity.Card{card}); err != nil {
-		return entity.Card{}, logAndReturnError(
-			ctx,
-			fmt.Sprintf("save card: %s", err.Error()),
-			map[string]any{logFieldUserID: req.UserID},
-		)
+	view := card.InDirection(review.Direction)
+	if !view.LastReviewedAt.Equal(review.ReviewedAt) {
+		logger.FromContext(ctx).
+			Debug("card changed after the review")
+		return entity.Card{}, fmt.Errorf("%w: card %s changed after its last review", NewFailedPreconditionError(), card.ID)
+	}
+
+	view.RestoreSchedulingState(*review.CardBefore)
+	card.SetDirectionSchedule(review.Direction, view)
+	card.UpdatedAt = time.Now().UTC()
+
+	// the review is marked undone together with the card restored, so the review undone is no longer
+	// the last one and the card is never restored with its review still counted
+	if err = s.transactor.InTransaction(ctx, func(txCtx context.Context) error {
+		logger.FromContext(txCtx).
+			Debug("mark review undone")
+		if markErr := s.reviewRepo.MarkReviewUndone(txCtx, review.ID); markErr != nil {
+			return logAndReturnError(
+				txCtx,
+				fmt.Sprintf("mark review undone: %s", markErr.Error()),
+				map[string]any{
+					logFieldUserID: req.UserID,
+					logFieldCardID: card.ID,
+				},
+			)
+		}
+
+		return s.saveCard(txCtx, &card)
+	}); err != nil {
+		return entity.Card{}, err
 	}
 
-	return card, nil
+	return card.InDirection(review.Direction), nil
 }
 
-func (s *Service) GetCardsToLearn(ctx context.Context, req GetCardsRequest) (GetCardsResponse, error) {
+func (s *Service) GetReviewHistory(ctx context.Context, req GetReviewHistoryRequest) (GetReviewHistoryResponse, error) {
+	if err := s.validator.ValidateGetReviewHistoryRequest(req); err != nil {
+		return GetReviewHistoryResponse{}, fmt.Errorf("%w: %w", NewValidationError(), err)
+	}
+
 	ctx = createContextWithCorrelationLogger(ctx,
 		map[string]any{
-			logFieldUserID:   req.UserID,
-			logFieldLanguage: req.Language.String(),
-			logFieldRequest:  "GetCardsToLearn",
+			logFieldUserID:  req.UserID,
+			logFieldCardID:  req.CardID,
+			"PageSize":      req.PageSize,
+			"PageToken":     req.PageToken,
+			logFieldRequest: "GetReviewHistory",
 		},
 	)
 
 	closeSession, err := s.createUserSession(ctx, req.UserID)
 	if err != nil {
-		return GetCardsResponse{}, fmt.Errorf("create user session: %w", err)
+		return GetReviewHistoryResponse{}, fmt.Errorf("create user session: %w", err)
 	}
 	defer closeSession()
 
-	toLearnFilter := func(card entity.Card) bool {
-		return strings.EqualFold(card.Language.String(), req.Language.String()) && card.NeedToLearn()
+	offset, err := parsePageToken(req.PageToken)
+	if err != nil {
+		return GetReviewHistoryResponse{}, fmt.Errorf("%w: %w", NewValidationError(), err)
+	}
+	pageSize := int64(req.PageSize)
+	if pageSize == 0 {
+		pageSize = DefaultReviewHistoryPageSize
 	}
 
-	resp, err := s.getCardsByFilter(ctx, req, toLearnFilter)
+	logger.FromContext(ctx).
+		Debug("get reviews")
+	// one extra review tells whether there is a next page
+	reviews, err := s.reviewRepo.GetReviews(ctx, req.UserID, req.CardID, offset, pageSize+1)
 	if err != nil {
-		return GetCardsResponse{}, err
+		return GetReviewHistoryResponse{}, logAndReturnError(
+			ctx,
+			fmt.Sprintf("get reviews: %s", err.Error()),
+			map[string]any{logFieldUserID: req.UserID},
+		)
 	}
 
-	var newCards []entity.Card
-	for _, card := range slices.Backward(resp.Cards) {
-		newCards = append(newCards, card)
+	var nextPageToken string
+	if int64(len(reviews)) > pageSize {
+		reviews = reviews[:pageSize]
+		nextPageToken = strconv.FormatInt(offset+pageSize, 10)
 	}
-	resp.Cards = newCards
 
-	return resp, nil
+	return GetReviewHistoryResponse{
+		Reviews:       reviews,
+		NextPageToken: nextPageToken,
+	}, nil
 }
 
-// todo: clean a response up to get rid of any definitions/metadata containing the word to repeat.
-func (s *Service) GetCardsToRepeat(ctx context.Context, req GetCardsRequest) (GetCardsResponse, error) {
+// parsePageToken returns the number of reviews the page token skips, the token is opaque for the clients.
+func parsePageToken(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	offset, err := strconv.ParseInt(token, 10, 64)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid pageToken: %s", token)
+	}
+
+	return offset, nil
+}
+
+func (s *Service) OptimiseSchedulerParameters(
+	ctx context.Context,
+	req OptimiseSchedulerParametersRequest,
+) (OptimiseSchedulerParametersResponse, error) {
+	if err := s.validator.ValidateOptimiseSchedulerParametersRequest(req); err != nil {
+		return OptimiseSchedulerParametersResponse{}, fmt.Errorf("%w: %w", NewValidationError(), err)
+	}
+
 	ctx = createContextWithCorrelationLogger(ctx,
 		map[string]any{
-			logFieldUserID:   req.UserID,
-			logFieldLanguage: req.Language.String(),
-			logFieldRequest:  "GetCardsToRepeat",
+			logFieldUserID:    req.UserID,
+			"TargetRetention": req.TargetRetention,
+			logFieldRequest:   "OptimiseSchedulerParameters",
 		},
 	)
 
-	closeSession, err := s.createUserSession(ctx, req.UserID)
+	// the fit takes a while, so the user session is only held to save the fitted parameters
+	// and the user can study meanwhile
+	logger.FromContext(ctx).
+		Debug("get reviews for user")
+	reviews, err := s.reviewRepo.GetReviewsForUser(ctx, req.UserID, time.Time{})
 	if err != nil {
-		return GetCardsResponse{}, fmt.Errorf("create user session: %w", err)
+		return OptimiseSchedulerParametersResponse{}, logAndReturnError(
+			ctx,
+			fmt.Sprintf("get reviews: %s", err.Error()),
+			map[string]any{logFieldUserID: req.UserID},
+		)
 	}
-	defer closeSession()
-
-	toRepeatFilter := func(card entity.Card) bool {
-		return strings.EqualFold(card.Language.String(), req.Language.String()) && card.NeedToRepeat()
+	if len(reviews) < MinReviewsNumberToOptimise {
+		return OptimiseSchedulerParametersResponse{}, fmt.Errorf(
+			"%w: at least %d reviews are required to optimise the scheduler parameters, got %d",
+			NewFailedPreconditionError(), MinReviewsNumberToOptimise, len(reviews),
+		)
 	}
 
-	resp, err := s.getCardsByFilter(ctx, req, toRepeatFilter)
+	user, err := s.getUser(ctx, req.UserID)
 	if err != nil {
-		return GetCardsResponse{}, err
+		return OptimiseSchedulerParametersResponse{}, logAndReturnError(
+			ctx,
+			fmt.Sprintf("get user: %s", err.Error()),
+			map[string]any{logFieldUserID: req.UserID},
+		)
 	}
 
-	sortByConsecutiveCorrectAnswersAndShuffleInChunks(resp.Cards, 5) //nolint:mnd // it's ok, will be removed later
+	targetRetention := req.TargetRetention
+	if targetRetention == 0 {
+		targetRetention = user.SchedulerParameters.DesiredRetention
+	}
+	if targetRetention == 0 {
+		targetRetention = s.ankiAlgo.DefaultParameters().DesiredRetention
+	}
 
-	shuffleWordsInCards(resp.Cards)
+	logger.FromContext(ctx).
+		Debug("optimise scheduler parameters")
+	optimisation, err := s.optimiser.Optimise(user.SchedulerParameters, reviews, targetRetention)
+	if err != nil {
+		return OptimiseSchedulerParametersResponse{}, logAndReturnError(
+			ctx,
+			fmt.Sprintf("optimise scheduler parameters: %s", err.Error()),
+			map[string]any{logFieldUserID: req.UserID},
+		)
+	}
+
+	if err = s.saveSchedulerParameters(ctx, req.UserID, optimisation.Parameters); err != nil {
+		return OptimiseSchedulerParametersResponse{}, err
+	}
+
+	return OptimiseSchedulerParametersResponse{
+		PredictedRetentionBefore: optimisation.PredictedRetentionBefore,
+		PredictedRetentionAfter:  optimisation.PredictedRetentionAfter,
+		ReviewsNumber:            uint32(len(reviews)), //nolint:gosec // the number of reviews fits uint32
+	}, nil
+}
+
+// saveSchedulerParameters sets the scheduler parameters of the user in the user session, the user is read
+// again so the changes made to the profile during the fit are kept.
+func (s *Service) saveSchedulerParameters(ctx context.Context, userID string, params entity.SchedulerParameters) error {
+	closeSession, err := s.createUserSession(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("create user session: %w", err)
+	}
+	defer closeSession()
+
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return logAndReturnError(
+			ctx,
+			fmt.Sprintf("get user: %s", err.Error()),
+			map[string]any{logFieldUserID: userID},
+		)
+	}
+
+	user.SchedulerParameters = params
+
+	logger.FromContext(ctx).
+		Debug("save user")
+	if err = s.userRepo.SaveUser(ctx, user); err != nil {
+		return logAndReturnError(
+			ctx,
+			fmt.Sprintf("save user: %s", err.Error()),
+			map[string]any{logFieldUserID: userID},
+		)
+	}
+
+	return nil
+}
+
+// getCard returns the user's card, the card not found is reported as the not found error.
+func (s *Service) getCard(ctx context.Context, userID, cardID string) (entity.Card, error) {
+	logger.FromContext(ctx).
+		Debug("get card")
+	card, found, err := s.cardRepo.GetCardByID(ctx, userID, cardID)
+	if err != nil {
+		return entity.Card{}, logAndReturnError(
+			ctx,
+			fmt.Sprintf("get card: %s", err.Error()),
+			map[string]any{
+				logFieldUserID: userID,
+				logFieldCardID: cardID,
+			},
+		)
+	}
+	if !found {
+		logger.FromContext(ctx).
+			Debug("card not found")
+		return entity.Card{}, fmt.Errorf("%w: card ID %s", NewNotFoundError(), cardID)
+	}
+
+	return card, nil
+}
+
+// getUser returns the stored user, or a new one with the default profile and scheduler parameters.
+func (s *Service) getUser(ctx context.Context, userID string) (entity.User, error) {
+	user, found, err := s.userRepo.GetUser(ctx, userID)
+	if err != nil {
+		return entity.User{}, fmt.Errorf("get user: %w", err)
+	}
+	if !found {
+		return entity.User{
+			ID:        userID,
+			CreatedAt: time.Now().UTC(),
+		}, nil
+	}
+
+	return user, nil
+}
+
+func (s *Service) GetUserProfile(ctx context.Context, req GetUserProfileRequest) (entity.Profile, error) {
+	if err := s.validator.ValidateGetUserProfileRequest(req); err != nil {
+		return entity.Profile{}, fmt.Errorf("%w: %w", NewValidationError(), err)
+	}
+
+	ctx = createContextWithCorrelationLogger(ctx,
+		map[string]any{
+			logFieldUserID:  req.UserID,
+			logFieldRequest: "GetUserProfile",
+		},
+	)
+
+	closeSession, err := s.createUserSession(ctx, req.UserID)
+	if err != nil {
+		return entity.Profile{}, fmt.Errorf("create user session: %w", err)
+	}
+	defer closeSession()
+
+	logger.FromContext(ctx).
+		Debug("get user")
+	user, err := s.getUser(ctx, req.UserID)
+	if err != nil {
+		return entity.Profile{}, logAndReturnError(
+			ctx,
+			fmt.Sprintf("get user: %s", err.Error()),
+			map[string]any{logFieldUserID: req.UserID},
+		)
+	}
+
+	return user.Profile, nil
+}
+
+func (s *Service) UpdateUserProfile(ctx context.Context, req UpdateUserProfileRequest) (entity.Profile, error) {
+	if err := s.validator.ValidateUpdateUserProfileRequest(req); err != nil {
+		return entity.Profile{}, fmt.Errorf("%w: %w", NewValidationError(), err)
+	}
+
+	ctx = createContextWithCorrelationLogger(ctx,
+		map[string]any{
+			logFieldUserID:  req.UserID,
+			"TimeZone":      req.Profile.TimeZone,
+			"DayStart":      req.Profile.DayStart.String(),
+			logFieldRequest: "UpdateUserProfile",
+		},
+	)
+
+	closeSession, err := s.createUserSession(ctx, req.UserID)
+	if err != nil {
+		return entity.Profile{}, fmt.Errorf("create user session: %w", err)
+	}
+	defer closeSession()
+
+	logger.FromContext(ctx).
+		Debug("get user")
+	user, err := s.getUser(ctx, req.UserID)
+	if err != nil {
+		return entity.Profile{}, logAndReturnError(
+			ctx,
+			fmt.Sprintf("get user: %s", err.Error()),
+			map[string]any{logFieldUserID: req.UserID},
+		)
+	}
+
+	user.Profile = req.Profile
+
+	logger.FromContext(ctx).
+		Debug("save user")
+	if err = s.userRepo.SaveUser(ctx, user); err != nil {
+		return entity.Profile{}, logAndReturnError(
+			ctx,
+			fmt.Sprintf("save user: %s", err.Error()),
+			map[string]any{logFieldUserID: req.UserID},
+		)
+	}
+
+	return user.Profile, nil
+}
+
+func (s *Service) UpdateCard(ctx context.Context, req UpdateCardRequest) (entity.Card, error) {
+	if err := s.validator.ValidateUpdateCardRequest(req); err != nil {
+		return entity.Card{}, fmt.Errorf("%w: %w", NewValidationError(), err)
+	}
+
+	ctx = createContextWithCorrelationLogger(ctx,
+		map[string]any{
+			logFieldUserID:  req.UserID,
+			logFieldCardID:  req.CardID,
+			logFieldRequest: "UpdateCard",
+		},
+	)
+
+	closeSession, err := s.createUserSession(ctx, req.UserID)
+	if err != nil {
+		return entity.Card{}, fmt.Errorf("create user session: %w", err)
+	}
+	defer closeSession()
+
+	card, err := s.getCard(ctx, req.UserID, req.CardID)
+	if err != nil {
+		return entity.Card{}, err
+	}
+	if req.Version != 0 && req.Version != card.Version {
+		logger.FromContext(ctx).
+			Debug("card changed since the version the update is based on")
+		return entity.Card{}, fmt.Errorf(
+			"%w: card %s is at version %d, the update is based on %d",
+			NewConflictError(), card.ID, card.Version, req.Version,
+		)
+	}
+
+	previousWords := card.WordInformationList
+	card.Type = cmp.Or(req.Type, entity.NoteTypeWords)
+	card.WordInformationList = req.WordInformationList
+	card.Note = req.Note
+	// the leech has been rewritten, so its lapses don't tell it's hard to remember anymore
+	card.Leech = false
+
+	if err = s.enrichWordsDetailsFromDictionary(card.Language, card.WordInformationList); err != nil {
+		return entity.Card{}, fmt.Errorf("enrich words details from dictionary: %w", err)
+	}
+
+	if err = s.enrichWordsWithAudio(ctx, card.Language, card.WordInformationList); err != nil {
+		return entity.Card{}, fmt.Errorf("enrich words with audio: %w", err)
+	}
+	card.UpdatedAt = time.Now().UTC()
+
+	if err = s.saveCard(ctx, &card); err != nil {
+		return entity.Card{}, err
+	}
+
+	s.deleteImages(ctx, req.UserID, previousWords, card.WordInformationList)
+
+	return card, nil
+}
+
+func (s *Service) GetCardsToLearn(ctx context.Context, req GetCardsRequest) (GetCardsResponse, error) {
+	ctx = createContextWithCorrelationLogger(ctx,
+		map[string]any{
+			logFieldUserID:   req.UserID,
+			logFieldLanguage: req.Language.String(),
+			logFieldRequest:  "GetCardsToLearn",
+		},
+	)
+
+	closeSession, err := s.createUserSession(ctx, req.UserID)
+	if err != nil {
+		return GetCardsResponse{}, fmt.Errorf("create user session: %w", err)
+	}
+	defer closeSession()
+
+	user, usage, err := s.getDailyUsage(ctx, req)
+	if err != nil {
+		return GetCardsResponse{}, err
+	}
+
+	logger.FromContext(ctx).
+		Debug("get new cards")
+	cards, err := s.cardRepo.GetNewCards(ctx, req.UserID, req.Language)
+	if err != nil {
+		return GetCardsResponse{}, logAndReturnError(
+			ctx,
+			fmt.Sprintf("get new cards: %s", err.Error()),
+			map[string]any{logFieldUserID: req.UserID},
+		)
+	}
+
+	logger.FromContext(ctx).
+		Debug("filter cards out")
+	toLearn := lo.Filter(cards, func(card entity.Card, _ int) bool {
+		return card.NeedToLearn() && req.Filter.Matches(card)
+	})
+	sortByCreatedAtDesc(toLearn)
+
+	limits := dailyLimits(user, req.Language)
+
+	return limitCards(req, toLearn, int(limits.NewCards)-usage.NewCards), nil
+}
+
+// todo: clean a response up to get rid of any definitions/metadata containing the word to repeat.
+func (s *Service) GetCardsToRepeat(ctx context.Context, req GetCardsRequest) (GetCardsResponse, error) {
+	ctx = createContextWithCorrelationLogger(ctx,
+		map[string]any{
+			logFieldUserID:   req.UserID,
+			logFieldLanguage: req.Language.String(),
+			logFieldRequest:  "GetCardsToRepeat",
+		},
+	)
+
+	closeSession, err := s.createUserSession(ctx, req.UserID)
+	if err != nil {
+		return GetCardsResponse{}, fmt.Errorf("create user session: %w", err)
+	}
+	defer closeSession()
+
+	user, usage, err := s.getDailyUsage(ctx, req)
+	if err != nil {
+		return GetCardsResponse{}, err
+	}
+
+	logger.FromContext(ctx).
+		Debug("get due cards")
+	endOfDay := user.Profile.StartOfDay(time.Now()).AddDate(0, 0, 1)
+	cards, err := s.cardRepo.GetDueCards(ctx, req.UserID, req.Language, endOfDay, user.Profile.Directions)
+	if err != nil {
+		return GetCardsResponse{}, logAndReturnError(
+			ctx,
+			fmt.Sprintf("get due cards: %s", err.Error()),
+			map[string]any{logFieldUserID: req.UserID},
+		)
+	}
+	cards = lo.Filter(cards,
+		func(item entity.Card, _ int) bool {
+			return req.Filter.Matches(item)
+		},
+	)
+
+	logger.FromContext(ctx).
+		Debug("filter cards out")
+	// every direction of a card is due on its own, the card is served once per due direction
+	var toRepeat []entity.Card
+	for _, card := range cards {
+		for _, direction := range user.Profile.EnabledDirections() {
+			if card.NeedToRepeatIn(direction, user.Profile) {
+				toRepeat = append(toRepeat, card.InDirection(direction))
+			}
+		}
+	}
+	inSteps, toReview := lo.FilterReject(toRepeat,
+		func(item entity.Card, _ int) bool {
+			return item.Learning.InSteps()
+		},
+	)
+
+	sortByConsecutiveCorrectAnswersAndShuffleInChunks(toReview, 5) //nolint:mnd // it's ok, will be removed later
+
+	limits := dailyLimits(user, req.Language)
+	resp := limitCards(req, toReview, int(limits.Reviews)-usage.Reviews)
+
+	// the cards in the learning steps have already been counted by the limits, they go first
+	// in the order their steps end.
+	slices.SortStableFunc(inSteps, func(a, b entity.Card) int {
+		return a.NextDueDate.Compare(b.NextDueDate)
+	})
+	resp.Cards = append(inSteps, resp.Cards...)
+
+	// a sample of the learnt cards is checked for retention after the other cards,
+	// the ones learnt or checked the longest ago first.
+	remainingChecks := max(int(s.maintenance.DailyLimit())-usage.Maintenance, 0)
+	if remainingChecks > 0 {
+		logger.FromContext(ctx).
+			Debug("get learnt cards")
+		learnt, err := s.cardRepo.GetLearntCards(ctx, req.UserID, req.Language)
+		if err != nil {
+			return GetCardsResponse{}, logAndReturnError(
+				ctx,
+				fmt.Sprintf("get learnt cards: %s", err.Error()),
+				map[string]any{logFieldUserID: req.UserID},
+			)
+		}
+
+		toCheck := lo.FilterMap(learnt,
+			func(item entity.Card, _ int) (entity.Card, bool) {
+				return item.InDirection(entity.DirectionProduction), s.maintenance.Due(user, item) && req.Filter.Matches(item)
+			},
+		)
+		slices.SortStableFunc(toCheck, func(a, b entity.Card) int {
+			return a.LastChecked().Compare(b.LastChecked())
+		})
+		resp.Cards = append(resp.Cards, toCheck[:min(len(toCheck), remainingChecks)]...)
+	}
+
+	shuffleWordsInCards(resp.Cards)
 
 	return resp, nil
 }
 
-func (s *Service) getCardsByFilter(
-	ctx context.Context, req GetCardsRequest, filter func(card entity.Card) bool,
-) (GetCardsResponse, error) {
+// getDailyUsage returns the user and the number of the cards in the requested language the user has already
+// studied during the current day.
+func (s *Service) getDailyUsage(ctx context.Context, req GetCardsRequest) (entity.User, entity.DailyUsage, error) {
 	if err := s.validator.ValidateGetCardsRequest(req); err != nil {
-		return GetCardsResponse{}, fmt.Errorf("%w: %w", NewValidationError(), err)
+		return entity.User{}, entity.DailyUsage{}, fmt.Errorf("%w: %w", NewValidationError(), err)
+	}
+
+	logger.FromContext(ctx).
+		Debug("get user")
+	user, err := s.getUser(ctx, req.UserID)
+	if err != nil {
+		return entity.User{}, entity.DailyUsage{}, logAndReturnError(
+			ctx,
+			fmt.Sprintf("get user: %s", err.Error()),
+			map[string]any{logFieldUserID: req.UserID},
+		)
+	}
+
+	logger.FromContext(ctx).
+		Debug("get daily usage")
+	usage, err := s.reviewRepo.GetDailyUsage(ctx, req.UserID, req.Language, user.Profile.StartOfDay(time.Now()))
+	if err != nil {
+		return entity.User{}, entity.DailyUsage{}, logAndReturnError(
+			ctx,
+			fmt.Sprintf("get daily usage: %s", err.Error()),
+			map[string]any{logFieldUserID: req.UserID},
+		)
+	}
+
+	return user, usage, nil
+}
+
+// dailyLimits returns the limits the user has set for the language or the default ones.
+func dailyLimits(user entity.User, lang language.Tag) entity.DailyLimits {
+	if limits, ok := user.DailyLimits[lang.String()]; ok {
+		return limits
+	}
+
+	return entity.DailyLimits{
+		NewCards: DefaultDailyNewCardsLimit,
+		Reviews:  DefaultDailyReviewsLimit,
+	}
+}
+
+// limitCards keeps as many first cards as the rest of the daily limit allows, the others are postponed until tomorrow.
+func limitCards(req GetCardsRequest, cards []entity.Card, remaining int) GetCardsResponse {
+	remaining = max(remaining, 0)
+	served := cards[:min(len(cards), remaining)]
+
+	return GetCardsResponse{
+		UserID:         req.UserID,
+		Language:       req.Language,
+		Cards:          served,
+		RemainingToday: uint32(remaining),                //nolint:gosec // not negative and bounded by the limit
+		Postponed:      uint32(len(cards) - len(served)), //nolint:gosec // not negative
+	}
+}
+
+func (s *Service) UpdateDailyLimits(ctx context.Context, req UpdateDailyLimitsRequest) (entity.DailyLimits, error) {
+	if err := s.validator.ValidateUpdateDailyLimitsRequest(req); err != nil {
+		return entity.DailyLimits{}, fmt.Errorf("%w: %w", NewValidationError(), err)
+	}
+
+	ctx = createContextWithCorrelationLogger(ctx,
+		map[string]any{
+			logFieldUserID:   req.UserID,
+			logFieldLanguage: req.Language.String(),
+			"NewCards":       req.Limits.NewCards,
+			"Reviews":        req.Limits.Reviews,
+			logFieldRequest:  "UpdateDailyLimits",
+		},
+	)
+
+	closeSession, err := s.createUserSession(ctx, req.UserID)
+	if err != nil {
+		return entity.DailyLimits{}, fmt.Errorf("create user session: %w", err)
+	}
+	defer closeSession()
+
+	logger.FromContext(ctx).
+		Debug("get user")
+	user, err := s.getUser(ctx, req.UserID)
+	if err != nil {
+		return entity.DailyLimits{}, logAndReturnError(
+			ctx,
+			fmt.Sprintf("get user: %s", err.Error()),
+			map[string]any{logFieldUserID: req.UserID},
+		)
+	}
+
+	if user.DailyLimits == nil {
+		user.DailyLimits = make(map[string]entity.DailyLimits)
+	}
+	user.DailyLimits[req.Language.String()] = req.Limits
+
+	logger.FromContext(ctx).
+		Debug("save user")
+	if err = s.userRepo.SaveUser(ctx, user); err != nil {
+		return entity.DailyLimits{}, logAndReturnError(
+			ctx,
+			fmt.Sprintf("save user: %s", err.Error()),
+			map[string]any{logFieldUserID: req.UserID},
+		)
+	}
+
+	return req.Limits, nil
+}
+
+func (s *Service) UpdateGraduationPolicy(
+	ctx context.Context,
+	req UpdateGraduationPolicyRequest,
+) (entity.GraduationPolicy, error) {
+	if err := s.validator.ValidateUpdateGraduationPolicyRequest(req); err != nil {
+		return entity.GraduationPolicy{}, fmt.Errorf("%w: %w", NewValidationError(), err)
+	}
+
+	ctx = createContextWithCorrelationLogger(ctx,
+		map[string]any{
+			logFieldUserID:   req.UserID,
+			"CorrectAnswers": req.Policy.CorrectAnswers,
+			"Interval":       req.Policy.Interval,
+			logFieldRequest:  "UpdateGraduationPolicy",
+		},
+	)
+
+	closeSession, err := s.createUserSession(ctx, req.UserID)
+	if err != nil {
+		return entity.GraduationPolicy{}, fmt.Errorf("create user session: %w", err)
+	}
+	defer closeSession()
+
+	logger.FromContext(ctx).
+		Debug("get user")
+	user, err := s.getUser(ctx, req.UserID)
+	if err != nil {
+		return entity.GraduationPolicy{}, logAndReturnError(
+			ctx,
+			fmt.Sprintf("get user: %s", err.Error()),
+			map[string]any{logFieldUserID: req.UserID},
+		)
+	}
+
+	user.Graduation = req.Policy
+
+	logger.FromContext(ctx).
+		Debug("save user")
+	if err = s.userRepo.SaveUser(ctx, user); err != nil {
+		return entity.GraduationPolicy{}, logAndReturnError(
+			ctx,
+			fmt.Sprintf("save user: %s", err.Error()),
+			map[string]any{logFieldUserID: req.UserID},
+		)
+	}
+
+	return req.Policy, nil
+}
+
+func (s *Service) GetSentences(ctx context.Context, req GetSentencesRequest) (GetSentencesResponse, error) {
+	if err := s.validator.ValidateGetSentencesRequest(req); err != nil {
+		return GetSentencesResponse{}, fmt.Errorf("%w: %w", NewValidationError(), err)
+	}
+
+	ctx = createContextWithCorrelationLogger(ctx,
+		map[string]any{
+			logFieldUserID:   req.UserID,
+			logFieldWord:     req.Word,
+			"SentencesCount": req.SentencesCount,
+			logFieldRequest:  "GetSentences",
+		},
+	)
+
+	logger.FromContext(ctx).
+		Debug("get sentences")
+	sentences, err := s.generateSentences(req.Word, req.SentencesCount)
+	if err != nil {
+		return GetSentencesResponse{}, fmt.Errorf("get sentences for word [%s]: %w", req.Word, err)
+	}
+
+	return GetSentencesResponse{
+		Sentences: sentences,
+	}, nil
+}
+
+func (s *Service) GenerateStory(ctx context.Context, req GenerateStoryRequest) (GenerateStoryResponse, error) {
+	if err := s.validator.ValidateGenerateStoryRequest(req); err != nil {
+		return GenerateStoryResponse{}, fmt.Errorf("%w: %w", NewValidationError(), err)
+	}
+
+	ctx = createContextWithCorrelationLogger(ctx,
+		map[string]any{
+			logFieldUserID:   req.UserID,
+			logFieldLanguage: req.Language.String(),
+			logFieldRequest:  "GenerateStory",
+		},
+	)
+
+	closeSession, err := s.createUserSession(ctx, req.UserID)
+	if err != nil {
+		return GenerateStoryResponse{}, fmt.Errorf("create user session: %w", err)
+	}
+	defer closeSession()
+
+	logger.FromContext(ctx).
+		Debug("get scheduled cards in language")
+	cardsForStory, err := s.cardRepo.GetScheduledCards(ctx, req.UserID, req.Language, req.Filter)
+	if err != nil {
+		return GenerateStoryResponse{}, logAndReturnError(
+			ctx,
+			fmt.Sprintf("get cards: %s", err.Error()),
+			map[string]any{logFieldUserID: req.UserID},
+		)
+	}
+
+	words := mapCardsToWords(cardsForStory)
+
+	rand.Shuffle(len(words), func(i, j int) {
+		words[i], words[j] = words[j], words[i]
+	})
+
+	story, err := s.aiHelper.GenStory(words, req.Language)
+	if err != nil {
+		return GenerateStoryResponse{}, fmt.Errorf("generate story: %w", err)
+	}
+
+	return GenerateStoryResponse{Story: story}, nil
+}
+
+func (s *Service) DeleteCard(ctx context.Context, req DeleteCardRequest) (entity.Card, error) {
+	if err := s.validator.ValidateDeleteCardRequest(req); err != nil {
+		return entity.Card{}, fmt.Errorf("%w: %w", NewValidationError(), err)
+	}
+
+	ctx = createContextWithCorrelationLogger(ctx,
+		map[string]any{
+			logFieldUserID:  req.UserID,
+			logFieldCardID:  req.CardID,
+			logFieldRequest: "DeleteCard",
+		},
+	)
+
+	closeSession, err := s.createUserSession(ctx, req.UserID)
+	if err != nil {
+		return entity.Card{}, fmt.Errorf("create user session: %w", err)
+	}
+	defer closeSession()
+
+	card, err := s.getCard(ctx, req.UserID, req.CardID)
+	if err != nil {
+		return entity.Card{}, err
+	}
+
+	logger.FromContext(ctx).
+		Debug("delete card")
+	if err = s.cardRepo.DeleteCard(ctx, req.CardID); err != nil {
+		return entity.Card{}, logAndReturnError(
+			ctx,
+			fmt.Sprintf("delete card: %s", err.Error()),
+			map[string]any{
+				logFieldUserID: req.UserID,
+				logFieldCardID: req.CardID,
+			},
+		)
+	}
+
+	s.deleteImages(ctx, req.UserID, card.WordInformationList, nil)
+
+	return card, nil
+}
+
+func (s *Service) UploadImage(ctx context.Context, req UploadImageRequest) (entity.Image, error) {
+	contentType := http.DetectContentType(req.Data)
+	if err := s.validator.ValidateUploadImageRequest(req, contentType); err != nil {
+		return entity.Image{}, fmt.Errorf("%w: %w", NewValidationError(), err)
+	}
+
+	ctx = createContextWithCorrelationLogger(ctx,
+		map[string]any{
+			logFieldUserID:  req.UserID,
+			"Size":          len(req.Data),
+			logFieldRequest: "UploadImage",
+		},
+	)
+
+	image := entity.Image{
+		ID:          uuid.NewString(),
+		ContentType: contentType,
+		Data:        req.Data,
+	}
+
+	logger.FromContext(ctx).
+		Debug("put image")
+	if err := s.blobStore.Put(ctx, imageKey(req.UserID, image.ID), image.Data); err != nil {
+		return entity.Image{}, logAndReturnError(
+			ctx,
+			fmt.Sprintf("put image: %s", err.Error()),
+			map[string]any{logFieldUserID: req.UserID},
+		)
+	}
+
+	return image, nil
+}
+
+func (s *Service) GetImage(ctx context.Context, req GetImageRequest) (entity.Image, error) {
+	if err := s.validator.ValidateGetImageRequest(req); err != nil {
+		return entity.Image{}, fmt.Errorf("%w: %w", NewValidationError(), err)
+	}
+
+	ctx = createContextWithCorrelationLogger(ctx,
+		map[string]any{
+			logFieldUserID:  req.UserID,
+			"ImageID":       req.ImageID,
+			logFieldRequest: "GetImage",
+		},
+	)
+
+	logger.FromContext(ctx).
+		Debug("get image")
+	data, found, err := s.blobStore.Get(ctx, imageKey(req.UserID, req.ImageID))
+	if err != nil {
+		return entity.Image{}, logAndReturnError(
+			ctx,
+			fmt.Sprintf("get image: %s", err.Error()),
+			map[string]any{logFieldUserID: req.UserID},
+		)
+	}
+	if !found {
+		logger.FromContext(ctx).
+			Debug("image not found")
+		return entity.Image{}, fmt.Errorf("%w: image ID %s", NewNotFoundError(), req.ImageID)
+	}
+
+	return entity.Image{
+		ID:          req.ImageID,
+		ContentType: http.DetectContentType(data),
+		Data:        data,
+	}, nil
+}
+
+// GetAudio returns the audio of a word by the ID the word refers to it with. The audio is shared by the words
+// sounding the same, so it's kept when the cards are deleted.
+func (s *Service) GetAudio(ctx context.Context, req GetAudioRequest) (entity.Audio, error) {
+	if err := s.validator.ValidateGetAudioRequest(req); err != nil {
+		return entity.Audio{}, fmt.Errorf("%w: %w", NewValidationError(), err)
 	}
 
+	ctx = createContextWithCorrelationLogger(ctx,
+		map[string]any{
+			logFieldUserID:  req.UserID,
+			"AudioID":       req.AudioID,
+			logFieldRequest: "GetAudio",
+		},
+	)
+
 	logger.FromContext(ctx).
-		Debug("get all cards for user")
-	cards, err := s.cardRepo.GetCardsForUser(ctx, req.UserID)
+		Debug("get audio")
+	data, found, err := s.audioStore.Get(ctx, req.AudioID)
 	if err != nil {
-		return GetCardsResponse{}, logAndReturnError(
+		return entity.Audio{}, logAndReturnError(
 			ctx,
-			fmt.Sprintf("get cards: %s", err.Error()),
+			fmt.Sprintf("get audio: %s", err.Error()),
 			map[string]any{logFieldUserID: req.UserID},
 		)
 	}
+	if !found {
+		logger.FromContext(ctx).
+			Debug("audio not found")
+		return entity.Audio{}, fmt.Errorf("%w: audio ID %s", NewNotFoundError(), req.AudioID)
+	}
 
-	// todo: add iter package here to iterate over cards with a custom iterator
-	logger.FromContext(ctx).
-		Debug("filter cards out")
-	filtered := lo.Filter(cards,
-		func(item entity.Card, _ int) bool {
-			return filter(item)
-		},
-	)
-
-	return GetCardsResponse{
-		UserID:   req.UserID,
-		Language: req.Language,
-		Cards:    filtered,
+	return entity.Audio{
+		ID:          req.AudioID,
+		ContentType: audioContentType,
+		Data:        data,
 	}, nil
 }
 
-func (s *Service) GetSentences(ctx context.Context, req GetSentencesRequest) (GetSentencesResponse, error) {
-	if err := s.validator.ValidateGetSentencesRequest(req); err != nil {
-		return GetSentencesResponse{}, fmt.Errorf("%w: %w", NewValidationError(), err)
+// imageKey is the key the user's image is stored under in the blob store.
+func imageKey(userID, imageID string) string {
+	return path.Join("images", userID, imageID)
+}
+
+// deleteImages deletes the images attached to the words before but not after a change of the words.
+// The images left behind are only a waste of space, so the errors are logged and the change goes on.
+func (s *Service) deleteImages(ctx context.Context, userID string, before, after []entity.WordInformation) {
+	kept := make(map[string]struct{}, len(after))
+	for _, word := range after {
+		kept[word.ImageID] = struct{}{}
+	}
+
+	for _, word := range before {
+		if _, ok := kept[word.ImageID]; ok || word.ImageID == "" {
+			continue
+		}
+		if err := s.blobStore.Delete(ctx, imageKey(userID, word.ImageID)); err != nil {
+			logger.FromContext(ctx).
+				WithField("ImageID", word.ImageID).
+				Errorf("delete image: %s", err.Error())
+		}
+	}
+}
+
+func (s *Service) MarkCardLearnt(ctx context.Context, req MarkCardLearntRequest) (entity.Card, error) {
+	if err := s.validator.ValidateMarkCardLearntRequest(req); err != nil {
+		return entity.Card{}, fmt.Errorf("%w: %w", NewValidationError(), err)
 	}
 
 	ctx = createContextWithCorrelationLogger(ctx,
 		map[string]any{
-			logFieldUserID:   req.UserID,
-			logFieldWord:     req.Word,
-			"SentencesCount": req.SentencesCount,
-			logFieldRequest:  "GetSentences",
+			logFieldUserID:  req.UserID,
+			logFieldCardID:  req.CardID,
+			logFieldRequest: "MarkCardLearnt",
 		},
 	)
 
-	logger.FromContext(ctx).
-		Debug("get sentences")
-	sentences, err := s.generateSentences(req.Word, req.SentencesCount)
+	closeSession, err := s.createUserSession(ctx, req.UserID)
 	if err != nil {
-		return GetSentencesResponse{}, fmt.Errorf("get sentences for word [%s]: %w", req.Word, err)
+		return entity.Card{}, fmt.Errorf("create user session: %w", err)
 	}
+	defer closeSession()
 
-	return GetSentencesResponse{
-		Sentences: sentences,
-	}, nil
+	card, err := s.getCard(ctx, req.UserID, req.CardID)
+	if err != nil {
+		return entity.Card{}, err
+	}
+
+	if card.Learnt {
+		logger.FromContext(ctx).
+			Debug("card already marked learnt")
+		return card, nil
+	}
+
+	user, err := s.getUser(ctx, req.UserID)
+	if err != nil {
+		return entity.Card{}, logAndReturnError(
+			ctx,
+			fmt.Sprintf("get user: %s", err.Error()),
+			map[string]any{logFieldUserID: req.UserID},
+		)
+	}
+
+	card.Learnt = true
+	card.LearntAt = user.Profile.Now()
+	card.UpdatedAt = time.Now().UTC()
+
+	if err = s.saveCard(ctx, &card); err != nil {
+		return entity.Card{}, err
+	}
+
+	return card, nil
 }
 
-func (s *Service) GenerateStory(ctx context.Context, req GenerateStoryRequest) (GenerateStoryResponse, error) {
-	if err := s.validator.ValidateGenerateStoryRequest(req); err != nil {
-		return GenerateStoryResponse{}, fmt.Errorf("%w: %w", NewValidationError(), err)
+// GetCard returns the user's card, the card not found is reported as the not found error.
+func (s *Service) GetCard(ctx context.Context, req GetCardRequest) (entity.Card, error) {
+	if err := s.validator.ValidateGetCardRequest(req); err != nil {
+		return entity.Card{}, fmt.Errorf("%w: %w", NewValidationError(), err)
 	}
 
 	ctx = createContextWithCorrelationLogger(ctx,
 		map[string]any{
-			logFieldUserID:   req.UserID,
-			logFieldLanguage: req.Language.String(),
-			logFieldRequest:  "GenerateStory",
+			logFieldUserID:  req.UserID,
+			logFieldCardID:  req.CardID,
+			logFieldRequest: "GetCard",
 		},
 	)
 
 	closeSession, err := s.createUserSession(ctx, req.UserID)
 	if err != nil {
-		return GenerateStoryResponse{}, fmt.Errorf("create user session: %w", err)
+		return entity.Card{}, fmt.Errorf("create user session: %w", err)
 	}
 	defer closeSession()
 
-	logger.FromContext(ctx).
-		Debug("get all cards for user")
-	cards, err := s.cardRepo.GetCardsForUser(ctx, req.UserID)
+	return s.getCard(ctx, req.UserID, req.CardID)
+}
+
+// ResetCard returns the card to the study, see ResetMode, even if the card has been learnt.
+func (s *Service) ResetCard(ctx context.Context, req ResetCardRequest) (entity.Card, error) {
+	if err := s.validator.ValidateResetCardRequest(req); err != nil {
+		return entity.Card{}, fmt.Errorf("%w: %w", NewValidationError(), err)
+	}
+
+	ctx = createContextWithCorrelationLogger(ctx,
+		map[string]any{
+			logFieldUserID:  req.UserID,
+			logFieldCardID:  req.CardID,
+			"Mode":          req.Mode,
+			logFieldRequest: "ResetCard",
+		},
+	)
+
+	closeSession, err := s.createUserSession(ctx, req.UserID)
 	if err != nil {
-		return GenerateStoryResponse{}, logAndReturnError(
-			ctx,
-			fmt.Sprintf("get cards: %s", err.Error()),
-			map[string]any{logFieldUserID: req.UserID},
-		)
+		return entity.Card{}, fmt.Errorf("create user session: %w", err)
 	}
+	defer closeSession()
 
-	logger.FromContext(ctx).
-		Debug("filter cards out by next due date")
-	cardsForStory := make([]entity.Card, 0, len(cards))
-	for _, card := range cards {
-		sameLanguage := strings.EqualFold(card.Language.String(), req.Language.String())
-		hasReviewSchedule := !card.NextDueDate.IsZero()
-		includeInStory := sameLanguage && hasReviewSchedule && !card.Learnt
-		if includeInStory {
-			cardsForStory = append(cardsForStory, card)
+	return s.updateUserCard(ctx, req.UserID, req.CardID, func(card *entity.Card) error {
+		if req.Mode == ResetModeForget {
+			card.Forget()
+			return nil
+		}
+
+		user, err := s.getUser(ctx, req.UserID)
+		if err != nil {
+			return fmt.Errorf("get user: %w", err)
 		}
+		card.Relearn(user.Profile.StartOfDay(time.Now()))
+		return nil
+	})
+}
+
+func (s *Service) SuspendCard(ctx context.Context, req SuspendCardRequest) (entity.Card, error) {
+	if err := s.validator.ValidateSuspendCardRequest(req); err != nil {
+		return entity.Card{}, fmt.Errorf("%w: %w", NewValidationError(), err)
 	}
 
-	words := mapCardsToWords(cardsForStory)
+	ctx = createContextWithCorrelationLogger(ctx,
+		map[string]any{
+			logFieldUserID:  req.UserID,
+			logFieldCardID:  req.CardID,
+			"Suspended":     req.Suspended,
+			logFieldRequest: "SuspendCard",
+		},
+	)
 
-	rand.Shuffle(len(words), func(i, j int) {
-		words[i], words[j] = words[j], words[i]
+	closeSession, err := s.createUserSession(ctx, req.UserID)
+	if err != nil {
+		return entity.Card{}, fmt.Errorf("create user session: %w", err)
+	}
+	defer closeSession()
+
+	return s.updateUserCard(ctx, req.UserID, req.CardID, func(card *entity.Card) error {
+		card.Suspended = req.Suspended
+		return nil
 	})
+}
 
-	story, err := s.aiHelper.GenStory(words, req.Language)
+func (s *Service) UpdateCardTags(ctx context.Context, req UpdateCardTagsRequest) (entity.Card, error) {
+	if err := s.validator.ValidateUpdateCardTagsRequest(req); err != nil {
+		return entity.Card{}, fmt.Errorf("%w: %w", NewValidationError(), err)
+	}
+
+	ctx = createContextWithCorrelationLogger(ctx,
+		map[string]any{
+			logFieldUserID:  req.UserID,
+			logFieldCardID:  req.CardID,
+			"Add":           req.Add,
+			"Remove":        req.Remove,
+			logFieldRequest: "UpdateCardTags",
+		},
+	)
+
+	closeSession, err := s.createUserSession(ctx, req.UserID)
 	if err != nil {
-		return GenerateStoryResponse{}, fmt.Errorf("generate story: %w", err)
+		return entity.Card{}, fmt.Errorf("create user session: %w", err)
 	}
+	defer closeSession()
 
-	return GenerateStoryResponse{Story: story}, nil
+	return s.updateUserCard(ctx, req.UserID, req.CardID, func(card *entity.Card) error {
+		card.RemoveTags(req.Remove...)
+		card.AddTags(req.Add...)
+		return nil
+	})
 }
 
-func (s *Service) DeleteCard(ctx context.Context, req DeleteCardRequest) (entity.Card, error) {
-	if err := s.validator.ValidateDeleteCardRequest(req); err != nil {
+func (s *Service) SetCardDeck(ctx context.Context, req SetCardDeckRequest) (entity.Card, error) {
+	if err := s.validator.ValidateSetCardDeckRequest(req); err != nil {
 		return entity.Card{}, fmt.Errorf("%w: %w", NewValidationError(), err)
 	}
 
@@ -688,7 +2001,8 @@ func (s *Service) DeleteCard(ctx context.Context, req DeleteCardRequest) (entity
 		map[string]any{
 			logFieldUserID:  req.UserID,
 			logFieldCardID:  req.CardID,
-			logFieldRequest: "DeleteCard",
+			"Deck":          req.Deck,
+			logFieldRequest: "SetCardDeck",
 		},
 	)
 
@@ -699,45 +2013,88 @@ func (s *Service) DeleteCard(ctx context.Context, req DeleteCardRequest) (entity
 	defer closeSession()
 
 	logger.FromContext(ctx).
-		Debug("get all cards for user")
-	cards, err := s.cardRepo.GetCardsForUser(ctx, req.UserID)
+		Debug("get decks")
+	decks, err := s.cardRepo.GetDecks(ctx, req.UserID, language.Und)
 	if err != nil {
 		return entity.Card{}, logAndReturnError(
 			ctx,
-			fmt.Sprintf("get cards: %s", err.Error()),
+			fmt.Sprintf("get decks: %s", err.Error()),
 			map[string]any{logFieldUserID: req.UserID},
 		)
 	}
 
-	card, found := lo.Find(cards,
-		func(item entity.Card) bool {
-			return item.ID == req.CardID
+	// the deck keeps the name it has been created with, whatever case it's referred to in
+	deck := req.Deck
+	if i := slices.IndexFunc(decks, func(name string) bool { return strings.EqualFold(name, deck) }); i >= 0 {
+		deck = decks[i]
+	}
+
+	return s.updateUserCard(ctx, req.UserID, req.CardID, func(card *entity.Card) error {
+		card.Deck = deck
+		return nil
+	})
+}
+
+// GetDecks returns the user's decks with the number of cards in them sorted by name,
+// the decks of the cards in the language if it's set.
+func (s *Service) GetDecks(ctx context.Context, req GetDecksRequest) (GetDecksResponse, error) {
+	if err := s.validator.ValidateGetDecksRequest(req); err != nil {
+		return GetDecksResponse{}, fmt.Errorf("%w: %w", NewValidationError(), err)
+	}
+
+	ctx = createContextWithCorrelationLogger(ctx,
+		map[string]any{
+			logFieldUserID:   req.UserID,
+			logFieldLanguage: req.Language.String(),
+			logFieldRequest:  "GetDecks",
 		},
 	)
-	if !found {
-		logger.FromContext(ctx).
-			Debug("card not found")
-		return entity.Card{}, fmt.Errorf("%w: card ID %s", NewNotFoundError(), req.CardID)
+
+	closeSession, err := s.createUserSession(ctx, req.UserID)
+	if err != nil {
+		return GetDecksResponse{}, fmt.Errorf("create user session: %w", err)
 	}
+	defer closeSession()
 
 	logger.FromContext(ctx).
-		Debug("delete card")
-	if err = s.cardRepo.DeleteCard(ctx, req.CardID); err != nil {
-		return entity.Card{}, logAndReturnError(
+		Debug("get decks")
+	names, err := s.cardRepo.GetDecks(ctx, req.UserID, req.Language)
+	if err != nil {
+		return GetDecksResponse{}, logAndReturnError(
 			ctx,
-			fmt.Sprintf("delete card: %s", err.Error()),
-			map[string]any{
-				logFieldUserID: req.UserID,
-				logFieldCardID: req.CardID,
-			},
+			fmt.Sprintf("get decks: %s", err.Error()),
+			map[string]any{logFieldUserID: req.UserID},
 		)
 	}
 
-	return card, nil
+	return GetDecksResponse{
+		UserID: req.UserID,
+		Decks:  countDecks(names),
+	}, nil
 }
 
-func (s *Service) MarkCardLearnt(ctx context.Context, req MarkCardLearntRequest) (entity.Card, error) {
-	if err := s.validator.ValidateMarkCardLearntRequest(req); err != nil {
+// countDecks counts the cards by deck, names are the decks of the cards.
+func countDecks(names []string) []Deck {
+	var decks []Deck
+	for _, name := range names {
+		i := slices.IndexFunc(decks, func(deck Deck) bool {
+			return strings.EqualFold(deck.Name, name)
+		})
+		if i < 0 {
+			decks = append(decks, Deck{Name: name})
+			i = len(decks) - 1
+		}
+		decks[i].Cards++
+	}
+	slices.SortFunc(decks, func(a, b Deck) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+
+	return decks
+}
+
+func (s *Service) BuryCard(ctx context.Context, req BuryCardRequest) (entity.Card, error) {
+	if err := s.validator.ValidateBuryCardRequest(req); err != nil {
 		return entity.Card{}, fmt.Errorf("%w: %w", NewValidationError(), err)
 	}
 
@@ -745,7 +2102,8 @@ func (s *Service) MarkCardLearnt(ctx context.Context, req MarkCardLearntRequest)
 		map[string]any{
 			logFieldUserID:  req.UserID,
 			logFieldCardID:  req.CardID,
-			logFieldRequest: "MarkCardLearnt",
+			"Buried":        req.Buried,
+			logFieldRequest: "BuryCard",
 		},
 	)
 
@@ -755,49 +2113,45 @@ func (s *Service) MarkCardLearnt(ctx context.Context, req MarkCardLearntRequest)
 	}
 	defer closeSession()
 
-	logger.FromContext(ctx).
-		Debug("get all cards for user")
-	cards, err := s.cardRepo.GetCardsForUser(ctx, req.UserID)
-	if err != nil {
-		return entity.Card{}, logAndReturnError(
-			ctx,
-			fmt.Sprintf("get cards: %s", err.Error()),
-			map[string]any{logFieldUserID: req.UserID},
-		)
-	}
+	return s.updateUserCard(ctx, req.UserID, req.CardID, func(card *entity.Card) error {
+		if !req.Buried {
+			card.BuriedUntil = time.Time{}
+			return nil
+		}
 
-	card, found := lo.Find(cards,
-		func(item entity.Card) bool {
-			return item.ID == req.CardID
-		},
-	)
-	if !found {
-		logger.FromContext(ctx).
-			Debug("card not found")
-		return entity.Card{}, fmt.Errorf("%w: card ID %s", NewNotFoundError(), req.CardID)
-	}
+		user, err := s.getUser(ctx, req.UserID)
+		if err != nil {
+			return fmt.Errorf("get user: %w", err)
+		}
+		card.BuriedUntil = user.Profile.StartOfDay(user.Profile.StartOfDay(time.Now()).AddDate(0, 0, 1))
+		return nil
+	})
+}
 
-	if card.Learnt {
-		logger.FromContext(ctx).
-			Debug("card already marked learnt")
-		return card, nil
+// updateUserCard applies the update to the user's card and saves it.
+func (s *Service) updateUserCard(
+	ctx context.Context, userID, cardID string, update func(card *entity.Card) error,
+) (entity.Card, error) {
+	card, err := s.getCard(ctx, userID, cardID)
+	if err != nil {
+		return entity.Card{}, err
 	}
 
-	card.Learnt = true
-	card.LearntAt = time.Now().UTC()
-
-	logger.FromContext(ctx).
-		Debug("save learnt card")
-	if err = s.cardRepo.SaveCards(ctx, []entity.Card{card}); err != nil {
+	if err = update(&card); err != nil {
 		return entity.Card{}, logAndReturnError(
 			ctx,
-			fmt.Sprintf("save card: %s", err.Error()),
+			fmt.Sprintf("update card: %s", err.Error()),
 			map[string]any{
-				logFieldUserID: req.UserID,
-				logFieldCardID: req.CardID,
+				logFieldUserID: userID,
+				logFieldCardID: cardID,
 			},
 		)
 	}
+	card.UpdatedAt = time.Now().UTC()
+
+	if err = s.saveCard(ctx, &card); err != nil {
+		return entity.Card{}, err
+	}
 
 	return card, nil
 }
@@ -829,6 +2183,34 @@ func (s *Service) generateSentences(word string, size int) ([]string, error) {
 	return sentences, nil
 }
 
+// saveCard saves the card and moves it to the version saved, the card changed or deleted by another
+// request since it has been read is a conflict, the card sharing a word with another card of the user
+// in the language already exists.
+func (s *Service) saveCard(ctx context.Context, card *entity.Card) error {
+	logger.FromContext(ctx).
+		Debug("save card")
+	if err := s.cardRepo.SaveCards(ctx, []entity.Card{*card}); err != nil {
+		if errors.Is(err, entity.ErrVersionConflict) {
+			logger.FromContext(ctx).
+				Debug("card has been changed concurrently")
+			return fmt.Errorf("%w: %w", NewConflictError(), err)
+		}
+		if errors.Is(err, entity.ErrDuplicateWord) {
+			logger.FromContext(ctx).
+				Debug("another card has a word of the card")
+			return fmt.Errorf("%w: %w", NewAlreadyExistsError(), err)
+		}
+		return logAndReturnError(
+			ctx,
+			fmt.Sprintf("save card: %s", err.Error()),
+			map[string]any{logFieldCardID: card.ID},
+		)
+	}
+	card.Version++
+
+	return nil
+}
+
 func logAndReturnError(ctx context.Context, msg string, fields map[string]any) error {
 	logger.FromContext(ctx).
 		WithFields(fields).
@@ -900,33 +2282,36 @@ func (s *Service) enrichWordsWithAudio(
 		auVoice = "en-AU-Standard-C"
 	)
 
-	for i := range words {
-		if words[i].AudioByLanguage == nil {
-			words[i].AudioByLanguage = make(map[string][]byte)
-		}
-
-		audio, err := s.textToAudio(ctx, words[i].Word, gbLanguage, gbVoice)
-		if err != nil {
-			return fmt.Errorf("text (%s) to speech: %w", words[i].Word, err)
-		}
-		words[i].AudioByLanguage[gbLanguage] = audio
-
-		audio, err = s.textToAudio(ctx, words[i].Word, usLanguage, usVoice)
-		if err != nil {
-			return fmt.Errorf("text (%s) to speech: %w", words[i].Word, err)
-		}
-		words[i].AudioByLanguage[usLanguage] = audio
+	voices := []struct{ language, name string }{
+		{language: gbLanguage, name: gbVoice},
+		{language: usLanguage, name: usVoice},
+		{language: auLanguage, name: auVoice},
+	}
 
-		audio, err = s.textToAudio(ctx, words[i].Word, auLanguage, auVoice)
-		if err != nil {
-			return fmt.Errorf("text (%s) to speech: %w", words[i].Word, err)
+	for i := range words {
+		words[i].AudioByLanguage = nil
+		words[i].AudioIDs = make(map[string]string, len(voices))
+
+		for _, voice := range voices {
+			audio, err := s.textToAudio(ctx, words[i].Word, voice.language, voice.name)
+			if err != nil {
+				return fmt.Errorf("text (%s) to speech: %w", words[i].Word, err)
+			}
+
+			id := entity.AudioID(audio)
+			if err = s.audioStore.Put(ctx, id, audio); err != nil {
+				return fmt.Errorf("put audio of (%s): %w", words[i].Word, err)
+			}
+			words[i].AudioIDs[voice.language] = id
 		}
-		words[i].AudioByLanguage[auLanguage] = audio
 	}
 
 	return nil
 }
 
+// audioContentType is the content type of the audio synthesised by textToAudio.
+const audioContentType = "audio/mpeg"
+
 func (s *Service) textToAudio(ctx context.Context, text string, voiceLanguage, voiceName string) ([]byte, error) {
 	req := speech.ToSpeechRequest{
 		Input: text,
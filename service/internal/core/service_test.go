@@ -0,0 +1,355 @@
+package core //nolint:testpackage // it's intended to be a test package of a private functions
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/genvmoroz/lale/service/pkg/entity"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	fakeCardRepo struct {
+		CardRepo
+
+		card    entity.Card
+		saveErr error
+		saved   []entity.Card
+	}
+
+	fakeUserRepo struct {
+		UserRepo
+	}
+
+	fakeReviewRepo struct {
+		ReviewRepo
+
+		addErr  error
+		added   []entity.Review
+		markErr error
+		undone  []string
+	}
+
+	// fakeTransactor rolls the changes of the fake repos back if the operation fails.
+	fakeTransactor struct {
+		cards   *fakeCardRepo
+		reviews *fakeReviewRepo
+	}
+
+	fakeSessionRepo struct{}
+
+	// fakeUserSessions tracks the sessions held by the users.
+	fakeUserSessions struct {
+		held map[string]bool
+	}
+
+	// fakeProfileUserRepo returns the user with the profile changed at every read.
+	fakeProfileUserRepo struct {
+		UserRepo
+
+		reads int
+		saved []entity.User
+	}
+
+	// fakeOptimiser records whether the user session was held during the fit.
+	fakeOptimiser struct {
+		sessions      *fakeUserSessions
+		heldDuringFit bool
+	}
+
+	fakeLearningSteps struct{}
+
+	inTransactionKey struct{}
+)
+
+var errNotInTransaction = errors.New("changed out of a transaction")
+
+func (r *fakeCardRepo) GetCardByID(_ context.Context, _, cardID string) (entity.Card, bool, error) {
+	return r.card, r.card.ID == cardID, nil
+}
+
+func (r *fakeCardRepo) SaveCards(ctx context.Context, cards []entity.Card) error {
+	if ctx.Value(inTransactionKey{}) == nil {
+		return errNotInTransaction
+	}
+	if r.saveErr != nil {
+		return r.saveErr
+	}
+	r.saved = append(r.saved, cards...)
+
+	return nil
+}
+
+func (fakeUserRepo) GetUser(_ context.Context, _ string) (entity.User, bool, error) {
+	return entity.User{}, false, nil
+}
+
+func (r *fakeReviewRepo) AddReview(ctx context.Context, review entity.Review) error {
+	if ctx.Value(inTransactionKey{}) == nil {
+		return errNotInTransaction
+	}
+	if r.addErr != nil {
+		return r.addErr
+	}
+	r.added = append(r.added, review)
+
+	return nil
+}
+
+// GetReviews returns the last review added and not undone.
+func (r *fakeReviewRepo) GetReviews(_ context.Context, _, _ string, _, _ int64) ([]entity.Review, error) {
+	for _, review := range slices.Backward(r.added) {
+		if !slices.Contains(r.undone, review.ID) {
+			return []entity.Review{review}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *fakeReviewRepo) GetReviewsForUser(_ context.Context, _ string, _ time.Time) ([]entity.Review, error) {
+	return r.added, nil
+}
+
+func (r *fakeReviewRepo) MarkReviewUndone(ctx context.Context, reviewID string) error {
+	if ctx.Value(inTransactionKey{}) == nil {
+		return errNotInTransaction
+	}
+	if r.markErr != nil {
+		return r.markErr
+	}
+	r.undone = append(r.undone, reviewID)
+
+	return nil
+}
+
+func (t fakeTransactor) InTransaction(ctx context.Context, operation func(ctx context.Context) error) error {
+	saved, added, undone := slices.Clone(t.cards.saved), slices.Clone(t.reviews.added), slices.Clone(t.reviews.undone)
+	if err := operation(context.WithValue(ctx, inTransactionKey{}, true)); err != nil {
+		t.cards.saved, t.reviews.added, t.reviews.undone = saved, added, undone
+		return err
+	}
+
+	return nil
+}
+
+func (fakeSessionRepo) CreateSession(_ string) error { return nil }
+
+func (fakeSessionRepo) CloseSession(_ string) error { return nil }
+
+func (s *fakeUserSessions) CreateSession(userID string) error {
+	if s.held[userID] {
+		return errors.New("session already exists")
+	}
+	s.held[userID] = true
+
+	return nil
+}
+
+func (s *fakeUserSessions) CloseSession(userID string) error {
+	delete(s.held, userID)
+
+	return nil
+}
+
+func (r *fakeProfileUserRepo) GetUser(_ context.Context, userID string) (entity.User, bool, error) {
+	r.reads++
+
+	return entity.User{ID: userID, Profile: entity.Profile{DayStart: time.Duration(r.reads) * time.Hour}}, true, nil
+}
+
+func (r *fakeProfileUserRepo) SaveUser(_ context.Context, user entity.User) error {
+	r.saved = append(r.saved, user)
+
+	return nil
+}
+
+func (o *fakeOptimiser) Optimise(
+	params entity.SchedulerParameters,
+	_ []entity.Review,
+	targetRetention float64,
+) (entity.SchedulerOptimisation, error) {
+	o.heldDuringFit = o.sessions.held["user"]
+	params.DesiredRetention = targetRetention
+
+	return entity.SchedulerOptimisation{Parameters: params}, nil
+}
+
+// Next keeps the card in the learning steps, so the answer is not scheduled by the algorithm.
+func (fakeLearningSteps) Next(_ entity.Card, _ uint32) entity.LearningStep {
+	return entity.LearningStep{Due: time.Now().Add(10 * time.Minute)}
+}
+
+func TestUpdateCardPerformanceKeepsReviewAndCardTogether(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		err   bool
+		added int
+		saved int
+	}
+	tests := []struct {
+		name    string
+		addErr  error
+		saveErr error
+		want    want
+	}{
+		{
+			name: "answer saved",
+			want: want{added: 1, saved: 1},
+		},
+		{
+			name:   "review not added",
+			addErr: errors.New("reviews unavailable"),
+			want:   want{err: true},
+		},
+		{
+			name:    "card not saved",
+			saveErr: errors.New("cards unavailable"),
+			want:    want{err: true},
+		},
+		{
+			name:    "card changed meanwhile",
+			saveErr: entity.ErrVersionConflict,
+			want:    want{err: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cards := &fakeCardRepo{
+				card:    entity.Card{ID: "card", UserID: "user"},
+				saveErr: tt.saveErr,
+			}
+			reviews := &fakeReviewRepo{addErr: tt.addErr}
+			s := &Service{
+				cardRepo:      cards,
+				userRepo:      fakeUserRepo{},
+				reviewRepo:    reviews,
+				transactor:    fakeTransactor{cards: cards, reviews: reviews},
+				sessionRepo:   fakeSessionRepo{},
+				learningSteps: fakeLearningSteps{},
+				validator:     validator{},
+			}
+
+			_, err := s.UpdateCardPerformance(context.Background(), UpdateCardPerformanceRequest{
+				UserID:      "user",
+				CardID:      "card",
+				Performance: 4,
+			})
+
+			require.Equal(t, tt.want.err, err != nil)
+			require.NotErrorIs(t, err, errNotInTransaction)
+			require.Len(t, reviews.added, tt.want.added)
+			require.Len(t, cards.saved, tt.want.saved)
+			for _, review := range reviews.added {
+				require.NotNil(t, review.CardBefore)
+			}
+		})
+	}
+}
+
+func TestUndoLastReviewKeepsReviewAndCardTogether(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		err    bool
+		saved  int
+		undone int
+	}
+	tests := []struct {
+		name    string
+		markErr error
+		saveErr error
+		want    want
+	}{
+		{
+			name: "review undone",
+			want: want{saved: 1, undone: 1},
+		},
+		{
+			name:    "review not marked undone",
+			markErr: errors.New("reviews unavailable"),
+			want:    want{err: true},
+		},
+		{
+			name:    "card not saved",
+			saveErr: errors.New("cards unavailable"),
+			want:    want{err: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reviewedAt := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+			cards := &fakeCardRepo{
+				card: entity.Card{
+					ID:             "card",
+					UserID:         "user",
+					NextDueDate:    reviewedAt.AddDate(0, 0, 3),
+					LastReviewedAt: reviewedAt,
+				},
+				saveErr: tt.saveErr,
+			}
+			reviews := &fakeReviewRepo{
+				added: []entity.Review{{
+					ID:         "review",
+					UserID:     "user",
+					CardID:     "card",
+					ReviewedAt: reviewedAt,
+					CardBefore: &entity.SchedulingState{},
+				}},
+				markErr: tt.markErr,
+			}
+			s := &Service{
+				cardRepo:    cards,
+				reviewRepo:  reviews,
+				transactor:  fakeTransactor{cards: cards, reviews: reviews},
+				sessionRepo: fakeSessionRepo{},
+				validator:   validator{},
+			}
+
+			_, err := s.UndoLastReview(context.Background(), UndoLastReviewRequest{UserID: "user", CardID: "card"})
+
+			require.Equal(t, tt.want.err, err != nil)
+			require.NotErrorIs(t, err, errNotInTransaction)
+			require.Len(t, cards.saved, tt.want.saved)
+			require.Len(t, reviews.undone, tt.want.undone)
+			require.Len(t, reviews.added, 1, "the review log is append-only")
+		})
+	}
+}
+
+func TestOptimiseSchedulerParametersReleasesUserSessionDuringFit(t *testing.T) {
+	t.Parallel()
+
+	sessions := &fakeUserSessions{held: map[string]bool{}}
+	users := &fakeProfileUserRepo{}
+	optimiser := &fakeOptimiser{sessions: sessions}
+	s := &Service{
+		userRepo:    users,
+		reviewRepo:  &fakeReviewRepo{added: make([]entity.Review, MinReviewsNumberToOptimise)},
+		sessionRepo: sessions,
+		optimiser:   optimiser,
+		validator:   validator{},
+	}
+
+	_, err := s.OptimiseSchedulerParameters(context.Background(), OptimiseSchedulerParametersRequest{
+		UserID:          "user",
+		TargetRetention: 0.85,
+	})
+
+	require.NoError(t, err)
+	require.False(t, optimiser.heldDuringFit, "the user must be able to study during the fit")
+	require.Empty(t, sessions.held)
+	require.Len(t, users.saved, 1)
+	require.InDelta(t, 0.85, users.saved[0].SchedulerParameters.DesiredRetention, 1e-9)
+	require.Equal(t, 2*time.Hour, users.saved[0].Profile.DayStart, "the profile changed during the fit is kept")
+}
@@ -1,8 +1,19 @@
 package core
 
 import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"slices"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/genvmoroz/lale/service/pkg/entity"
+	"github.com/google/uuid"
 )
 
 type validator struct{}
@@ -45,11 +56,7 @@ func (validator) ValidateCreateCardRequest(req CreateCardRequest) error {
 	if len(strings.TrimSpace(req.Language.String())) == 0 {
 		return errors.New("language is required")
 	}
-	if len(req.WordInformationList) == 0 {
-		return errors.New("wordInformationList are required, specify one at least")
-	}
-
-	return nil
+	return validateCardContent(req.Type, req.WordInformationList, req.Note)
 }
 
 func (validator) ValidateUpdateCardRequest(req UpdateCardRequest) error {
@@ -59,8 +66,114 @@ func (validator) ValidateUpdateCardRequest(req UpdateCardRequest) error {
 	if len(strings.TrimSpace(req.CardID)) == 0 {
 		return errors.New("cardID is required")
 	}
-	if len(req.WordInformationList) == 0 {
-		return errors.New("wordInformationList are required, specify one at least")
+	return validateCardContent(req.Type, req.WordInformationList, req.Note)
+}
+
+// validateCardContent checks the card holds what its type requires, the empty type is the word cards.
+func validateCardContent(noteType entity.NoteType, words []entity.WordInformation, note entity.Note) error {
+	noteType = cmp.Or(noteType, entity.NoteTypeWords)
+	if noteType == entity.NoteTypeWords {
+		if len(words) == 0 {
+			return errors.New("wordInformationList are required, specify one at least")
+		}
+		if !note.IsZero() {
+			return errors.New("note is not allowed on the word cards")
+		}
+		return validateWordNotes(words)
+	}
+
+	if len(words) != 0 {
+		return fmt.Errorf("wordInformationList is not allowed on the %s cards", noteType)
+	}
+	if utf8.RuneCountInString(note.Text)+utf8.RuneCountInString(note.Answer)+
+		utf8.RuneCountInString(note.Explanation) > MaxNoteLength {
+		return fmt.Errorf("note exceeds %d characters", MaxNoteLength)
+	}
+
+	switch noteType {
+	case entity.NoteTypePhrase:
+		if len(strings.TrimSpace(note.Text)) == 0 {
+			return errors.New("phrase is required")
+		}
+		if len(strings.TrimSpace(note.Answer)) == 0 {
+			return errors.New("meaning of the phrase is required")
+		}
+	case entity.NoteTypeCloze:
+		deletions, err := entity.ClozeDeletions(note.Text)
+		if err != nil {
+			return fmt.Errorf("invalid cloze sentence: %w", err)
+		}
+		if len(deletions) == 0 {
+			return fmt.Errorf("cloze sentence requires a deletion in %s %s, ex: I %shave been%s here",
+				entity.ClozeOpen, entity.ClozeClose, entity.ClozeOpen, entity.ClozeClose)
+		}
+		if len(strings.TrimSpace(note.Answer)) != 0 {
+			return errors.New("answer is not allowed on the cloze cards, the deletions are the answers")
+		}
+	case entity.NoteTypeGrammar:
+		if len(strings.TrimSpace(note.Text)) == 0 {
+			return errors.New("grammar prompt is required")
+		}
+		if len(strings.TrimSpace(note.Answer)) == 0 {
+			return errors.New("answer to the grammar prompt is required")
+		}
+	default:
+		return fmt.Errorf("unknown note type [%s], expected one of %v", noteType, entity.NoteTypes)
+	}
+
+	return nil
+}
+
+func validateWordNotes(words []entity.WordInformation) error {
+	for _, word := range words {
+		if utf8.RuneCountInString(word.Notes) > MaxNotesLength {
+			return fmt.Errorf("notes of word [%s] exceed %d characters", word.Word, MaxNotesLength)
+		}
+	}
+
+	return nil
+}
+
+func (validator) ValidateUploadImageRequest(req UploadImageRequest, contentType string) error {
+	if len(strings.TrimSpace(req.UserID)) == 0 {
+		return errors.New("userID is required")
+	}
+	if len(req.Data) == 0 {
+		return errors.New("data is required")
+	}
+	if len(req.Data) > MaxImageSize {
+		return fmt.Errorf("image exceeds %d bytes", MaxImageSize)
+	}
+	if !strings.HasPrefix(contentType, "image/") {
+		return fmt.Errorf("data is not an image but [%s]", contentType)
+	}
+
+	return nil
+}
+
+func (validator) ValidateGetImageRequest(req GetImageRequest) error {
+	if len(strings.TrimSpace(req.UserID)) == 0 {
+		return errors.New("userID is required")
+	}
+	if len(strings.TrimSpace(req.ImageID)) == 0 {
+		return errors.New("imageID is required")
+	}
+	if err := uuid.Validate(req.ImageID); err != nil {
+		return fmt.Errorf("invalid imageID: %w", err)
+	}
+
+	return nil
+}
+
+func (validator) ValidateGetAudioRequest(req GetAudioRequest) error {
+	if len(strings.TrimSpace(req.UserID)) == 0 {
+		return errors.New("userID is required")
+	}
+	if len(strings.TrimSpace(req.AudioID)) == 0 {
+		return errors.New("audioID is required")
+	}
+	if id, err := hex.DecodeString(req.AudioID); err != nil || len(id) != sha256.Size {
+		return fmt.Errorf("invalid audioID [%s]", req.AudioID)
 	}
 
 	return nil
@@ -77,6 +190,10 @@ func validateUserIDAndCardID(userID, cardID string) error {
 	return nil
 }
 
+func (validator) ValidateGetCardRequest(req GetCardRequest) error {
+	return validateUserIDAndCardID(req.UserID, req.CardID)
+}
+
 func (validator) ValidateDeleteCardRequest(req DeleteCardRequest) error {
 	return validateUserIDAndCardID(req.UserID, req.CardID)
 }
@@ -85,6 +202,111 @@ func (validator) ValidateMarkCardLearntRequest(req MarkCardLearntRequest) error
 	return validateUserIDAndCardID(req.UserID, req.CardID)
 }
 
+func (validator) ValidateResetCardRequest(req ResetCardRequest) error {
+	if err := validateUserIDAndCardID(req.UserID, req.CardID); err != nil {
+		return err
+	}
+	if req.Mode != ResetModeRelearn && req.Mode != ResetModeForget {
+		return fmt.Errorf("unknown reset mode [%s], must be %s or %s", req.Mode, ResetModeRelearn, ResetModeForget)
+	}
+
+	return nil
+}
+
+func (validator) ValidateSuspendCardRequest(req SuspendCardRequest) error {
+	return validateUserIDAndCardID(req.UserID, req.CardID)
+}
+
+func (validator) ValidateBuryCardRequest(req BuryCardRequest) error {
+	return validateUserIDAndCardID(req.UserID, req.CardID)
+}
+
+func (validator) ValidateGetUserProfileRequest(req GetUserProfileRequest) error {
+	if len(strings.TrimSpace(req.UserID)) == 0 {
+		return errors.New("userID is required")
+	}
+
+	return nil
+}
+
+func (validator) ValidateUpdateUserProfileRequest(req UpdateUserProfileRequest) error {
+	if len(strings.TrimSpace(req.UserID)) == 0 {
+		return errors.New("userID is required")
+	}
+	// Local is the time zone of the server, not the one of the user
+	if req.Profile.TimeZone == "Local" {
+		return errors.New("unknown time zone Local, must be an IANA time zone name")
+	}
+	if _, err := time.LoadLocation(req.Profile.TimeZone); err != nil {
+		return fmt.Errorf("unknown time zone %s: %w", req.Profile.TimeZone, err)
+	}
+	if req.Profile.DayStart < 0 || req.Profile.DayStart >= 24*time.Hour {
+		return errors.New("day start must be in range [00:00, 24:00)")
+	}
+	for _, direction := range req.Profile.Directions {
+		if err := validateDirection(direction); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (validator) ValidateUpdateDailyLimitsRequest(req UpdateDailyLimitsRequest) error {
+	if len(strings.TrimSpace(req.UserID)) == 0 {
+		return errors.New("userID is required")
+	}
+	if len(strings.TrimSpace(req.Language.String())) == 0 {
+		return errors.New("language is required")
+	}
+
+	return nil
+}
+
+func (validator) ValidateUpdateGraduationPolicyRequest(req UpdateGraduationPolicyRequest) error {
+	if len(strings.TrimSpace(req.UserID)) == 0 {
+		return errors.New("userID is required")
+	}
+	if req.Policy.Interval < 0 {
+		return errors.New("graduation interval must not be negative")
+	}
+
+	return nil
+}
+
+func (validator) ValidateUndoLastReviewRequest(req UndoLastReviewRequest) error {
+	if len(strings.TrimSpace(req.UserID)) == 0 {
+		return errors.New("userID is required")
+	}
+
+	return nil
+}
+
+func (validator) ValidateGetReviewHistoryRequest(req GetReviewHistoryRequest) error {
+	if len(strings.TrimSpace(req.UserID)) == 0 {
+		return errors.New("userID is required")
+	}
+	if req.PageSize > MaxReviewHistoryPageSize {
+		return fmt.Errorf("pageSize must not exceed %d", MaxReviewHistoryPageSize)
+	}
+	if _, err := parsePageToken(req.PageToken); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (validator) ValidateOptimiseSchedulerParametersRequest(req OptimiseSchedulerParametersRequest) error {
+	if len(strings.TrimSpace(req.UserID)) == 0 {
+		return errors.New("userID is required")
+	}
+	if req.TargetRetention < 0 || req.TargetRetention >= 1 {
+		return errors.New("target retention must be in range (0, 1)")
+	}
+
+	return nil
+}
+
 func (validator) ValidateUpdateCardPerformanceRequest(req UpdateCardPerformanceRequest) error {
 	if len(strings.TrimSpace(req.UserID)) == 0 {
 		return errors.New("userID is required")
@@ -92,6 +314,20 @@ func (validator) ValidateUpdateCardPerformanceRequest(req UpdateCardPerformanceR
 	if len(strings.TrimSpace(req.CardID)) == 0 {
 		return errors.New("cardID is required")
 	}
+	if req.Performance > MaxAllowedPerformanceRating {
+		return fmt.Errorf("performance must be in range [0, %d]", MaxAllowedPerformanceRating)
+	}
+	if req.Direction != "" {
+		return validateDirection(req.Direction)
+	}
+
+	return nil
+}
+
+func validateDirection(direction entity.ReviewDirection) error {
+	if !slices.Contains(entity.ReviewDirections, direction) {
+		return fmt.Errorf("unknown direction %s, must be one of %v", direction, entity.ReviewDirections)
+	}
 
 	return nil
 }
@@ -101,6 +337,67 @@ func (validator) ValidateGetCardsRequest(req GetCardsRequest) error {
 		return errors.New("userID is required")
 	}
 
+	return validateTags(req.Filter.Tags)
+}
+
+func (validator) ValidateUpdateCardTagsRequest(req UpdateCardTagsRequest) error {
+	if err := validateUserIDAndCardID(req.UserID, req.CardID); err != nil {
+		return err
+	}
+	if len(req.Add) == 0 && len(req.Remove) == 0 {
+		return errors.New("tags to add or remove are required")
+	}
+	if err := validateTags(req.Add); err != nil {
+		return err
+	}
+
+	return validateTags(req.Remove)
+}
+
+func (validator) ValidateSetCardDeckRequest(req SetCardDeckRequest) error {
+	if err := validateUserIDAndCardID(req.UserID, req.CardID); err != nil {
+		return err
+	}
+	if req.Deck != strings.TrimSpace(req.Deck) {
+		return fmt.Errorf("deck [%s] must not start or end with spaces", req.Deck)
+	}
+
+	return nil
+}
+
+func (validator) ValidateGetDecksRequest(req GetDecksRequest) error {
+	if len(strings.TrimSpace(req.UserID)) == 0 {
+		return errors.New("userID is required")
+	}
+
+	return nil
+}
+
+// validateTags checks the tags are single words, so they can be typed separated by spaces.
+func validateTags(tags []string) error {
+	for _, tag := range tags {
+		if len(tag) == 0 {
+			return errors.New("tag must not be empty")
+		}
+		if strings.ContainsFunc(tag, unicode.IsSpace) {
+			return fmt.Errorf("tag [%s] must not contain spaces", tag)
+		}
+	}
+
+	return nil
+}
+
+func (validator) ValidateGetCardsForCramRequest(req GetCardsForCramRequest) error {
+	if len(strings.TrimSpace(req.UserID)) == 0 {
+		return errors.New("userID is required")
+	}
+	switch req.Order {
+	case "", CramOrderHardest, CramOrderRecent, CramOrderRandom:
+	default:
+		return fmt.Errorf("unknown cram order [%s], must be %s, %s or %s",
+			req.Order, CramOrderHardest, CramOrderRecent, CramOrderRandom)
+	}
+
 	return nil
 }
 
@@ -123,5 +420,5 @@ func (validator) ValidateGenerateStoryRequest(req GenerateStoryRequest) error {
 		return errors.New("language is required")
 	}
 
-	return nil
+	return validateTags(req.Filter.Tags)
 }
@@ -4,20 +4,27 @@ package dependency
 import (
 	"context"
 	"fmt"
+	"math/rand/v2"
+	"slices"
 	"time"
 
 	"github.com/genvmoroz/lale/service/internal/algo"
 	"github.com/genvmoroz/lale/service/internal/core"
 	"github.com/genvmoroz/lale/service/internal/observability"
 	"github.com/genvmoroz/lale/service/internal/options"
+	"github.com/genvmoroz/lale/service/internal/repo/blob"
 	"github.com/genvmoroz/lale/service/internal/repo/card"
 	"github.com/genvmoroz/lale/service/internal/repo/dictionary"
+	"github.com/genvmoroz/lale/service/internal/repo/review"
 	"github.com/genvmoroz/lale/service/internal/repo/session"
 	"github.com/genvmoroz/lale/service/internal/repo/stub"
+	"github.com/genvmoroz/lale/service/internal/repo/transaction"
+	"github.com/genvmoroz/lale/service/internal/repo/user"
 	"github.com/genvmoroz/lale/service/pkg/openai"
 	"github.com/genvmoroz/lale/service/pkg/speech"
 	"github.com/genvmoroz/lale/service/pkg/speech/google"
 	"github.com/prometheus/client_golang/prometheus"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type Dependency struct {
@@ -48,9 +55,31 @@ func NewDependency(ctx context.Context, cfg options.Config) (*Dependency, error)
 		return nil, fmt.Errorf("register observability metrics: %w", err)
 	}
 
-	cardRepo, err := card.NewRepo(ctx, cfg.CardRepo, metrics.Mongo)
+	mongoClient, err := card.NewClient(ctx, cfg.CardRepo, metrics.Mongo)
 	if err != nil {
-		return nil, fmt.Errorf("create card repo: %w", err)
+		return nil, fmt.Errorf("create mongo client: %w", err)
+	}
+
+	cardRepo := card.NewRepo(mongoClient, cfg.CardRepo)
+	if err = cardRepo.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("ensure card indexes: %w", err)
+	}
+	userRepo := user.NewRepo(
+		mongoClient,
+		user.Config{
+			Database:   cfg.CardRepo.Database,
+			Collection: cfg.UserRepo.Collection,
+		},
+	)
+	reviewRepo := review.NewRepo(
+		mongoClient,
+		review.Config{
+			Database:   cfg.CardRepo.Database,
+			Collection: cfg.ReviewRepo.Collection,
+		},
+	)
+	if err = reviewRepo.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("ensure review indexes: %w", err)
 	}
 
 	var dictionaryRepo core.Dictionary
@@ -80,13 +109,48 @@ func NewDependency(ctx context.Context, cfg options.Config) (*Dependency, error)
 		textToSpeechRepo = speech.NewRepo(googleTextToSpeechClient)
 	}
 
+	scheduler, err := newScheduler(cfg.Scheduler)
+	if err != nil {
+		return nil, fmt.Errorf("create scheduler: %w", err)
+	}
+
+	learningSteps, err := newLearningSteps(cfg.Scheduler)
+	if err != nil {
+		return nil, fmt.Errorf("create learning steps: %w", err)
+	}
+
+	leeches, err := newLeeches(cfg.Scheduler)
+	if err != nil {
+		return nil, fmt.Errorf("create leech detector: %w", err)
+	}
+
+	blobStore, err := blob.NewLocalStore(cfg.BlobStore.Path)
+	if err != nil {
+		return nil, fmt.Errorf("create blob store: %w", err)
+	}
+
+	audioStore, err := NewAudioStore(cfg.AudioStore, mongoClient.Database(cfg.CardRepo.Database))
+	if err != nil {
+		return nil, fmt.Errorf("create audio store: %w", err)
+	}
+
 	service, err := core.NewService(
 		cardRepo,
+		userRepo,
+		reviewRepo,
+		transaction.NewRunner(mongoClient),
 		userSessionRepo,
 		openaiHelper,
-		algo.NewAnki(time.Now),
+		scheduler,
+		learningSteps,
+		leeches,
+		algo.NewMaintenance(time.Now, cfg.Scheduler.MaintenanceInterval, cfg.Scheduler.MaintenanceDailyLimit),
+		algo.NewBalancer(cfg.Scheduler.Fuzz, cfg.Scheduler.LoadBalancing, rand.IntN),
+		algo.NewOptimiser(cfg.Scheduler.Algorithm, cfg.Scheduler.DesiredRetention),
 		dictionaryRepo,
 		textToSpeechRepo,
+		blobStore,
+		audioStore,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("create core service: %w", err)
@@ -95,6 +159,54 @@ func NewDependency(ctx context.Context, cfg options.Config) (*Dependency, error)
 	return &Dependency{service: service}, nil
 }
 
+// NewAudioStore creates the store of the words' audio, the GridFS bucket is created in the database.
+func NewAudioStore(cfg options.AudioStoreConfig, db *mongo.Database) (core.BlobStore, error) {
+	switch cfg.Type {
+	case options.AudioStoreGridFS:
+		return blob.NewGridFSStore(db, cfg.Bucket)
+	case options.AudioStoreLocal:
+		return blob.NewLocalStore(cfg.Path)
+	default:
+		return nil, fmt.Errorf("unknown audio store type: %s", cfg.Type)
+	}
+}
+
+func newScheduler(cfg algo.Config) (core.AnkiAlgo, error) {
+	if cfg.DesiredRetention <= 0 || cfg.DesiredRetention >= 1 {
+		return nil, fmt.Errorf("desired retention must be in range (0, 1), got %v", cfg.DesiredRetention)
+	}
+
+	switch cfg.Algorithm {
+	case algo.AlgorithmAnki:
+		return algo.NewAnki(time.Now, cfg.DesiredRetention), nil
+	case algo.AlgorithmFSRS:
+		return algo.NewFSRS(time.Now, cfg.DesiredRetention), nil
+	default:
+		return nil, fmt.Errorf("unknown scheduling algorithm: %s", cfg.Algorithm)
+	}
+}
+
+func newLearningSteps(cfg algo.Config) (*algo.LearningSteps, error) {
+	for _, step := range slices.Concat(cfg.LearningSteps, cfg.RelearningSteps) {
+		if step <= 0 {
+			return nil, fmt.Errorf("learning steps must be positive, got %s", step)
+		}
+	}
+
+	return algo.NewLearningSteps(time.Now, cfg.LearningSteps, cfg.RelearningSteps), nil
+}
+
+func newLeeches(cfg algo.Config) (*algo.Leeches, error) {
+	switch cfg.LeechAction {
+	case algo.LeechActionTag:
+		return algo.NewLeeches(cfg.LeechThreshold, false), nil
+	case algo.LeechActionSuspend:
+		return algo.NewLeeches(cfg.LeechThreshold, true), nil
+	default:
+		return nil, fmt.Errorf("unknown leech action: %s", cfg.LeechAction)
+	}
+}
+
 func (d *Dependency) BuildService() *core.Service {
 	return d.service
 }
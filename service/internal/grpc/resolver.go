@@ -20,11 +20,30 @@ type Service interface {
 	UpdateCard(ctx context.Context, req core.UpdateCardRequest) (entity.Card, error)
 	UpdateCardPerformance(ctx context.Context, req core.UpdateCardPerformanceRequest) (core.UpdateCardPerformanceResponse, error) //nolint:lll // long line
 	GetCardsToLearn(ctx context.Context, req core.GetCardsRequest) (core.GetCardsResponse, error)
+	GetLeeches(ctx context.Context, req core.GetCardsRequest) (core.GetCardsResponse, error)
+	GetCardsForCram(ctx context.Context, req core.GetCardsForCramRequest) (core.GetCardsResponse, error)
 	GetCardsToRepeat(ctx context.Context, req core.GetCardsRequest) (core.GetCardsResponse, error)
 	GetSentences(ctx context.Context, req core.GetSentencesRequest) (core.GetSentencesResponse, error)
 	GenerateStory(ctx context.Context, req core.GenerateStoryRequest) (core.GenerateStoryResponse, error)
+	GetCard(ctx context.Context, req core.GetCardRequest) (entity.Card, error)
 	DeleteCard(ctx context.Context, req core.DeleteCardRequest) (entity.Card, error)
 	MarkCardLearnt(ctx context.Context, req core.MarkCardLearntRequest) (entity.Card, error)
+	ResetCard(ctx context.Context, req core.ResetCardRequest) (entity.Card, error)
+	SuspendCard(ctx context.Context, req core.SuspendCardRequest) (entity.Card, error)
+	BuryCard(ctx context.Context, req core.BuryCardRequest) (entity.Card, error)
+	UpdateCardTags(ctx context.Context, req core.UpdateCardTagsRequest) (entity.Card, error)
+	SetCardDeck(ctx context.Context, req core.SetCardDeckRequest) (entity.Card, error)
+	GetDecks(ctx context.Context, req core.GetDecksRequest) (core.GetDecksResponse, error)
+	UploadImage(ctx context.Context, req core.UploadImageRequest) (entity.Image, error)
+	GetImage(ctx context.Context, req core.GetImageRequest) (entity.Image, error)
+	GetAudio(ctx context.Context, req core.GetAudioRequest) (entity.Audio, error)
+	GetUserProfile(ctx context.Context, req core.GetUserProfileRequest) (entity.Profile, error)
+	UpdateUserProfile(ctx context.Context, req core.UpdateUserProfileRequest) (entity.Profile, error)
+	UpdateDailyLimits(ctx context.Context, req core.UpdateDailyLimitsRequest) (entity.DailyLimits, error)
+	UpdateGraduationPolicy(ctx context.Context, req core.UpdateGraduationPolicyRequest) (entity.GraduationPolicy, error)
+	UndoLastReview(ctx context.Context, req core.UndoLastReviewRequest) (entity.Card, error)
+	GetReviewHistory(ctx context.Context, req core.GetReviewHistoryRequest) (core.GetReviewHistoryResponse, error)
+	OptimiseSchedulerParameters(ctx context.Context, req core.OptimiseSchedulerParametersRequest) (core.OptimiseSchedulerParametersResponse, error) //nolint:lll // long line
 }
 
 type Resolver struct {
@@ -85,6 +104,16 @@ func (r *Resolver) GetAllCards(ctx context.Context, req *api.GetCardsRequest) (*
 	)
 }
 
+func (r *Resolver) GetLeeches(ctx context.Context, req *api.GetCardsRequest) (*api.GetCardsResponse, error) {
+	return genericResolver(
+		ctx,
+		req,
+		r.transformer.ToCoreGetCardsRequest,
+		r.service.GetLeeches,
+		r.transformer.ToAPIGetCardsResponse,
+	)
+}
+
 func (r *Resolver) UpdateCardPerformance(
 	ctx context.Context,
 	req *api.UpdateCardPerformanceRequest,
@@ -92,9 +121,7 @@ func (r *Resolver) UpdateCardPerformance(
 	return genericResolver(
 		ctx,
 		req,
-		func(req *api.UpdateCardPerformanceRequest) (core.UpdateCardPerformanceRequest, error) {
-			return r.transformer.ToCoreUpdateCardPerformanceRequest(req), nil
-		},
+		r.transformer.ToCoreUpdateCardPerformanceRequest,
 		r.service.UpdateCardPerformance,
 		r.transformer.ToAPIUpdateCardPerformanceResponse,
 	)
@@ -120,6 +147,19 @@ func (r *Resolver) GetCardsToLearn(ctx context.Context, req *api.GetCardsRequest
 	)
 }
 
+func (r *Resolver) GetCardsForCram(
+	ctx context.Context,
+	req *api.GetCardsForCramRequest,
+) (*api.GetCardsResponse, error) {
+	return genericResolver(
+		ctx,
+		req,
+		r.transformer.ToCoreGetCardsForCramRequest,
+		r.service.GetCardsForCram,
+		r.transformer.ToAPIGetCardsResponse,
+	)
+}
+
 func (r *Resolver) GetCardsToRepeat(ctx context.Context, req *api.GetCardsRequest) (*api.GetCardsResponse, error) {
 	return genericResolver(
 		ctx,
@@ -155,6 +195,18 @@ func (r *Resolver) GenerateStory(
 	)
 }
 
+func (r *Resolver) GetCard(ctx context.Context, req *api.GetCardRequest) (*api.Card, error) {
+	return genericResolver(
+		ctx,
+		req,
+		func(req *api.GetCardRequest) (core.GetCardRequest, error) {
+			return r.transformer.ToCoreGetCardRequest(req), nil
+		},
+		r.service.GetCard,
+		r.transformer.ToAPICard,
+	)
+}
+
 func (r *Resolver) DeleteCard(ctx context.Context, req *api.DeleteCardRequest) (*api.Card, error) {
 	return genericResolver(
 		ctx,
@@ -179,6 +231,209 @@ func (r *Resolver) MarkCardLearnt(ctx context.Context, req *api.MarkCardLearntRe
 	)
 }
 
+func (r *Resolver) ResetCard(ctx context.Context, req *api.ResetCardRequest) (*api.Card, error) {
+	return genericResolver(
+		ctx,
+		req,
+		func(req *api.ResetCardRequest) (core.ResetCardRequest, error) {
+			return r.transformer.ToCoreResetCardRequest(req), nil
+		},
+		r.service.ResetCard,
+		r.transformer.ToAPICard,
+	)
+}
+
+func (r *Resolver) SuspendCard(ctx context.Context, req *api.SuspendCardRequest) (*api.Card, error) {
+	return genericResolver(
+		ctx,
+		req,
+		func(req *api.SuspendCardRequest) (core.SuspendCardRequest, error) {
+			return r.transformer.ToCoreSuspendCardRequest(req), nil
+		},
+		r.service.SuspendCard,
+		r.transformer.ToAPICard,
+	)
+}
+
+func (r *Resolver) BuryCard(ctx context.Context, req *api.BuryCardRequest) (*api.Card, error) {
+	return genericResolver(
+		ctx,
+		req,
+		func(req *api.BuryCardRequest) (core.BuryCardRequest, error) {
+			return r.transformer.ToCoreBuryCardRequest(req), nil
+		},
+		r.service.BuryCard,
+		r.transformer.ToAPICard,
+	)
+}
+
+func (r *Resolver) UpdateCardTags(ctx context.Context, req *api.UpdateCardTagsRequest) (*api.Card, error) {
+	return genericResolver(
+		ctx,
+		req,
+		func(req *api.UpdateCardTagsRequest) (core.UpdateCardTagsRequest, error) {
+			return r.transformer.ToCoreUpdateCardTagsRequest(req), nil
+		},
+		r.service.UpdateCardTags,
+		r.transformer.ToAPICard,
+	)
+}
+
+func (r *Resolver) SetCardDeck(ctx context.Context, req *api.SetCardDeckRequest) (*api.Card, error) {
+	return genericResolver(
+		ctx,
+		req,
+		func(req *api.SetCardDeckRequest) (core.SetCardDeckRequest, error) {
+			return r.transformer.ToCoreSetCardDeckRequest(req), nil
+		},
+		r.service.SetCardDeck,
+		r.transformer.ToAPICard,
+	)
+}
+
+func (r *Resolver) GetDecks(ctx context.Context, req *api.GetDecksRequest) (*api.GetDecksResponse, error) {
+	return genericResolver(
+		ctx,
+		req,
+		r.transformer.ToCoreGetDecksRequest,
+		r.service.GetDecks,
+		r.transformer.ToAPIGetDecksResponse,
+	)
+}
+
+func (r *Resolver) UploadImage(ctx context.Context, req *api.UploadImageRequest) (*api.Image, error) {
+	return genericResolver(
+		ctx,
+		req,
+		func(req *api.UploadImageRequest) (core.UploadImageRequest, error) {
+			return r.transformer.ToCoreUploadImageRequest(req), nil
+		},
+		r.service.UploadImage,
+		r.transformer.ToAPIImage,
+	)
+}
+
+func (r *Resolver) GetImage(ctx context.Context, req *api.GetImageRequest) (*api.Image, error) {
+	return genericResolver(
+		ctx,
+		req,
+		func(req *api.GetImageRequest) (core.GetImageRequest, error) {
+			return r.transformer.ToCoreGetImageRequest(req), nil
+		},
+		r.service.GetImage,
+		r.transformer.ToAPIImage,
+	)
+}
+
+// GetAudio streams the audio in chunks, so the size of the audio is not limited by the message size.
+func (r *Resolver) GetAudio(req *api.GetAudioRequest, stream api.LaleService_GetAudioServer) error {
+	if req == nil {
+		return status.Error(codes.InvalidArgument, "request must not be nil")
+	}
+
+	audio, err := r.service.GetAudio(stream.Context(), r.transformer.ToCoreGetAudioRequest(req))
+	if err != nil {
+		return resolveCoreError(err)
+	}
+
+	for _, chunk := range r.transformer.ToAPIAudioChunks(audio) {
+		if err = stream.Send(chunk); err != nil {
+			return fmt.Errorf("send audio chunk: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Resolver) GetUserProfile(ctx context.Context, req *api.GetUserProfileRequest) (*api.UserProfile, error) {
+	return genericResolver(
+		ctx,
+		req,
+		func(req *api.GetUserProfileRequest) (core.GetUserProfileRequest, error) {
+			return r.transformer.ToCoreGetUserProfileRequest(req), nil
+		},
+		r.service.GetUserProfile,
+		r.transformer.ToAPIUserProfile,
+	)
+}
+
+func (r *Resolver) UpdateUserProfile(ctx context.Context, req *api.UpdateUserProfileRequest) (*api.UserProfile, error) {
+	return genericResolver(
+		ctx,
+		req,
+		r.transformer.ToCoreUpdateUserProfileRequest,
+		r.service.UpdateUserProfile,
+		r.transformer.ToAPIUserProfile,
+	)
+}
+
+func (r *Resolver) UpdateDailyLimits(ctx context.Context, req *api.UpdateDailyLimitsRequest) (*api.DailyLimits, error) {
+	return genericResolver(
+		ctx,
+		req,
+		r.transformer.ToCoreUpdateDailyLimitsRequest,
+		r.service.UpdateDailyLimits,
+		r.transformer.ToAPIDailyLimits,
+	)
+}
+
+func (r *Resolver) UpdateGraduationPolicy(
+	ctx context.Context,
+	req *api.UpdateGraduationPolicyRequest,
+) (*api.GraduationPolicy, error) {
+	return genericResolver(
+		ctx,
+		req,
+		func(req *api.UpdateGraduationPolicyRequest) (core.UpdateGraduationPolicyRequest, error) {
+			return r.transformer.ToCoreUpdateGraduationPolicyRequest(req), nil
+		},
+		r.service.UpdateGraduationPolicy,
+		r.transformer.ToAPIGraduationPolicy,
+	)
+}
+
+func (r *Resolver) UndoLastReview(ctx context.Context, req *api.UndoLastReviewRequest) (*api.Card, error) {
+	return genericResolver(
+		ctx,
+		req,
+		func(req *api.UndoLastReviewRequest) (core.UndoLastReviewRequest, error) {
+			return r.transformer.ToCoreUndoLastReviewRequest(req), nil
+		},
+		r.service.UndoLastReview,
+		r.transformer.ToAPICard,
+	)
+}
+
+func (r *Resolver) GetReviewHistory(
+	ctx context.Context,
+	req *api.GetReviewHistoryRequest,
+) (*api.GetReviewHistoryResponse, error) {
+	return genericResolver(
+		ctx,
+		req,
+		func(req *api.GetReviewHistoryRequest) (core.GetReviewHistoryRequest, error) {
+			return r.transformer.ToCoreGetReviewHistoryRequest(req), nil
+		},
+		r.service.GetReviewHistory,
+		r.transformer.ToAPIGetReviewHistoryResponse,
+	)
+}
+
+func (r *Resolver) OptimiseSchedulerParameters(
+	ctx context.Context,
+	req *api.OptimiseSchedulerParametersRequest,
+) (*api.OptimiseSchedulerParametersResponse, error) {
+	return genericResolver(
+		ctx,
+		req,
+		func(req *api.OptimiseSchedulerParametersRequest) (core.OptimiseSchedulerParametersRequest, error) {
+			return r.transformer.ToCoreOptimiseSchedulerParametersRequest(req), nil
+		},
+		r.service.OptimiseSchedulerParameters,
+		r.transformer.ToAPIOptimiseSchedulerParametersResponse,
+	)
+}
+
 func genericResolver[
 	APIRequest any,
 	CoreRequest any,
@@ -225,6 +480,8 @@ func resolveCoreError(err error) error {
 		return status.Error(codes.AlreadyExists, err.Error())
 	case core.IsFailedPreconditionError(err):
 		return status.Error(codes.FailedPrecondition, err.Error())
+	case core.IsConflictError(err):
+		return status.Error(codes.Aborted, err.Error())
 	default:
 		return status.Error(codes.Internal, err.Error())
 	}
@@ -58,6 +58,13 @@ func TestResolveCoreError(t *testing.T) {
 			),
 			wantCode: codes.FailedPrecondition,
 		},
+		{
+			name: "conflict",
+			err: fmt.Errorf("%w: card changed",
+				core.NewConflictError(),
+			),
+			wantCode: codes.Aborted,
+		},
 		{
 			name:     "internal fallback",
 			err:      errors.New("mongodb exploded"),
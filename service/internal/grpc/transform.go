@@ -2,11 +2,14 @@ package grpc
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/genvmoroz/lale/service/api"
 	"github.com/genvmoroz/lale/service/internal/core"
 	"github.com/genvmoroz/lale/service/pkg/entity"
 	"golang.org/x/text/language"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -19,15 +22,47 @@ type (
 		ToCoreCreateCardRequest(req *api.CreateCardRequest) (core.CreateCardRequest, error)
 		ToCoreGetCardsRequest(req *api.GetCardsRequest) (core.GetCardsRequest, error)
 		ToAPIGetCardsResponse(resp core.GetCardsResponse) *api.GetCardsResponse
+		ToCoreGetCardsForCramRequest(req *api.GetCardsForCramRequest) (core.GetCardsForCramRequest, error)
 		ToCoreUpdateCardRequest(req *api.UpdateCardRequest) (core.UpdateCardRequest, error)
-		ToCoreUpdateCardPerformanceRequest(req *api.UpdateCardPerformanceRequest) core.UpdateCardPerformanceRequest
+		ToCoreUpdateCardPerformanceRequest(
+			req *api.UpdateCardPerformanceRequest,
+		) (core.UpdateCardPerformanceRequest, error)
 		ToAPIUpdateCardPerformanceResponse(resp core.UpdateCardPerformanceResponse) *api.UpdateCardPerformanceResponse
 		ToCoreGetSentencesRequest(req *api.GetSentencesRequest) core.GetSentencesRequest
 		ToAPIGetSentencesResponse(resp core.GetSentencesResponse) *api.GetSentencesResponse
 		ToCoreGenerateStoryRequest(req *api.GenerateStoryRequest) (core.GenerateStoryRequest, error)
 		ToAPIGenerateStoryResponse(resp core.GenerateStoryResponse) *api.GenerateStoryResponse
+		ToCoreGetCardRequest(req *api.GetCardRequest) core.GetCardRequest
 		ToCoreDeleteCardRequest(req *api.DeleteCardRequest) core.DeleteCardRequest
 		ToCoreMarkCardLearntRequest(req *api.MarkCardLearntRequest) core.MarkCardLearntRequest
+		ToCoreResetCardRequest(req *api.ResetCardRequest) core.ResetCardRequest
+		ToCoreSuspendCardRequest(req *api.SuspendCardRequest) core.SuspendCardRequest
+		ToCoreBuryCardRequest(req *api.BuryCardRequest) core.BuryCardRequest
+		ToCoreUpdateCardTagsRequest(req *api.UpdateCardTagsRequest) core.UpdateCardTagsRequest
+		ToCoreSetCardDeckRequest(req *api.SetCardDeckRequest) core.SetCardDeckRequest
+		ToCoreGetDecksRequest(req *api.GetDecksRequest) (core.GetDecksRequest, error)
+		ToAPIGetDecksResponse(resp core.GetDecksResponse) *api.GetDecksResponse
+		ToCoreUploadImageRequest(req *api.UploadImageRequest) core.UploadImageRequest
+		ToCoreGetImageRequest(req *api.GetImageRequest) core.GetImageRequest
+		ToAPIImage(image entity.Image) *api.Image
+		ToCoreGetAudioRequest(req *api.GetAudioRequest) core.GetAudioRequest
+		ToAPIAudioChunks(audio entity.Audio) []*api.AudioChunk
+		ToCoreGetUserProfileRequest(req *api.GetUserProfileRequest) core.GetUserProfileRequest
+		ToCoreUpdateUserProfileRequest(req *api.UpdateUserProfileRequest) (core.UpdateUserProfileRequest, error)
+		ToAPIUserProfile(profile entity.Profile) *api.UserProfile
+		ToCoreUpdateDailyLimitsRequest(req *api.UpdateDailyLimitsRequest) (core.UpdateDailyLimitsRequest, error)
+		ToAPIDailyLimits(limits entity.DailyLimits) *api.DailyLimits
+		ToCoreUpdateGraduationPolicyRequest(req *api.UpdateGraduationPolicyRequest) core.UpdateGraduationPolicyRequest
+		ToAPIGraduationPolicy(policy entity.GraduationPolicy) *api.GraduationPolicy
+		ToCoreUndoLastReviewRequest(req *api.UndoLastReviewRequest) core.UndoLastReviewRequest
+		ToCoreGetReviewHistoryRequest(req *api.GetReviewHistoryRequest) core.GetReviewHistoryRequest
+		ToAPIGetReviewHistoryResponse(resp core.GetReviewHistoryResponse) *api.GetReviewHistoryResponse
+		ToCoreOptimiseSchedulerParametersRequest(
+			req *api.OptimiseSchedulerParametersRequest,
+		) core.OptimiseSchedulerParametersRequest
+		ToAPIOptimiseSchedulerParametersResponse(
+			resp core.OptimiseSchedulerParametersResponse,
+		) *api.OptimiseSchedulerParametersResponse
 	}
 
 	transformer struct{}
@@ -97,22 +132,72 @@ func (t transformer) ToCoreCreateCardRequest(req *api.CreateCardRequest) (core.C
 	return core.CreateCardRequest{
 		UserID:              req.GetUserID(),
 		Language:            lang,
+		Type:                toCoreNoteType(req.GetType()),
 		WordInformationList: words,
+		Note:                toCoreNote(req.GetNote()),
 	}, nil
 }
 
-func (transformer) ToCoreGetCardsRequest(req *api.GetCardsRequest) (core.GetCardsRequest, error) {
+func (t transformer) ToCoreGetCardsRequest(req *api.GetCardsRequest) (core.GetCardsRequest, error) {
 	if req == nil {
 		return core.GetCardsRequest{}, nil
 	}
 
-	lang, err := language.Parse(req.GetLanguage())
-	if err != nil {
-		return core.GetCardsRequest{}, fmt.Errorf("invalid language (%s): %w", req.GetLanguage(), err)
+	lang := language.Und
+	if len(strings.TrimSpace(req.GetLanguage())) != 0 {
+		var err error
+		if lang, err = language.Parse(req.GetLanguage()); err != nil {
+			return core.GetCardsRequest{}, fmt.Errorf("invalid language (%s): %w", req.GetLanguage(), err)
+		}
 	}
+
 	return core.GetCardsRequest{
 		UserID:   req.GetUserID(),
 		Language: lang,
+		Filter:   t.toCoreCardFilter(req.GetFilter()),
+	}, nil
+}
+
+func (transformer) toCoreCardFilter(filter *api.CardFilter) entity.CardFilter {
+	return entity.CardFilter{
+		Tags: trimTags(filter.GetTags()),
+		Deck: strings.TrimSpace(filter.GetDeck()),
+	}
+}
+
+// trimTags trims the spaces around the tags, nil stays nil.
+func trimTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+
+	trimmed := make([]string, len(tags))
+	for i, tag := range tags {
+		trimmed[i] = strings.TrimSpace(tag)
+	}
+
+	return trimmed
+}
+
+func (transformer) ToCoreGetCardsForCramRequest(req *api.GetCardsForCramRequest) (core.GetCardsForCramRequest, error) {
+	if req == nil {
+		return core.GetCardsForCramRequest{}, nil
+	}
+
+	lang := language.Und
+	if len(strings.TrimSpace(req.GetLanguage())) != 0 {
+		var err error
+		if lang, err = language.Parse(req.GetLanguage()); err != nil {
+			return core.GetCardsForCramRequest{}, fmt.Errorf("invalid language (%s): %w", req.GetLanguage(), err)
+		}
+	}
+
+	return core.GetCardsForCramRequest{
+		UserID:   req.GetUserID(),
+		Language: lang,
+		CardIDs:  req.GetCardIDs(),
+		Order:    core.CramOrder(strings.ToLower(strings.TrimSpace(req.GetOrder()))),
+		Limit:    req.GetLimit(),
 	}, nil
 }
 
@@ -121,16 +206,274 @@ func (t transformer) ToAPIGetCardsResponse(resp core.GetCardsResponse) *api.GetC
 		UserID:   resp.UserID,
 		Language: resp.Language.String(),
 		Cards:    t.toAPICards(resp.Cards),
+
+		RemainingToday: resp.RemainingToday,
+		Postponed:      resp.Postponed,
 	}
 }
 
 func (transformer) ToCoreUpdateCardPerformanceRequest(
 	req *api.UpdateCardPerformanceRequest,
-) core.UpdateCardPerformanceRequest {
+) (core.UpdateCardPerformanceRequest, error) {
+	// a missing rating is not taken for a failed answer, it's sent by the clients still on is_input_correct
+	if req == nil || req.Performance == nil {
+		return core.UpdateCardPerformanceRequest{}, fmt.Errorf("performance is required")
+	}
+
 	return core.UpdateCardPerformanceRequest{
-		UserID:         req.GetUserID(),
-		CardID:         req.GetCardID(),
-		IsInputCorrect: req.GetIsInputCorrect(),
+		UserID:       req.GetUserID(),
+		CardID:       req.GetCardID(),
+		Performance:  req.GetPerformance(),
+		TimeToAnswer: req.GetTimeToAnswer().AsDuration(),
+		Direction:    toCoreDirection(req.GetDirection()),
+	}, nil
+}
+
+// dayStartLayout is the layout of the time the user's day starts at.
+const dayStartLayout = "15:04"
+
+func (transformer) ToCoreResetCardRequest(req *api.ResetCardRequest) core.ResetCardRequest {
+	return core.ResetCardRequest{
+		UserID: req.GetUserID(),
+		CardID: req.GetCardID(),
+		Mode:   core.ResetMode(strings.ToLower(strings.TrimSpace(req.GetMode()))),
+	}
+}
+
+func (transformer) ToCoreSuspendCardRequest(req *api.SuspendCardRequest) core.SuspendCardRequest {
+	return core.SuspendCardRequest{
+		UserID:    req.GetUserID(),
+		CardID:    req.GetCardID(),
+		Suspended: req.GetSuspended(),
+	}
+}
+
+func (transformer) ToCoreBuryCardRequest(req *api.BuryCardRequest) core.BuryCardRequest {
+	return core.BuryCardRequest{
+		UserID: req.GetUserID(),
+		CardID: req.GetCardID(),
+		Buried: req.GetBuried(),
+	}
+}
+
+func (transformer) ToCoreUpdateCardTagsRequest(req *api.UpdateCardTagsRequest) core.UpdateCardTagsRequest {
+	return core.UpdateCardTagsRequest{
+		UserID: req.GetUserID(),
+		CardID: req.GetCardID(),
+		Add:    trimTags(req.GetAdd()),
+		Remove: trimTags(req.GetRemove()),
+	}
+}
+
+func (transformer) ToCoreSetCardDeckRequest(req *api.SetCardDeckRequest) core.SetCardDeckRequest {
+	return core.SetCardDeckRequest{
+		UserID: req.GetUserID(),
+		CardID: req.GetCardID(),
+		Deck:   strings.TrimSpace(req.GetDeck()),
+	}
+}
+
+func (transformer) ToCoreGetDecksRequest(req *api.GetDecksRequest) (core.GetDecksRequest, error) {
+	lang := language.Und
+	if len(strings.TrimSpace(req.GetLanguage())) != 0 {
+		var err error
+		if lang, err = language.Parse(req.GetLanguage()); err != nil {
+			return core.GetDecksRequest{}, fmt.Errorf("invalid language (%s): %w", req.GetLanguage(), err)
+		}
+	}
+
+	return core.GetDecksRequest{
+		UserID:   req.GetUserID(),
+		Language: lang,
+	}, nil
+}
+
+func (transformer) ToAPIGetDecksResponse(resp core.GetDecksResponse) *api.GetDecksResponse {
+	decks := make([]*api.Deck, 0, len(resp.Decks))
+	for _, deck := range resp.Decks {
+		decks = append(decks, &api.Deck{
+			Name:  deck.Name,
+			Cards: deck.Cards,
+		})
+	}
+
+	return &api.GetDecksResponse{
+		UserID: resp.UserID,
+		Decks:  decks,
+	}
+}
+
+func (transformer) ToCoreUploadImageRequest(req *api.UploadImageRequest) core.UploadImageRequest {
+	return core.UploadImageRequest{
+		UserID: req.GetUserID(),
+		Data:   req.GetData(),
+	}
+}
+
+func (transformer) ToCoreGetImageRequest(req *api.GetImageRequest) core.GetImageRequest {
+	return core.GetImageRequest{
+		UserID:  req.GetUserID(),
+		ImageID: strings.TrimSpace(req.GetImageID()),
+	}
+}
+
+func (transformer) ToAPIImage(image entity.Image) *api.Image {
+	return &api.Image{
+		Id:          image.ID,
+		ContentType: image.ContentType,
+		Data:        image.Data,
+	}
+}
+
+func (transformer) ToCoreGetAudioRequest(req *api.GetAudioRequest) core.GetAudioRequest {
+	return core.GetAudioRequest{
+		UserID:  req.GetUserID(),
+		AudioID: strings.ToLower(strings.TrimSpace(req.GetAudioID())),
+	}
+}
+
+// audioChunkSize is the size of the audio data streamed in a single message by GetAudio.
+const audioChunkSize = 64 << 10
+
+func (transformer) ToAPIAudioChunks(audio entity.Audio) []*api.AudioChunk {
+	chunks := []*api.AudioChunk{{ContentType: audio.ContentType}}
+	for data := audio.Data; len(data) != 0; {
+		n := min(len(data), audioChunkSize)
+		last := chunks[len(chunks)-1]
+		if len(last.Data) != 0 {
+			last = &api.AudioChunk{}
+			chunks = append(chunks, last)
+		}
+		last.Data = data[:n]
+		data = data[n:]
+	}
+
+	return chunks
+}
+
+func (transformer) ToCoreGetUserProfileRequest(req *api.GetUserProfileRequest) core.GetUserProfileRequest {
+	return core.GetUserProfileRequest{
+		UserID: req.GetUserID(),
+	}
+}
+
+func (transformer) ToCoreUpdateUserProfileRequest(req *api.UpdateUserProfileRequest) (core.UpdateUserProfileRequest, error) {
+	if req == nil {
+		return core.UpdateUserProfileRequest{}, nil
+	}
+
+	var dayStart time.Duration
+	if raw := req.GetProfile().GetDayStart(); raw != "" {
+		parsed, err := time.Parse(dayStartLayout, raw)
+		if err != nil {
+			return core.UpdateUserProfileRequest{}, fmt.Errorf("parse day start: %w", err)
+		}
+		dayStart = time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute
+	}
+
+	return core.UpdateUserProfileRequest{
+		UserID: req.GetUserID(),
+		Profile: entity.Profile{
+			TimeZone:   req.GetProfile().GetTimeZone(),
+			DayStart:   dayStart,
+			Directions: toCoreDirections(req.GetProfile().GetDirections()),
+		},
+	}, nil
+}
+
+func (transformer) ToAPIUserProfile(profile entity.Profile) *api.UserProfile {
+	return &api.UserProfile{
+		TimeZone:   profile.TimeZone,
+		DayStart:   time.Time{}.Add(profile.DayStart).Format(dayStartLayout),
+		Directions: toAPIDirections(profile.Directions),
+	}
+}
+
+func (transformer) ToCoreUpdateDailyLimitsRequest(req *api.UpdateDailyLimitsRequest) (core.UpdateDailyLimitsRequest, error) {
+	if req == nil {
+		return core.UpdateDailyLimitsRequest{}, nil
+	}
+
+	lang, err := language.Parse(req.GetLanguage())
+	if err != nil {
+		return core.UpdateDailyLimitsRequest{}, fmt.Errorf("invalid language (%s): %w", req.GetLanguage(), err)
+	}
+
+	return core.UpdateDailyLimitsRequest{
+		UserID:   req.GetUserID(),
+		Language: lang,
+		Limits: entity.DailyLimits{
+			NewCards: req.GetLimits().GetNewCards(),
+			Reviews:  req.GetLimits().GetReviews(),
+		},
+	}, nil
+}
+
+func (transformer) ToCoreUpdateGraduationPolicyRequest(
+	req *api.UpdateGraduationPolicyRequest,
+) core.UpdateGraduationPolicyRequest {
+	return core.UpdateGraduationPolicyRequest{
+		UserID: req.GetUserID(),
+		Policy: entity.GraduationPolicy{
+			CorrectAnswers: req.GetPolicy().GetCorrectAnswers(),
+			Interval:       req.GetPolicy().GetInterval().AsDuration(),
+		},
+	}
+}
+
+func (transformer) ToAPIGraduationPolicy(policy entity.GraduationPolicy) *api.GraduationPolicy {
+	return &api.GraduationPolicy{
+		CorrectAnswers: policy.CorrectAnswers,
+		Interval:       durationpb.New(policy.Interval),
+	}
+}
+
+func (transformer) ToAPIDailyLimits(limits entity.DailyLimits) *api.DailyLimits {
+	return &api.DailyLimits{
+		NewCards: limits.NewCards,
+		Reviews:  limits.Reviews,
+	}
+}
+
+func (transformer) ToCoreUndoLastReviewRequest(req *api.UndoLastReviewRequest) core.UndoLastReviewRequest {
+	return core.UndoLastReviewRequest{
+		UserID: req.GetUserID(),
+		CardID: req.GetCardID(),
+	}
+}
+
+func (transformer) ToCoreGetReviewHistoryRequest(req *api.GetReviewHistoryRequest) core.GetReviewHistoryRequest {
+	return core.GetReviewHistoryRequest{
+		UserID:    req.GetUserID(),
+		CardID:    req.GetCardID(),
+		PageSize:  req.GetPageSize(),
+		PageToken: req.GetPageToken(),
+	}
+}
+
+func (t transformer) ToAPIGetReviewHistoryResponse(resp core.GetReviewHistoryResponse) *api.GetReviewHistoryResponse {
+	reviews := make([]*api.Review, 0, len(resp.Reviews))
+	for _, review := range resp.Reviews {
+		reviews = append(reviews, t.toAPIReview(review))
+	}
+
+	return &api.GetReviewHistoryResponse{
+		Reviews:       reviews,
+		NextPageToken: resp.NextPageToken,
+	}
+}
+
+func (transformer) toAPIReview(review entity.Review) *api.Review {
+	return &api.Review{
+		Id:               review.ID,
+		UserID:           review.UserID,
+		CardID:           review.CardID,
+		ReviewedAt:       timestamppb.New(review.ReviewedAt),
+		Performance:      review.Performance,
+		TimeToAnswer:     durationpb.New(review.TimeToAnswer),
+		PreviousInterval: durationpb.New(review.PreviousInterval),
+		NewInterval:      durationpb.New(review.NewInterval),
+		Direction:        string(review.Direction.OrProduction()),
 	}
 }
 
@@ -139,6 +482,33 @@ func (transformer) ToAPIUpdateCardPerformanceResponse(
 ) *api.UpdateCardPerformanceResponse {
 	return &api.UpdateCardPerformanceResponse{
 		NextDueDate: timestamppb.New(resp.NextDueDate),
+		Graduated:   resp.Graduated,
+	}
+}
+
+func (transformer) ToCoreOptimiseSchedulerParametersRequest(
+	req *api.OptimiseSchedulerParametersRequest,
+) core.OptimiseSchedulerParametersRequest {
+	return core.OptimiseSchedulerParametersRequest{
+		UserID:          req.GetUserID(),
+		TargetRetention: req.GetTargetRetention(),
+	}
+}
+
+func (transformer) ToAPIOptimiseSchedulerParametersResponse(
+	resp core.OptimiseSchedulerParametersResponse,
+) *api.OptimiseSchedulerParametersResponse {
+	return &api.OptimiseSchedulerParametersResponse{
+		PredictedRetentionBefore: resp.PredictedRetentionBefore,
+		PredictedRetentionAfter:  resp.PredictedRetentionAfter,
+		ReviewsNumber:            resp.ReviewsNumber,
+	}
+}
+
+func (transformer) ToCoreGetCardRequest(req *api.GetCardRequest) core.GetCardRequest {
+	return core.GetCardRequest{
+		UserID: req.GetUserID(),
+		CardID: req.GetCardID(),
 	}
 }
 
@@ -183,6 +553,7 @@ func (t transformer) ToCoreGenerateStoryRequest(req *api.GenerateStoryRequest) (
 	return core.GenerateStoryRequest{
 		UserID:   req.GetUserID(),
 		Language: lang,
+		Filter:   t.toCoreCardFilter(req.GetFilter()),
 	}, nil
 }
 
@@ -198,10 +569,51 @@ func (t transformer) ToCoreUpdateCardRequest(req *api.UpdateCardRequest) (core.U
 	return core.UpdateCardRequest{
 		UserID:              req.GetUserID(),
 		CardID:              req.GetCardID(),
+		Type:                toCoreNoteType(req.GetType()),
 		WordInformationList: words,
+		Note:                toCoreNote(req.GetNote()),
+		Version:             req.GetVersion(),
 	}, nil
 }
 
+func toCoreNoteType(noteType string) entity.NoteType {
+	return entity.NoteType(strings.ToLower(strings.TrimSpace(noteType)))
+}
+
+func toCoreDirection(direction string) entity.ReviewDirection {
+	return entity.ReviewDirection(strings.ToLower(strings.TrimSpace(direction)))
+}
+
+func toCoreDirections(directions []string) []entity.ReviewDirection {
+	if len(directions) == 0 {
+		return nil
+	}
+	res := make([]entity.ReviewDirection, len(directions))
+	for i, direction := range directions {
+		res[i] = toCoreDirection(direction)
+	}
+	return res
+}
+
+func toAPIDirections(directions []entity.ReviewDirection) []string {
+	if len(directions) == 0 {
+		return nil
+	}
+	res := make([]string, len(directions))
+	for i, direction := range directions {
+		res[i] = string(direction)
+	}
+	return res
+}
+
+func toCoreNote(note *api.Note) entity.Note {
+	return entity.Note{
+		Text:        strings.TrimSpace(note.GetText()),
+		Answer:      strings.TrimSpace(note.GetAnswer()),
+		Explanation: strings.TrimSpace(note.GetExplanation()),
+	}
+}
+
 func (t transformer) toAPICards(cards []entity.Card) []*api.Card {
 	if len(cards) == 0 {
 		return nil
@@ -224,10 +636,52 @@ func (t transformer) ToAPICard(card entity.Card) *api.Card {
 		ConsecutiveCorrectAnswersNumber: card.ConsecutiveCorrectAnswersNumber,
 		NextDueDate:                     timestamppb.New(card.NextDueDate),
 		Learnt:                          card.Learnt,
+		Lapses:                          card.Lapses,
+		Leech:                           card.Leech,
+		Suspended:                       card.Suspended,
+		Tags:                            card.Tags,
+		Deck:                            card.Deck,
+		Type:                            string(card.NoteType()),
+		Direction:                       string(card.Direction),
+		Version:                         card.Version,
+	}
+	if !card.Note.IsZero() {
+		out.Note = &api.Note{
+			Text:        card.Note.Text,
+			Answer:      card.Note.Answer,
+			Explanation: card.Note.Explanation,
+		}
 	}
 	if !card.LearntAt.IsZero() {
 		out.LearntAt = timestamppb.New(card.LearntAt)
 	}
+	if !card.LastReviewedAt.IsZero() {
+		out.LastReviewedAt = timestamppb.New(card.LastReviewedAt)
+	}
+	if !card.BuriedUntil.IsZero() {
+		out.BuriedUntil = timestamppb.New(card.BuriedUntil)
+	}
+	if !card.CreatedAt.IsZero() {
+		out.CreatedAt = timestamppb.New(card.CreatedAt)
+	}
+	if !card.UpdatedAt.IsZero() {
+		out.UpdatedAt = timestamppb.New(card.UpdatedAt)
+	}
+	if !card.StartedLearningAt.IsZero() {
+		out.StartedLearningAt = timestamppb.New(card.StartedLearningAt)
+	}
+	if !card.MemoryState.IsZero() {
+		out.MemoryState = &api.MemoryState{
+			Stability:  card.MemoryState.Stability,
+			Difficulty: card.MemoryState.Difficulty,
+		}
+	}
+	if card.Learning.InSteps() {
+		out.LearningState = &api.LearningState{
+			Phase: string(card.Learning.Phase),
+			Step:  card.Learning.Step,
+		}
+	}
 	return out
 }
 
@@ -281,22 +735,26 @@ func (transformer) toCoreTranslation(t *api.Translation) (*entity.Translation, e
 
 func (t transformer) toAPIWordInformation(info entity.WordInformation) *api.WordInformation {
 	return &api.WordInformation{
-		Word:            info.Word,
-		Translation:     t.toAPITranslation(info.Translation),
-		Origin:          info.Origin,
-		Phonetics:       t.toAPIPhonetics(info.Phonetics),
-		Meanings:        t.toAPIMeanings(info.Meanings),
-		AudioByLanguage: info.AudioByLanguage,
+		Word:        info.Word,
+		Translation: t.toAPITranslation(info.Translation),
+		Origin:      info.Origin,
+		Phonetics:   t.toAPIPhonetics(info.Phonetics),
+		Meanings:    t.toAPIMeanings(info.Meanings),
+		Notes:       info.Notes,
+		ImageID:     info.ImageID,
+		AudioIDs:    info.AudioIDs,
 	}
 }
 
 func (t transformer) toCoreWordInformation(info *api.WordInformation) (entity.WordInformation, error) {
 	out := entity.WordInformation{
-		Word:            info.Word,
-		Origin:          info.Origin,
-		Phonetics:       t.toCorePhonetics(info.Phonetics),
-		Meanings:        t.toCoreMeanings(info.Meanings),
-		AudioByLanguage: info.GetAudioByLanguage(),
+		Word:      info.Word,
+		Origin:    info.Origin,
+		Phonetics: t.toCorePhonetics(info.Phonetics),
+		Meanings:  t.toCoreMeanings(info.Meanings),
+		Notes:     strings.TrimSpace(info.GetNotes()),
+		ImageID:   strings.TrimSpace(info.GetImageID()),
+		AudioIDs:  info.GetAudioIDs(),
 	}
 
 	if info.Translation != nil {
@@ -2,7 +2,9 @@
 package grpc_test
 
 import (
+	"bytes"
 	"reflect"
+	"slices"
 	"testing"
 	"time"
 
@@ -12,6 +14,8 @@ import (
 	"github.com/genvmoroz/lale/service/pkg/entity"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/text/language"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -92,6 +96,7 @@ func TestTransformerToAPICard(t *testing.T) {
 	t.Parallel()
 
 	nextDueDate := time.Now().Add(time.Hour)
+	lastReviewedAt := time.Now().Add(-time.Hour)
 
 	card := entity.Card{
 		ID:       "ID",
@@ -110,9 +115,9 @@ func TestTransformerToAPICard(t *testing.T) {
 					{Text: "Text_12"},
 				},
 
-				AudioByLanguage: map[string][]byte{
-					"en": []byte("audio_en_1"),
-					"uk": []byte("audio_uk_1"),
+				AudioIDs: map[string]string{
+					"en": "audio_en_1",
+					"uk": "audio_uk_1",
 				},
 				Meanings: []entity.Meaning{
 					{
@@ -163,9 +168,9 @@ func TestTransformerToAPICard(t *testing.T) {
 					{Text: "Text_22"},
 				},
 
-				AudioByLanguage: map[string][]byte{
-					"en": []byte("audio_en_2"),
-					"fr": []byte("audio_fr_2"),
+				AudioIDs: map[string]string{
+					"en": "audio_en_2",
+					"fr": "audio_fr_2",
 				},
 				Meanings: []entity.Meaning{
 					{
@@ -207,6 +212,17 @@ func TestTransformerToAPICard(t *testing.T) {
 		},
 		ConsecutiveCorrectAnswersNumber: 1,
 		NextDueDate:                     nextDueDate,
+		LastReviewedAt:                  lastReviewedAt,
+		BuriedUntil:                     nextDueDate,
+		Tags:                            []string{"travel"},
+		Deck:                            "Dune",
+		CreatedAt:                       lastReviewedAt.Add(-time.Hour),
+		UpdatedAt:                       lastReviewedAt,
+		StartedLearningAt:               lastReviewedAt.Add(-time.Minute),
+		MemoryState:                     entity.MemoryState{Stability: 3.7, Difficulty: 5.2},
+		Learning:                        entity.LearningState{Phase: entity.LearningPhaseRelearning, Step: 1},
+		Lapses:                          2,
+		Leech:                           true,
 		Learnt:                          false,
 	}
 
@@ -227,9 +243,9 @@ func TestTransformerToAPICard(t *testing.T) {
 					{Text: "Text_12"},
 				},
 
-				AudioByLanguage: map[string][]byte{
-					"en": []byte("audio_en_1"),
-					"uk": []byte("audio_uk_1"),
+				AudioIDs: map[string]string{
+					"en": "audio_en_1",
+					"uk": "audio_uk_1",
 				},
 				Meanings: []*api.Meaning{
 					{
@@ -280,9 +296,9 @@ func TestTransformerToAPICard(t *testing.T) {
 					{Text: "Text_22"},
 				},
 
-				AudioByLanguage: map[string][]byte{
-					"en": []byte("audio_en_2"),
-					"fr": []byte("audio_fr_2"),
+				AudioIDs: map[string]string{
+					"en": "audio_en_2",
+					"fr": "audio_fr_2",
 				},
 				Meanings: []*api.Meaning{
 					{
@@ -324,6 +340,18 @@ func TestTransformerToAPICard(t *testing.T) {
 		},
 		ConsecutiveCorrectAnswersNumber: 1,
 		NextDueDate:                     timestamppb.New(nextDueDate),
+		LastReviewedAt:                  timestamppb.New(lastReviewedAt),
+		BuriedUntil:                     timestamppb.New(nextDueDate),
+		Tags:                            []string{"travel"},
+		Deck:                            "Dune",
+		Type:                            "words",
+		CreatedAt:                       timestamppb.New(lastReviewedAt.Add(-time.Hour)),
+		UpdatedAt:                       timestamppb.New(lastReviewedAt),
+		StartedLearningAt:               timestamppb.New(lastReviewedAt.Add(-time.Minute)),
+		MemoryState:                     &api.MemoryState{Stability: 3.7, Difficulty: 5.2},
+		LearningState:                   &api.LearningState{Phase: "relearning", Step: 1},
+		Lapses:                          2,
+		Leech:                           true,
 		Learnt:                          false,
 	}
 
@@ -368,9 +396,9 @@ func TestTransformerToCoreCreateCardRequest(t *testing.T) {
 								{Text: "Text_12"},
 							},
 
-							AudioByLanguage: map[string][]byte{
-								"en": []byte("test_en_1"),
-								"de": []byte("test_de_1"),
+							AudioIDs: map[string]string{
+								"en": "test_en_1",
+								"de": "test_de_1",
 							},
 							Meanings: []*api.Meaning{
 								{
@@ -422,9 +450,9 @@ func TestTransformerToCoreCreateCardRequest(t *testing.T) {
 								{Text: "Text_22"},
 							},
 
-							AudioByLanguage: map[string][]byte{
-								"en": []byte("test_en_2"),
-								"es": []byte("test_es_2"),
+							AudioIDs: map[string]string{
+								"en": "test_en_2",
+								"es": "test_es_2",
 							},
 							Meanings: []*api.Meaning{
 								{
@@ -483,9 +511,9 @@ func TestTransformerToCoreCreateCardRequest(t *testing.T) {
 								{Text: "Text_12"},
 							},
 
-							AudioByLanguage: map[string][]byte{
-								"en": []byte("test_en_1"),
-								"de": []byte("test_de_1"),
+							AudioIDs: map[string]string{
+								"en": "test_en_1",
+								"de": "test_de_1",
 							},
 							Meanings: []entity.Meaning{
 								{
@@ -535,9 +563,9 @@ func TestTransformerToCoreCreateCardRequest(t *testing.T) {
 								{Text: "Text_21"},
 								{Text: "Text_22"},
 							},
-							AudioByLanguage: map[string][]byte{
-								"en": []byte("test_en_2"),
-								"es": []byte("test_es_2"),
+							AudioIDs: map[string]string{
+								"en": "test_en_2",
+								"es": "test_es_2",
 							},
 							Meanings: []entity.Meaning{
 								{
@@ -660,6 +688,42 @@ func TestTransformerToCoreCreateCardRequest(t *testing.T) {
 	}
 }
 
+func TestTransformerToCoreGetCardsForCramRequest(t *testing.T) {
+	t.Parallel()
+
+	tr := grpc.DefaultTransformer()
+
+	got, err := tr.ToCoreGetCardsForCramRequest(&api.GetCardsForCramRequest{
+		UserID:   "UserID",
+		Language: "en",
+		CardIDs:  []string{"a", "b"},
+		Order:    " Hardest ",
+		Limit:    10,
+	})
+	if err != nil {
+		t.Fatalf("ToCoreGetCardsForCramRequest() error = %v", err)
+	}
+	want := core.GetCardsForCramRequest{
+		UserID:   "UserID",
+		Language: language.English,
+		CardIDs:  []string{"a", "b"},
+		Order:    core.CramOrderHardest,
+		Limit:    10,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ToCoreGetCardsForCramRequest() = %v, want %v", got, want)
+	}
+
+	got, err = tr.ToCoreGetCardsForCramRequest(&api.GetCardsForCramRequest{UserID: "UserID"})
+	if err != nil || got.Language != language.Und {
+		t.Fatalf("ToCoreGetCardsForCramRequest() without language = %v, %v, want all languages", got, err)
+	}
+
+	if _, err = tr.ToCoreGetCardsForCramRequest(&api.GetCardsForCramRequest{Language: "invalid language"}); err == nil {
+		t.Fatal("ToCoreGetCardsForCramRequest() with invalid language error = nil, want error")
+	}
+}
+
 func TestTransformerToCoreGetCardsRequest(t *testing.T) {
 	t.Parallel()
 
@@ -691,10 +755,30 @@ func TestTransformerToCoreGetCardsRequest(t *testing.T) {
 				},
 			},
 		},
+		"with filter": {
+			input: input{
+				req: &api.GetCardsRequest{
+					UserID:   "UserID",
+					Language: language.English.String(),
+					Filter:   &api.CardFilter{Tags: []string{" travel ", "book:Dune"}, Deck: " Spanish trip "},
+				},
+			},
+			want: want{
+				req: core.GetCardsRequest{
+					UserID:   "UserID",
+					Language: language.English,
+					Filter:   entity.CardFilter{Tags: []string{"travel", "book:Dune"}, Deck: "Spanish trip"},
+				},
+			},
+		},
 		"nullable input": {
 			input: input{req: nil},
 			want:  want{req: core.GetCardsRequest{}},
 		},
+		"empty language": {
+			input: input{req: &api.GetCardsRequest{UserID: "UserID"}},
+			want:  want{req: core.GetCardsRequest{UserID: "UserID", Language: language.Und}},
+		},
 		"invalid language": {
 			input: input{req: &api.GetCardsRequest{
 				UserID:   "UserID",
@@ -857,6 +941,8 @@ func TestTransformerToAPIGetCardsResponse(t *testing.T) {
 							NextDueDate:                     time.Date(2022, 0o1, 0o2, 0o1, 0o0, 0o0, 0o0, time.UTC),
 						},
 					},
+					RemainingToday: 5,
+					Postponed:      3,
 				},
 			},
 			want: want{
@@ -868,6 +954,7 @@ func TestTransformerToAPIGetCardsResponse(t *testing.T) {
 							Id:       "ID_1",
 							UserID:   "UserID_1",
 							Language: language.English.String(),
+							Type:     "words",
 							WordInformationList: []*api.WordInformation{
 								{
 									Word: "Word_11",
@@ -925,6 +1012,7 @@ func TestTransformerToAPIGetCardsResponse(t *testing.T) {
 							Id:       "ID_2",
 							UserID:   "UserID_2",
 							Language: language.Ukrainian.String(),
+							Type:     "words",
 							WordInformationList: []*api.WordInformation{
 								{
 									Word: "Word_21",
@@ -979,6 +1067,8 @@ func TestTransformerToAPIGetCardsResponse(t *testing.T) {
 							NextDueDate:                     timestamppb.New(time.Date(2022, 0o1, 0o2, 0o1, 0o0, 0o0, 0o0, time.UTC)),
 						},
 					},
+					RemainingToday: 5,
+					Postponed:      3,
 				},
 			},
 		},
@@ -1020,7 +1110,9 @@ func TestTransformerToCoreUpdateCardPerformanceRequest(t *testing.T) {
 			req *api.UpdateCardPerformanceRequest
 		}
 		want struct {
-			req core.UpdateCardPerformanceRequest
+			req         core.UpdateCardPerformanceRequest
+			err         bool
+			errContains string
 		}
 	)
 	testcases := map[string]struct {
@@ -1029,21 +1121,34 @@ func TestTransformerToCoreUpdateCardPerformanceRequest(t *testing.T) {
 	}{
 		"nullable req": {
 			input: input{req: nil},
-			want:  want{req: core.UpdateCardPerformanceRequest{}},
+			want:  want{err: true, errContains: "performance is required"},
+		},
+		"missing performance": {
+			input: input{
+				req: &api.UpdateCardPerformanceRequest{
+					UserID: "UserID",
+					CardID: "CardID",
+				},
+			},
+			want: want{err: true, errContains: "performance is required"},
 		},
 		"positive case": {
 			input: input{
 				req: &api.UpdateCardPerformanceRequest{
-					UserID:         "UserID",
-					CardID:         "CardID",
-					IsInputCorrect: true,
+					UserID:       "UserID",
+					CardID:       "CardID",
+					Performance:  proto.Uint32(4),
+					TimeToAnswer: durationpb.New(7 * time.Second),
+					Direction:    " Recognition ",
 				},
 			},
 			want: want{
 				req: core.UpdateCardPerformanceRequest{
-					UserID:         "UserID",
-					CardID:         "CardID",
-					IsInputCorrect: true,
+					UserID:       "UserID",
+					CardID:       "CardID",
+					Performance:  4,
+					TimeToAnswer: 7 * time.Second,
+					Direction:    entity.DirectionRecognition,
 				},
 			},
 		},
@@ -1056,9 +1161,13 @@ func TestTransformerToCoreUpdateCardPerformanceRequest(t *testing.T) {
 			t.Parallel()
 
 			tr := grpc.DefaultTransformer()
-			if got := tr.ToCoreUpdateCardPerformanceRequest(testcase.input.req); !reflect.DeepEqual(got, testcase.want.req) {
-				t.Fatalf("ToCoreUpdateCardPerformanceRequest() = %v, want %v", got, testcase.want.req)
+			got, err := tr.ToCoreUpdateCardPerformanceRequest(testcase.input.req)
+
+			require.Equal(t, testcase.want.err, err != nil)
+			if testcase.want.err {
+				require.ErrorContains(t, err, testcase.want.errContains)
 			}
+			require.Equal(t, testcase.want.req, got)
 		})
 	}
 }
@@ -1090,6 +1199,20 @@ func TestTransformerToAPIUpdateCardPerformanceResponse(t *testing.T) {
 				},
 			},
 		},
+		"graduated": {
+			input: input{
+				resp: core.UpdateCardPerformanceResponse{
+					NextDueDate: time.Date(2022, 6, 24, 0, 0, 0, 0, time.UTC),
+					Graduated:   true,
+				},
+			},
+			want: want{
+				resp: &api.UpdateCardPerformanceResponse{
+					NextDueDate: timestamppb.New(time.Date(2022, 6, 24, 0, 0, 0, 0, time.UTC)),
+					Graduated:   true,
+				},
+			},
+		},
 	}
 	for name, testcase := range testcases {
 		name := name
@@ -1106,6 +1229,77 @@ func TestTransformerToAPIUpdateCardPerformanceResponse(t *testing.T) {
 	}
 }
 
+func TestTransformerToAPIGetReviewHistoryResponse(t *testing.T) {
+	t.Parallel()
+
+	reviewedAt := time.Date(2022, 2, 24, 0, 0, 0, 0, time.UTC)
+
+	type (
+		input struct {
+			resp core.GetReviewHistoryResponse
+		}
+		want struct {
+			resp *api.GetReviewHistoryResponse
+		}
+	)
+	testcases := map[string]struct {
+		input input
+		want  want
+	}{
+		"empty history": {
+			input: input{resp: core.GetReviewHistoryResponse{}},
+			want:  want{resp: &api.GetReviewHistoryResponse{Reviews: []*api.Review{}}},
+		},
+		"positive case": {
+			input: input{
+				resp: core.GetReviewHistoryResponse{
+					Reviews: []entity.Review{
+						{
+							ID:               "ReviewID",
+							UserID:           "UserID",
+							CardID:           "CardID",
+							ReviewedAt:       reviewedAt,
+							Performance:      4,
+							TimeToAnswer:     7 * time.Second,
+							PreviousInterval: 24 * time.Hour,
+							NewInterval:      6 * 24 * time.Hour,
+						},
+					},
+					NextPageToken: "50",
+				},
+			},
+			want: want{
+				resp: &api.GetReviewHistoryResponse{
+					Reviews: []*api.Review{
+						{
+							Id:               "ReviewID",
+							UserID:           "UserID",
+							CardID:           "CardID",
+							ReviewedAt:       timestamppb.New(reviewedAt),
+							Performance:      4,
+							TimeToAnswer:     durationpb.New(7 * time.Second),
+							PreviousInterval: durationpb.New(24 * time.Hour),
+							NewInterval:      durationpb.New(6 * 24 * time.Hour),
+							Direction:        "production",
+						},
+					},
+					NextPageToken: "50",
+				},
+			},
+		},
+	}
+	for name, testcase := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tr := grpc.DefaultTransformer()
+			if got := tr.ToAPIGetReviewHistoryResponse(testcase.input.resp); !reflect.DeepEqual(got, testcase.want.resp) {
+				t.Fatalf("ToAPIGetReviewHistoryResponse() = %v, want %v", got, testcase.want.resp)
+			}
+		})
+	}
+}
+
 func TestTransformerToCoreMarkCardLearntRequest(t *testing.T) {
 	t.Parallel()
 
@@ -1144,6 +1338,476 @@ func TestTransformerToCoreMarkCardLearntRequest(t *testing.T) {
 	}
 }
 
+func TestTransformerToCoreUpdateUserProfileRequest(t *testing.T) {
+	t.Parallel()
+
+	type (
+		input struct {
+			req *api.UpdateUserProfileRequest
+		}
+		want struct {
+			req         core.UpdateUserProfileRequest
+			err         bool
+			errContains string
+		}
+	)
+	testcases := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			name: "positive case",
+			input: input{
+				req: &api.UpdateUserProfileRequest{
+					UserID: "UserID",
+					Profile: &api.UserProfile{
+						TimeZone:   "Asia/Tokyo",
+						DayStart:   "04:30",
+						Directions: []string{" Listening "},
+					},
+				},
+			},
+			want: want{
+				req: core.UpdateUserProfileRequest{
+					UserID: "UserID",
+					Profile: entity.Profile{
+						TimeZone:   "Asia/Tokyo",
+						DayStart:   4*time.Hour + 30*time.Minute,
+						Directions: []entity.ReviewDirection{entity.DirectionListening},
+					},
+				},
+			},
+		},
+		{
+			name:  "nil req",
+			input: input{req: nil},
+			want:  want{req: core.UpdateUserProfileRequest{}},
+		},
+		{
+			name:  "default profile",
+			input: input{req: &api.UpdateUserProfileRequest{UserID: "UserID"}},
+			want:  want{req: core.UpdateUserProfileRequest{UserID: "UserID"}},
+		},
+		{
+			name: "invalid day start",
+			input: input{
+				req: &api.UpdateUserProfileRequest{
+					UserID:  "UserID",
+					Profile: &api.UserProfile{DayStart: "25:00"},
+				},
+			},
+			want: want{
+				err:         true,
+				errContains: "parse day start",
+			},
+		},
+	}
+
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tr := grpc.DefaultTransformer()
+			got, err := tr.ToCoreUpdateUserProfileRequest(tt.input.req)
+
+			require.Equal(t, tt.want.err, err != nil)
+			if tt.want.err {
+				require.ErrorContains(t, err, tt.want.errContains)
+			}
+			require.Equal(t, tt.want.req, got)
+		})
+	}
+}
+
+func TestTransformerToAPIUserProfile(t *testing.T) {
+	t.Parallel()
+
+	tr := grpc.DefaultTransformer()
+	got := tr.ToAPIUserProfile(entity.Profile{
+		TimeZone:   "Asia/Tokyo",
+		DayStart:   4*time.Hour + 30*time.Minute,
+		Directions: []entity.ReviewDirection{entity.DirectionRecognition},
+	})
+	want := &api.UserProfile{TimeZone: "Asia/Tokyo", DayStart: "04:30", Directions: []string{"recognition"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ToAPIUserProfile() = %v, want %v", got, want)
+	}
+}
+
+func TestTransformerToCoreUpdateGraduationPolicyRequest(t *testing.T) {
+	t.Parallel()
+
+	tr := grpc.DefaultTransformer()
+	got := tr.ToCoreUpdateGraduationPolicyRequest(&api.UpdateGraduationPolicyRequest{
+		UserID: "UserID",
+		Policy: &api.GraduationPolicy{CorrectAnswers: 8, Interval: durationpb.New(90 * 24 * time.Hour)},
+	})
+	want := core.UpdateGraduationPolicyRequest{
+		UserID: "UserID",
+		Policy: entity.GraduationPolicy{CorrectAnswers: 8, Interval: 90 * 24 * time.Hour},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ToCoreUpdateGraduationPolicyRequest() = %v, want %v", got, want)
+	}
+	if got = tr.ToCoreUpdateGraduationPolicyRequest(nil); !reflect.DeepEqual(got, core.UpdateGraduationPolicyRequest{}) {
+		t.Fatalf("ToCoreUpdateGraduationPolicyRequest(nil) = %v, want empty request", got)
+	}
+}
+
+func TestTransformerToCoreUpdateDailyLimitsRequest(t *testing.T) {
+	t.Parallel()
+
+	type (
+		input struct {
+			req *api.UpdateDailyLimitsRequest
+		}
+		want struct {
+			req         core.UpdateDailyLimitsRequest
+			err         bool
+			errContains string
+		}
+	)
+	testcases := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			name: "positive case",
+			input: input{
+				req: &api.UpdateDailyLimitsRequest{
+					UserID:   "UserID",
+					Language: "en",
+					Limits:   &api.DailyLimits{NewCards: 10, Reviews: 100},
+				},
+			},
+			want: want{
+				req: core.UpdateDailyLimitsRequest{
+					UserID:   "UserID",
+					Language: language.English,
+					Limits:   entity.DailyLimits{NewCards: 10, Reviews: 100},
+				},
+			},
+		},
+		{
+			name:  "nil req",
+			input: input{req: nil},
+			want:  want{req: core.UpdateDailyLimitsRequest{}},
+		},
+		{
+			name: "no new cards",
+			input: input{
+				req: &api.UpdateDailyLimitsRequest{
+					UserID:   "UserID",
+					Language: "en",
+					Limits:   &api.DailyLimits{Reviews: 100},
+				},
+			},
+			want: want{
+				req: core.UpdateDailyLimitsRequest{
+					UserID:   "UserID",
+					Language: language.English,
+					Limits:   entity.DailyLimits{Reviews: 100},
+				},
+			},
+		},
+		{
+			name: "invalid language",
+			input: input{
+				req: &api.UpdateDailyLimitsRequest{
+					UserID:   "UserID",
+					Language: "invalid language",
+				},
+			},
+			want: want{
+				err:         true,
+				errContains: "invalid language",
+			},
+		},
+	}
+
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tr := grpc.DefaultTransformer()
+			got, err := tr.ToCoreUpdateDailyLimitsRequest(tt.input.req)
+
+			require.Equal(t, tt.want.err, err != nil)
+			if tt.want.err {
+				require.ErrorContains(t, err, tt.want.errContains)
+			}
+			require.Equal(t, tt.want.req, got)
+		})
+	}
+}
+
+func TestTransformerToCoreResetCardRequest(t *testing.T) {
+	t.Parallel()
+
+	tr := grpc.DefaultTransformer()
+	got := tr.ToCoreResetCardRequest(&api.ResetCardRequest{UserID: "UserID", CardID: "CardID", Mode: " Forget "})
+	want := core.ResetCardRequest{UserID: "UserID", CardID: "CardID", Mode: core.ResetModeForget}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ToCoreResetCardRequest() = %v, want %v", got, want)
+	}
+	if got = tr.ToCoreResetCardRequest(nil); !reflect.DeepEqual(got, core.ResetCardRequest{}) {
+		t.Fatalf("ToCoreResetCardRequest(nil) = %v, want empty request", got)
+	}
+}
+
+func TestTransformerToCoreSuspendCardRequest(t *testing.T) {
+	t.Parallel()
+
+	tr := grpc.DefaultTransformer()
+	got := tr.ToCoreSuspendCardRequest(&api.SuspendCardRequest{UserID: "UserID", CardID: "CardID", Suspended: true})
+	want := core.SuspendCardRequest{UserID: "UserID", CardID: "CardID", Suspended: true}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ToCoreSuspendCardRequest() = %v, want %v", got, want)
+	}
+	if got = tr.ToCoreSuspendCardRequest(nil); !reflect.DeepEqual(got, core.SuspendCardRequest{}) {
+		t.Fatalf("ToCoreSuspendCardRequest(nil) = %v, want empty request", got)
+	}
+}
+
+func TestTransformerToCoreBuryCardRequest(t *testing.T) {
+	t.Parallel()
+
+	tr := grpc.DefaultTransformer()
+	got := tr.ToCoreBuryCardRequest(&api.BuryCardRequest{UserID: "UserID", CardID: "CardID", Buried: true})
+	want := core.BuryCardRequest{UserID: "UserID", CardID: "CardID", Buried: true}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ToCoreBuryCardRequest() = %v, want %v", got, want)
+	}
+	if got = tr.ToCoreBuryCardRequest(nil); !reflect.DeepEqual(got, core.BuryCardRequest{}) {
+		t.Fatalf("ToCoreBuryCardRequest(nil) = %v, want empty request", got)
+	}
+}
+
+func TestTransformerToCoreUpdateCardTagsRequest(t *testing.T) {
+	t.Parallel()
+
+	tr := grpc.DefaultTransformer()
+	got := tr.ToCoreUpdateCardTagsRequest(&api.UpdateCardTagsRequest{
+		UserID: "UserID",
+		CardID: "CardID",
+		Add:    []string{" work"},
+		Remove: []string{"travel "},
+	})
+	want := core.UpdateCardTagsRequest{UserID: "UserID", CardID: "CardID", Add: []string{"work"}, Remove: []string{"travel"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ToCoreUpdateCardTagsRequest() = %v, want %v", got, want)
+	}
+}
+
+func TestTransformerToCoreSetCardDeckRequest(t *testing.T) {
+	t.Parallel()
+
+	tr := grpc.DefaultTransformer()
+	got := tr.ToCoreSetCardDeckRequest(&api.SetCardDeckRequest{UserID: "UserID", CardID: "CardID", Deck: " Dune "})
+	want := core.SetCardDeckRequest{UserID: "UserID", CardID: "CardID", Deck: "Dune"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ToCoreSetCardDeckRequest() = %v, want %v", got, want)
+	}
+}
+
+func TestTransformerGetDecks(t *testing.T) {
+	t.Parallel()
+
+	tr := grpc.DefaultTransformer()
+	req, err := tr.ToCoreGetDecksRequest(&api.GetDecksRequest{UserID: "UserID"})
+	if err != nil || !reflect.DeepEqual(req, core.GetDecksRequest{UserID: "UserID", Language: language.Und}) {
+		t.Fatalf("ToCoreGetDecksRequest() without language = %v, %v, want all languages", req, err)
+	}
+	if _, err = tr.ToCoreGetDecksRequest(&api.GetDecksRequest{Language: "invalid language"}); err == nil {
+		t.Fatal("ToCoreGetDecksRequest() with invalid language error = nil, want error")
+	}
+
+	got := tr.ToAPIGetDecksResponse(core.GetDecksResponse{
+		UserID: "UserID",
+		Decks:  []core.Deck{{Name: "Dune", Cards: 2}},
+	})
+	want := &api.GetDecksResponse{UserID: "UserID", Decks: []*api.Deck{{Name: "Dune", Cards: 2}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ToAPIGetDecksResponse() = %v, want %v", got, want)
+	}
+}
+
+func TestTransformerNote(t *testing.T) {
+	t.Parallel()
+
+	tr := grpc.DefaultTransformer()
+	note := &api.Note{Text: " I {{have been}} here ", Explanation: " present perfect continuous "}
+
+	createReq, err := tr.ToCoreCreateCardRequest(&api.CreateCardRequest{
+		UserID:   "UserID",
+		Language: "en",
+		Type:     " Cloze ",
+		Note:     note,
+	})
+	require.NoError(t, err)
+	wantNote := entity.Note{Text: "I {{have been}} here", Explanation: "present perfect continuous"}
+	require.Equal(t, entity.NoteTypeCloze, createReq.Type)
+	require.Equal(t, wantNote, createReq.Note)
+
+	updateReq, err := tr.ToCoreUpdateCardRequest(&api.UpdateCardRequest{UserID: "UserID", CardID: "CardID", Type: "cloze", Note: note})
+	require.NoError(t, err)
+	require.Equal(t, entity.NoteTypeCloze, updateReq.Type)
+	require.Equal(t, wantNote, updateReq.Note)
+
+	card := tr.ToAPICard(entity.Card{Type: entity.NoteTypeCloze, Note: wantNote})
+	require.Equal(t, "cloze", card.GetType())
+	require.Equal(t, "I {{have been}} here", card.GetNote().GetText())
+	require.Empty(t, card.GetNote().GetAnswer())
+	require.Equal(t, "present perfect continuous", card.GetNote().GetExplanation())
+
+	require.Nil(t, tr.ToAPICard(entity.Card{}).GetNote(), "word card has no note")
+}
+
+func TestTransformerImage(t *testing.T) {
+	t.Parallel()
+
+	tr := grpc.DefaultTransformer()
+	upload := tr.ToCoreUploadImageRequest(&api.UploadImageRequest{UserID: "UserID", Data: []byte("data")})
+	if !reflect.DeepEqual(upload, core.UploadImageRequest{UserID: "UserID", Data: []byte("data")}) {
+		t.Fatalf("ToCoreUploadImageRequest() = %v", upload)
+	}
+
+	get := tr.ToCoreGetImageRequest(&api.GetImageRequest{UserID: "UserID", ImageID: " ImageID "})
+	if !reflect.DeepEqual(get, core.GetImageRequest{UserID: "UserID", ImageID: "ImageID"}) {
+		t.Fatalf("ToCoreGetImageRequest() = %v", get)
+	}
+
+	got := tr.ToAPIImage(entity.Image{ID: "ImageID", ContentType: "image/png", Data: []byte("data")})
+	want := &api.Image{Id: "ImageID", ContentType: "image/png", Data: []byte("data")}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ToAPIImage() = %v, want %v", got, want)
+	}
+}
+
+func TestTransformerAudio(t *testing.T) {
+	t.Parallel()
+
+	tr := grpc.DefaultTransformer()
+
+	req := tr.ToCoreGetAudioRequest(&api.GetAudioRequest{UserID: "UserID", AudioID: " ABC "})
+	if !reflect.DeepEqual(req, core.GetAudioRequest{UserID: "UserID", AudioID: "abc"}) {
+		t.Fatalf("ToCoreGetAudioRequest() = %v", req)
+	}
+
+	data := bytes.Repeat([]byte{1}, 64<<10+1)
+	chunks := tr.ToAPIAudioChunks(entity.Audio{ID: "abc", ContentType: "audio/mpeg", Data: data})
+	require.Len(t, chunks, 2)
+	require.Equal(t, "audio/mpeg", chunks[0].GetContentType())
+	require.Empty(t, chunks[1].GetContentType())
+	require.Equal(t, data, slices.Concat(chunks[0].GetData(), chunks[1].GetData()))
+
+	chunks = tr.ToAPIAudioChunks(entity.Audio{ID: "abc", ContentType: "audio/mpeg"})
+	require.Len(t, chunks, 1)
+	require.Equal(t, "audio/mpeg", chunks[0].GetContentType())
+}
+
+func TestTransformerWordNotesAndImage(t *testing.T) {
+	t.Parallel()
+
+	tr := grpc.DefaultTransformer()
+	req, err := tr.ToCoreCreateCardRequest(&api.CreateCardRequest{
+		UserID:   "UserID",
+		Language: "en",
+		WordInformationList: []*api.WordInformation{
+			{Word: "word", Notes: " rhymes with bird ", ImageID: " ImageID "},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, req.WordInformationList, 1)
+	require.Equal(t, "rhymes with bird", req.WordInformationList[0].Notes)
+	require.Equal(t, "ImageID", req.WordInformationList[0].ImageID)
+
+	card := tr.ToAPICard(entity.Card{WordInformationList: req.WordInformationList})
+	require.Len(t, card.GetWordInformationList(), 1)
+	require.Equal(t, "rhymes with bird", card.GetWordInformationList()[0].GetNotes())
+	require.Equal(t, "ImageID", card.GetWordInformationList()[0].GetImageID())
+}
+
+func TestTransformerToCoreUndoLastReviewRequest(t *testing.T) {
+	t.Parallel()
+
+	type (
+		input struct{ req *api.UndoLastReviewRequest }
+		want  struct{ req core.UndoLastReviewRequest }
+	)
+
+	tests := []struct {
+		name string
+		input
+		want
+	}{
+		{
+			name:  "nil request",
+			input: input{req: nil},
+			want:  want{req: core.UndoLastReviewRequest{}},
+		},
+		{
+			name:  "last review of the user",
+			input: input{req: &api.UndoLastReviewRequest{UserID: "UserID"}},
+			want:  want{req: core.UndoLastReviewRequest{UserID: "UserID"}},
+		},
+		{
+			name:  "last review of the card",
+			input: input{req: &api.UndoLastReviewRequest{UserID: "UserID", CardID: "CardID"}},
+			want:  want{req: core.UndoLastReviewRequest{UserID: "UserID", CardID: "CardID"}},
+		},
+	}
+
+	tr := grpc.DefaultTransformer()
+
+	for _, testcase := range tests {
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tr.ToCoreUndoLastReviewRequest(testcase.input.req); !reflect.DeepEqual(got, testcase.want.req) {
+				t.Fatalf("ToCoreUndoLastReviewRequest() = %v, want %v", got, testcase.want.req)
+			}
+		})
+	}
+}
+
+func TestTransformerToCoreGetCardRequest(t *testing.T) {
+	t.Parallel()
+
+	type (
+		input struct{ req *api.GetCardRequest }
+		want  struct{ req core.GetCardRequest }
+	)
+	testcases := map[string]struct {
+		input input
+		want  want
+	}{
+		"nullable req": {
+			input: input{req: nil},
+			want:  want{req: core.GetCardRequest{}},
+		},
+		"positive case": {
+			input: input{req: &api.GetCardRequest{UserID: "UserID", CardID: "CardID"}},
+			want:  want{req: core.GetCardRequest{UserID: "UserID", CardID: "CardID"}},
+		},
+	}
+	for name, testcase := range testcases {
+		name := name
+		testcase := testcase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tr := grpc.DefaultTransformer()
+			if got := tr.ToCoreGetCardRequest(testcase.input.req); !reflect.DeepEqual(got, testcase.want.req) {
+				t.Fatalf("ToCoreGetCardRequest() = %v, want %v", got, testcase.want.req)
+			}
+		})
+	}
+}
+
 func TestTransformerToCoreDeleteCardRequest(t *testing.T) {
 	t.Parallel()
 
@@ -1179,6 +1843,49 @@ func TestTransformerToCoreDeleteCardRequest(t *testing.T) {
 	}
 }
 
+func TestTransformerToCoreOptimiseSchedulerParametersRequest(t *testing.T) {
+	t.Parallel()
+
+	type (
+		input struct {
+			req *api.OptimiseSchedulerParametersRequest
+		}
+		want struct {
+			req core.OptimiseSchedulerParametersRequest
+		}
+	)
+
+	tests := []struct {
+		name string
+		input
+		want
+	}{
+		{
+			name:  "nil request",
+			input: input{req: nil},
+			want:  want{req: core.OptimiseSchedulerParametersRequest{}},
+		},
+		{
+			name:  "happy path",
+			input: input{req: &api.OptimiseSchedulerParametersRequest{UserID: "UserID", TargetRetention: 0.9}},
+			want:  want{req: core.OptimiseSchedulerParametersRequest{UserID: "UserID", TargetRetention: 0.9}},
+		},
+	}
+
+	tr := grpc.DefaultTransformer()
+
+	for _, testcase := range tests {
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tr.ToCoreOptimiseSchedulerParametersRequest(testcase.input.req)
+			if !reflect.DeepEqual(got, testcase.want.req) {
+				t.Fatalf("ToCoreOptimiseSchedulerParametersRequest() = %v, want %v", got, testcase.want.req)
+			}
+		})
+	}
+}
+
 func Test_transformer_ToCorePromptCardRequest(t *testing.T) {
 	type args struct {
 		req *api.PromptCardRequest
@@ -1239,11 +1946,22 @@ func TestTransformerToCoreUpdateCardRequest(t *testing.T) {
 		want    core.UpdateCardRequest
 		wantErr bool
 	}{
-		// TODO: Add test cases.
+		{
+			name: "version",
+			args: args{
+				req: &api.UpdateCardRequest{UserID: "UserID", CardID: "CardID", Type: " Phrase ", Version: 3},
+			},
+			want: core.UpdateCardRequest{
+				UserID:  "UserID",
+				CardID:  "CardID",
+				Type:    entity.NoteTypePhrase,
+				Version: 3,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t1 *testing.T) {
-			t.Parallel()
+			t1.Parallel()
 
 			tr := grpc.DefaultTransformer()
 			got, err := tr.ToCoreUpdateCardRequest(tt.args.req)
@@ -1257,3 +1975,21 @@ func TestTransformerToCoreUpdateCardRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestTransformerCardDirection(t *testing.T) {
+	t.Parallel()
+
+	tr := grpc.DefaultTransformer()
+	got := tr.ToAPICard(entity.Card{ID: "CardID", Direction: entity.DirectionListening})
+	require.Equal(t, "listening", got.GetDirection())
+
+	got = tr.ToAPICard(entity.Card{ID: "CardID"})
+	require.Empty(t, got.GetDirection())
+}
+
+func TestTransformerCardVersion(t *testing.T) {
+	t.Parallel()
+
+	got := grpc.DefaultTransformer().ToAPICard(entity.Card{ID: "CardID", Version: 7})
+	require.Equal(t, uint64(7), got.GetVersion())
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/genvmoroz/lale/service/internal/algo"
 	"github.com/genvmoroz/lale/service/internal/infrastructure"
 	"github.com/genvmoroz/lale/service/internal/repo/card"
 	"github.com/genvmoroz/lale/service/pkg/openai"
@@ -20,8 +21,35 @@ type (
 		Infra      infrastructure.Config
 		OpenAI     openai.Config
 		CardRepo   card.Config
+		UserRepo   UserRepoConfig
+		ReviewRepo ReviewRepoConfig
 		Dictionary DictionaryConfig
 		Google     google.Config
+		Scheduler  algo.Config
+		BlobStore  BlobStoreConfig
+		AudioStore AudioStoreConfig
+	}
+
+	// BlobStoreConfig configures the local directory the images are stored in.
+	BlobStoreConfig struct {
+		Path string `envconfig:"APP_BLOB_STORE_PATH" default:"blobs"`
+	}
+
+	// AudioStoreConfig configures where the audio of the words is stored: in a GridFS bucket of the card repo
+	// database or in a local directory.
+	AudioStoreConfig struct {
+		Type   string `envconfig:"APP_AUDIO_STORE_TYPE" default:"gridfs"`
+		Path   string `envconfig:"APP_AUDIO_STORE_PATH" default:"audio"`
+		Bucket string `envconfig:"APP_AUDIO_STORE_BUCKET" default:"audio"`
+	}
+
+	// UserRepoConfig and ReviewRepoConfig configure the collections stored in the card repo database.
+	UserRepoConfig struct {
+		Collection string `envconfig:"APP_MONGO_USER_COLLECTION" default:"users"`
+	}
+
+	ReviewRepoConfig struct {
+		Collection string `envconfig:"APP_MONGO_REVIEW_COLLECTION" default:"reviews"`
 	}
 
 	DictionaryConfig struct {
@@ -32,6 +60,12 @@ type (
 	}
 )
 
+// Audio store types of AudioStoreConfig.
+const (
+	AudioStoreGridFS = "gridfs"
+	AudioStoreLocal  = "local"
+)
+
 const appPrefix = "APP"
 
 func FromEnv() (Config, error) {
@@ -0,0 +1,118 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GridFSStore keeps the objects as files of a GridFS bucket, the keys are the IDs of the files. The keys are
+// expected to identify the content, e.g. the content hashes of the audio, so a file is never replaced.
+type GridFSStore struct {
+	bucket *gridfs.Bucket
+}
+
+// NewGridFSStore creates the store on top of the bucket of the database.
+func NewGridFSStore(db *mongo.Database, bucket string) (*GridFSStore, error) {
+	b, err := gridfs.NewBucket(db, options.GridFSBucket().SetName(bucket))
+	if err != nil {
+		return nil, fmt.Errorf("create bucket: %w", err)
+	}
+
+	return &GridFSStore{bucket: b}, nil
+}
+
+// Put stores the object under the key, the object already stored under the key is kept as it is.
+func (s *GridFSStore) Put(ctx context.Context, key string, data []byte) error {
+	exists, err := s.exists(ctx, key)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	upload, err := s.bucket.OpenUploadStreamWithID(key, key)
+	if err != nil {
+		return fmt.Errorf("open upload stream: %w", err)
+	}
+	if err = upload.SetWriteDeadline(deadline(ctx)); err != nil {
+		_ = upload.Close()
+		return fmt.Errorf("set write deadline: %w", err)
+	}
+	// the duplicate key is the same object stored by a concurrent Put, it's not aborted as the abort deletes
+	// the chunks of the file, the ones stored by the concurrent Put as well
+	if _, err = io.Copy(upload, bytes.NewReader(data)); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil
+		}
+		_ = upload.Abort()
+		return fmt.Errorf("write: %w", err)
+	}
+	if err = upload.Close(); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil
+		}
+		return fmt.Errorf("close: %w", err)
+	}
+
+	return nil
+}
+
+// exists reports whether a file is stored under the key, the file is created after its chunks, so the file
+// found is complete.
+func (s *GridFSStore) exists(ctx context.Context, key string) (bool, error) {
+	n, err := s.bucket.GetFilesCollection().CountDocuments(ctx, bson.M{"_id": key}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("count files: %w", err)
+	}
+
+	return n != 0, nil
+}
+
+// Get returns the object stored under the key, the second value reports whether the object has been found.
+func (s *GridFSStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	download, err := s.bucket.OpenDownloadStream(key)
+	switch {
+	case errors.Is(err, gridfs.ErrFileNotFound):
+		return nil, false, nil
+	case err != nil:
+		return nil, false, fmt.Errorf("open download stream: %w", err)
+	}
+	defer func() {
+		_ = download.Close()
+	}()
+
+	if err = download.SetReadDeadline(deadline(ctx)); err != nil {
+		return nil, false, fmt.Errorf("set read deadline: %w", err)
+	}
+	data, err := io.ReadAll(download)
+	if err != nil {
+		return nil, false, fmt.Errorf("read: %w", err)
+	}
+
+	return data, true, nil
+}
+
+// Delete removes the object stored under the key, a missing object is not an error.
+func (s *GridFSStore) Delete(ctx context.Context, key string) error {
+	if err := s.bucket.DeleteContext(ctx, key); err != nil && !errors.Is(err, gridfs.ErrFileNotFound) {
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	return nil
+}
+
+// deadline returns the deadline of the context, the zero time means no deadline to the GridFS streams.
+func deadline(ctx context.Context) time.Time {
+	t, _ := ctx.Deadline()
+	return t
+}
@@ -0,0 +1,109 @@
+// Package blob provides stores of binary objects, e.g. the images attached to the words
+// and the audio of the words.
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// LocalStore keeps the objects as files under the root directory, the keys are the paths of the files
+// relative to the root.
+type LocalStore struct {
+	root string
+}
+
+const (
+	dirPerm  = 0o750
+	filePerm = 0o640
+)
+
+// NewLocalStore creates the store, the root directory is created if it doesn't exist.
+func NewLocalStore(root string) (*LocalStore, error) {
+	if err := os.MkdirAll(root, dirPerm); err != nil {
+		return nil, fmt.Errorf("create root directory: %w", err)
+	}
+
+	return &LocalStore{root: root}, nil
+}
+
+// Put stores the object under the key replacing the one stored before.
+func (s *LocalStore) Put(_ context.Context, key string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), dirPerm); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+
+	// the object is written aside and renamed, so a half-written object is never read
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("close: %w", err)
+	}
+	if err = os.Chmod(tmp.Name(), filePerm); err != nil {
+		return fmt.Errorf("chmod: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("rename: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the object stored under the key, the second value reports whether the object has been found.
+func (s *LocalStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, false, nil
+	case err != nil:
+		return nil, false, fmt.Errorf("read: %w", err)
+	default:
+		return data, true, nil
+	}
+}
+
+// Delete removes the object stored under the key, a missing object is not an error.
+func (s *LocalStore) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("remove: %w", err)
+	}
+
+	return nil
+}
+
+// path returns the path of the object, the keys pointing outside the root are rejected.
+func (s *LocalStore) path(key string) (string, error) {
+	if !filepath.IsLocal(key) {
+		return "", fmt.Errorf("invalid key [%s]", key)
+	}
+
+	return filepath.Join(s.root, key), nil
+}
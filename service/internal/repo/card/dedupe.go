@@ -0,0 +1,171 @@
+package card
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/genvmoroz/lale/service/pkg/entity"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Merge is a card the cards sharing its words have been merged into.
+type Merge struct {
+	Kept   entity.Card
+	Merged []entity.Card
+}
+
+// DedupeWords merges the cards of a user in a language sharing a word, ignoring the case, into one card, so
+// the unique word index can be created, and returns the merges. The card studied the longest is kept, it
+// takes the words and the tags of the others, which are deleted. Nothing is changed on a dry run.
+func (r *Repo) DedupeWords(ctx context.Context, dryRun bool) ([]Merge, error) {
+	cardsCollection := r.client.
+		Database(r.database).
+		Collection(r.collection)
+
+	withWords := bson.M{wordField: bson.M{"$exists": true}}
+	userIDs, err := cardsCollection.Distinct(ctx, userIDField, withWords)
+	if err != nil {
+		return nil, fmt.Errorf("distinct user IDs: %w", err)
+	}
+
+	var merges []Merge
+	for _, value := range userIDs {
+		userID, ok := value.(string)
+		if !ok {
+			return merges, fmt.Errorf("unexpected user ID [%v]", value)
+		}
+
+		cards, err := r.find(ctx, bson.M{userIDField: userID, wordField: bson.M{"$exists": true}})
+		if err != nil {
+			return merges, fmt.Errorf("find cards of user %s: %w", userID, err)
+		}
+
+		for _, merge := range mergeDuplicateWords(cards) {
+			if !dryRun {
+				if err = r.applyMerge(ctx, merge); err != nil {
+					return merges, err
+				}
+			}
+			merges = append(merges, merge)
+		}
+	}
+
+	return merges, nil
+}
+
+// applyMerge saves the kept card before the merged ones are deleted, so a failure never loses a word.
+func (r *Repo) applyMerge(ctx context.Context, merge Merge) error {
+	cardsCollection := r.client.
+		Database(r.database).
+		Collection(r.collection)
+
+	if err := r.saveCard(ctx, cardsCollection, merge.Kept); err != nil {
+		return fmt.Errorf("save card %s: %w", merge.Kept.ID, err)
+	}
+	for _, card := range merge.Merged {
+		if err := r.DeleteCard(ctx, card.ID); err != nil {
+			return fmt.Errorf("delete card %s: %w", card.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// mergeDuplicateWords joins the cards sharing a word in a language, transitively, and merges every group
+// of the joined cards into the card of the group studied the longest.
+func mergeDuplicateWords(cards []entity.Card) []Merge {
+	// parent points to the card the card is joined to, the card pointing to itself is the root of the group
+	parent := make([]int, len(cards))
+	for i := range parent {
+		parent[i] = i
+	}
+	root := func(i int) int {
+		for parent[i] != i {
+			i = parent[i]
+		}
+		return i
+	}
+
+	owners := make(map[string]int)
+	for i, card := range cards {
+		for _, word := range card.WordInformationList {
+			key := card.Language.String() + "/" + normaliseWord(word.Word)
+			if j, ok := owners[key]; ok {
+				parent[root(i)] = root(j)
+				continue
+			}
+			owners[key] = i
+		}
+	}
+
+	var roots []int
+	groups := make(map[int][]entity.Card)
+	for i := range cards {
+		r := root(i)
+		if _, ok := groups[r]; !ok {
+			roots = append(roots, r)
+		}
+		groups[r] = append(groups[r], cards[i])
+	}
+
+	var merges []Merge
+	for _, r := range roots {
+		group := groups[r]
+		if len(group) < 2 {
+			continue
+		}
+		slices.SortStableFunc(group, studiedLongerFirst)
+		merges = append(merges, merge(group[0], group[1:]))
+	}
+
+	return merges
+}
+
+// merge adds the words the kept card doesn't have and the tags of the merged cards to the kept card,
+// the kept card without a deck takes the deck of the first merged card in one.
+func merge(kept entity.Card, merged []entity.Card) Merge {
+	kept.WordInformationList = slices.Clone(kept.WordInformationList)
+	kept.Tags = slices.Clone(kept.Tags)
+
+	words := make(map[string]struct{}, len(kept.WordInformationList))
+	for _, word := range kept.WordInformationList {
+		words[normaliseWord(word.Word)] = struct{}{}
+	}
+
+	for _, card := range merged {
+		for _, word := range card.WordInformationList {
+			if _, ok := words[normaliseWord(word.Word)]; ok {
+				continue
+			}
+			words[normaliseWord(word.Word)] = struct{}{}
+			kept.WordInformationList = append(kept.WordInformationList, word)
+		}
+		kept.AddTags(card.Tags...)
+		kept.Deck = cmp.Or(kept.Deck, card.Deck)
+	}
+	kept.UpdatedAt = time.Now().UTC()
+
+	return Merge{Kept: kept, Merged: merged}
+}
+
+// studiedLongerFirst orders the cards by the time they have started being learnt, the new cards last,
+// and then by the time they have been created.
+func studiedLongerFirst(a, b entity.Card) int {
+	if a.StartedLearningAt.IsZero() != b.StartedLearningAt.IsZero() {
+		if a.StartedLearningAt.IsZero() {
+			return 1
+		}
+		return -1
+	}
+
+	return cmp.Or(a.StartedLearningAt.Compare(b.StartedLearningAt), a.CreatedAt.Compare(b.CreatedAt))
+}
+
+// normaliseWord returns the word the way the unique word index compares it, ignoring the case.
+func normaliseWord(word string) string {
+	return strings.ToLower(strings.TrimSpace(word))
+}
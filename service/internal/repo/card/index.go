@@ -0,0 +1,74 @@
+package card
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	mongo "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EnsureIndexes creates the indexes the cards are queried by, the existing indexes are left untouched.
+// The unique word index can't be created over the duplicate words stored before it, a warning asking to merge
+// them by cmd/dedupe-card-words is logged then and the cards are served without the index, the duplicate words
+// are still rejected by the check of the service before the cards are saved.
+func (r *Repo) EnsureIndexes(ctx context.Context) error {
+	cardsCollection := r.client.
+		Database(r.database).
+		Collection(r.collection)
+
+	models := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: idField, Value: 1}},
+			Options: options.Index().SetName("id"),
+		},
+		{
+			Keys:    bson.D{{Key: userIDField, Value: 1}, {Key: idField, Value: 1}},
+			Options: options.Index().SetName("userid_id"),
+		},
+		{
+			// the new and the due cards of a language
+			Keys: bson.D{
+				{Key: userIDField, Value: 1},
+				{Key: languageField, Value: 1},
+				{Key: nextDueDateField, Value: 1},
+			},
+			Options: options.Index().SetName("userid_language_nextduedate"),
+		},
+		{
+			Keys:    bson.D{{Key: userIDField, Value: 1}, {Key: wordField, Value: 1}},
+			Options: options.Index().SetName("userid_word").SetCollation(wordCollation),
+		},
+	}
+	if _, err := cardsCollection.Indexes().CreateMany(ctx, models); err != nil {
+		return fmt.Errorf("create indexes: %w", err)
+	}
+
+	// the unique word index is created on its own, so the indexes above are in place even if it fails
+	unique := mongo.IndexModel{
+		// a word is in one card of the user in a language at most, the case is ignored by the collation;
+		// the cards with no words, e.g. the phrase cards, are left out
+		Keys: bson.D{
+			{Key: userIDField, Value: 1},
+			{Key: languageField, Value: 1},
+			{Key: wordField, Value: 1},
+		},
+		Options: options.Index().
+			SetName("userid_language_word_unique").
+			SetCollation(wordCollation).
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{wordField: bson.M{"$exists": true}}),
+	}
+	_, err := cardsCollection.Indexes().CreateOne(ctx, unique)
+	switch {
+	case mongo.IsDuplicateKeyError(err):
+		logrus.Warnf("unique word index not created, run cmd/dedupe-card-words to merge the duplicate words: %s",
+			err.Error())
+	case err != nil:
+		return fmt.Errorf("create unique word index: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package card
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// BSON field names of the card timestamps.
+const (
+	createdAtField         = "createdat"
+	updatedAtField         = "updatedat"
+	startedLearningAtField = "startedlearningat"
+)
+
+// BSON field names of the words' audio.
+const (
+	wordsField           = "wordinformationlist"
+	audioByLanguageField = "audiobylanguage"
+	audioIDsField        = "audioids"
+)
+
+// timestampsDoc is the projection of a card document on what its timestamps are derived from.
+type timestampsDoc struct {
+	ObjectID       primitive.ObjectID `bson:"_id"`
+	ID             string             `bson:"id"`
+	NextDueDate    time.Time          `bson:"nextduedate"`
+	LastReviewedAt time.Time          `bson:"lastreviewedat"`
+	LearntAt       time.Time          `bson:"learntat"`
+}
+
+// BackfillTimestamps sets the created, updated and started learning times of the cards stored before
+// the timestamps have been tracked and returns the number of cards updated, firstReviews are the times
+// the cards have been reviewed the first time by card ID. The cards already having the timestamps are
+// left untouched, so the backfill can be rerun.
+func (r *Repo) BackfillTimestamps(ctx context.Context, firstReviews map[string]time.Time) (int, error) {
+	cardsCollection := r.client.
+		Database(r.database).
+		Collection(r.collection)
+
+	cursor, err := cardsCollection.Find(ctx, bson.M{createdAtField: bson.M{"$exists": false}})
+	if err != nil {
+		return 0, fmt.Errorf("find: %w", err)
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			logrus.Errorf("failed to close cursor: %s", closeErr.Error())
+		}
+	}()
+
+	var updated int
+	for cursor.Next(ctx) {
+		var doc timestampsDoc
+		if err = cursor.Decode(&doc); err != nil {
+			return updated, fmt.Errorf("decode: %w", err)
+		}
+
+		createdAt, updatedAt, startedLearningAt := deriveTimestamps(doc, firstReviews[doc.ID])
+		if _, err = cardsCollection.UpdateByID(ctx, doc.ObjectID, bson.M{"$set": bson.M{
+			createdAtField:         createdAt,
+			updatedAtField:         updatedAt,
+			startedLearningAtField: startedLearningAt,
+		}}); err != nil {
+			return updated, fmt.Errorf("update card %s: %w", doc.ID, err)
+		}
+		updated++
+	}
+	if err = cursor.Err(); err != nil {
+		return updated, fmt.Errorf("iterate: %w", err)
+	}
+
+	return updated, nil
+}
+
+// audioDoc is the projection of a card document on the audio embedded in its words.
+type audioDoc struct {
+	ObjectID primitive.ObjectID `bson:"_id"`
+	ID       string             `bson:"id"`
+	Words    []struct {
+		AudioByLanguage map[string][]byte `bson:"audiobylanguage"`
+	} `bson:"wordinformationlist"`
+}
+
+// MoveAudio moves the audio embedded in the words of the cards to the audio store and returns the number
+// of cards updated, store puts the audio to the store and returns its ID. The words refer to the audio
+// by the IDs afterwards. The cards are updated one by one, so the move can be rerun after a failure.
+func (r *Repo) MoveAudio(ctx context.Context, store func(ctx context.Context, data []byte) (string, error)) (int, error) {
+	cardsCollection := r.client.
+		Database(r.database).
+		Collection(r.collection)
+
+	cursor, err := cardsCollection.Find(
+		ctx,
+		bson.M{wordsField + "." + audioByLanguageField: bson.M{"$type": "object"}},
+		options.Find().SetProjection(bson.M{idField: 1, wordsField + "." + audioByLanguageField: 1}),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("find: %w", err)
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			logrus.Errorf("failed to close cursor: %s", closeErr.Error())
+		}
+	}()
+
+	var updated int
+	for cursor.Next(ctx) {
+		var doc audioDoc
+		if err = cursor.Decode(&doc); err != nil {
+			return updated, fmt.Errorf("decode: %w", err)
+		}
+
+		set, unset := bson.M{}, bson.M{}
+		for i, word := range doc.Words {
+			prefix := fmt.Sprintf("%s.%d.", wordsField, i)
+			for lang, data := range word.AudioByLanguage {
+				var id string
+				if id, err = store(ctx, data); err != nil {
+					return updated, fmt.Errorf("store audio of card %s: %w", doc.ID, err)
+				}
+				set[prefix+audioIDsField+"."+lang] = id
+			}
+			unset[prefix+audioByLanguageField] = ""
+		}
+
+		update := bson.M{"$unset": unset}
+		if len(set) != 0 {
+			update["$set"] = set
+		}
+		if _, err = cardsCollection.UpdateByID(ctx, doc.ObjectID, update); err != nil {
+			return updated, fmt.Errorf("update card %s: %w", doc.ID, err)
+		}
+		updated++
+	}
+	if err = cursor.Err(); err != nil {
+		return updated, fmt.Errorf("iterate: %w", err)
+	}
+
+	return updated, nil
+}
+
+// deriveTimestamps estimates the timestamps of a card from the time its document has been inserted,
+// kept in the object ID, and its schedule. The card has started being learnt with its first review,
+// with the last one if the review log doesn't go back that far.
+func deriveTimestamps(doc timestampsDoc, firstReview time.Time) (time.Time, time.Time, time.Time) {
+	startedLearningAt := firstReview
+	if startedLearningAt.IsZero() && !doc.NextDueDate.IsZero() {
+		startedLearningAt = doc.LastReviewedAt
+	}
+
+	createdAt := doc.ObjectID.Timestamp().UTC()
+	if !startedLearningAt.IsZero() && startedLearningAt.Before(createdAt) {
+		createdAt = startedLearningAt
+	}
+
+	updatedAt := createdAt
+	for _, t := range []time.Time{doc.LastReviewedAt, doc.LearntAt} {
+		if t.After(updatedAt) {
+			updatedAt = t
+		}
+	}
+
+	return createdAt, updatedAt, startedLearningAt
+}
@@ -0,0 +1,310 @@
+package card
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+	"unicode/utf8"
+
+	"github.com/genvmoroz/lale/service/pkg/entity"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/text/language"
+)
+
+// BSON field names the cards are queried by.
+const (
+	idField         = "id"
+	languageField   = "language"
+	learntField     = "learnt"
+	suspendedField  = "suspended"
+	leechField      = "leech"
+	deckField       = "deck"
+	tagsField       = "tags"
+	typeField       = "type"
+	wordField       = "wordinformationlist.word"
+	noteTextField   = "note.text"
+	directionsField = "directions"
+)
+
+// deckDoc is the projection of a card document on its deck.
+type deckDoc struct {
+	Deck string `bson:"deck"`
+}
+
+// wordCollation matches the words ignoring the case, the word index is built with it.
+var wordCollation = &options.Collation{Locale: "en", Strength: 2}
+
+// GetCardByID returns the user's card, the second value reports whether the card has been found.
+func (r *Repo) GetCardByID(ctx context.Context, userID, cardID string) (entity.Card, bool, error) {
+	if !utf8.ValidString(userID) {
+		return entity.Card{}, false, fmt.Errorf("userID [%s] is invalid utf8 string", userID)
+	}
+	if !utf8.ValidString(cardID) {
+		return entity.Card{}, false, fmt.Errorf("cardID [%s] is invalid utf8 string", cardID)
+	}
+
+	cards, err := r.find(ctx, bson.M{userIDField: userID, idField: cardID}, options.Find().SetLimit(1))
+	if err != nil {
+		return entity.Card{}, false, err
+	}
+	if len(cards) == 0 {
+		return entity.Card{}, false, nil
+	}
+
+	return cards[0], true, nil
+}
+
+// FindByWord returns the user's cards having the word, or the phrase cards of the phrase, in any language.
+// The word is matched ignoring the case.
+func (r *Repo) FindByWord(ctx context.Context, userID, word string) ([]entity.Card, error) {
+	if !utf8.ValidString(userID) {
+		return nil, fmt.Errorf("userID [%s] is invalid utf8 string", userID)
+	}
+
+	filter := bson.M{
+		userIDField: userID,
+		"$or": bson.A{
+			bson.M{wordField: word},
+			bson.M{typeField: string(entity.NoteTypePhrase), noteTextField: word},
+		},
+	}
+
+	return r.find(ctx, filter, options.Find().SetCollation(wordCollation))
+}
+
+// GetCards returns the user's cards in the language in the deck and having all the tags of the filter,
+// language.Und returns the cards in all languages.
+func (r *Repo) GetCards(
+	ctx context.Context,
+	userID string,
+	lang language.Tag,
+	filter entity.CardFilter,
+) ([]entity.Card, error) {
+	if !utf8.ValidString(userID) {
+		return nil, fmt.Errorf("userID [%s] is invalid utf8 string", userID)
+	}
+
+	return r.find(ctx, matching(inLanguage(userID, lang), filter))
+}
+
+// GetCardsByIDs returns the user's cards in the language having the IDs, empty cardIDs return all the cards
+// in the language, language.Und returns the cards in all languages.
+func (r *Repo) GetCardsByIDs(
+	ctx context.Context,
+	userID string,
+	lang language.Tag,
+	cardIDs []string,
+) ([]entity.Card, error) {
+	if !utf8.ValidString(userID) {
+		return nil, fmt.Errorf("userID [%s] is invalid utf8 string", userID)
+	}
+
+	filter := inLanguage(userID, lang)
+	if len(cardIDs) != 0 {
+		filter[idField] = bson.M{"$in": cardIDs}
+	}
+
+	return r.find(ctx, filter)
+}
+
+// GetScheduledCards returns the user's cards in the language answered at least once and not learnt,
+// the ones in the deck and having all the tags of the filter.
+func (r *Repo) GetScheduledCards(
+	ctx context.Context,
+	userID string,
+	lang language.Tag,
+	filter entity.CardFilter,
+) ([]entity.Card, error) {
+	if !utf8.ValidString(userID) {
+		return nil, fmt.Errorf("userID [%s] is invalid utf8 string", userID)
+	}
+
+	query := matching(inLanguage(userID, lang), filter)
+	query[learntField] = bson.M{"$ne": true}
+	query[nextDueDateField] = bson.M{"$gt": time.Time{}}
+
+	return r.find(ctx, query)
+}
+
+// GetNewCards returns the user's cards in the language never answered yet, the learnt and the suspended
+// cards are left out.
+func (r *Repo) GetNewCards(ctx context.Context, userID string, lang language.Tag) ([]entity.Card, error) {
+	if !utf8.ValidString(userID) {
+		return nil, fmt.Errorf("userID [%s] is invalid utf8 string", userID)
+	}
+
+	filter := inStudy(userID, lang)
+	filter[nextDueDateField] = bson.M{"$not": bson.M{"$gt": time.Time{}}}
+
+	return r.find(ctx, filter)
+}
+
+// GetDueCards returns the user's cards in the language which may be due before the given time in production
+// or in any of the directions, the learnt and the suspended cards are left out. A direction not started yet
+// may be due once the card is scheduled in production, so the service decides whether the cards are due.
+func (r *Repo) GetDueCards(
+	ctx context.Context,
+	userID string,
+	lang language.Tag,
+	before time.Time,
+	directions []entity.ReviewDirection,
+) ([]entity.Card, error) {
+	if !utf8.ValidString(userID) {
+		return nil, fmt.Errorf("userID [%s] is invalid utf8 string", userID)
+	}
+
+	scheduled := bson.M{"$gt": time.Time{}}
+	due := bson.A{
+		bson.M{nextDueDateField: bson.M{"$gt": time.Time{}, "$lt": before}},
+	}
+	for _, direction := range lo.Uniq(directions) {
+		if direction.OrProduction() == entity.DirectionProduction {
+			continue
+		}
+		dueDateField := directionsField + "." + string(direction) + "." + nextDueDateField
+		due = append(due,
+			bson.M{dueDateField: bson.M{"$gt": time.Time{}, "$lt": before}},
+			bson.M{
+				dueDateField:     bson.M{"$not": scheduled},
+				nextDueDateField: scheduled,
+				// only the word cards are reviewed in the directions other than production
+				typeField: bson.M{"$in": bson.A{nil, "", string(entity.NoteTypeWords)}},
+			},
+		)
+	}
+
+	filter := inStudy(userID, lang)
+	filter["$or"] = due
+
+	return r.find(ctx, filter)
+}
+
+// GetLearntCards returns the user's learnt cards in the language, the suspended cards are left out.
+func (r *Repo) GetLearntCards(ctx context.Context, userID string, lang language.Tag) ([]entity.Card, error) {
+	if !utf8.ValidString(userID) {
+		return nil, fmt.Errorf("userID [%s] is invalid utf8 string", userID)
+	}
+
+	filter := inLanguage(userID, lang)
+	filter[learntField] = true
+	filter[suspendedField] = bson.M{"$ne": true}
+
+	return r.find(ctx, filter)
+}
+
+// GetLeeches returns the user's leeches in the language, language.Und returns the leeches in all languages.
+func (r *Repo) GetLeeches(ctx context.Context, userID string, lang language.Tag) ([]entity.Card, error) {
+	if !utf8.ValidString(userID) {
+		return nil, fmt.Errorf("userID [%s] is invalid utf8 string", userID)
+	}
+
+	filter := inLanguage(userID, lang)
+	filter[leechField] = true
+
+	return r.find(ctx, filter)
+}
+
+// GetDecks returns the deck of every user's card in a deck in the language, language.Und returns
+// the decks of the cards in all languages.
+func (r *Repo) GetDecks(ctx context.Context, userID string, lang language.Tag) ([]string, error) {
+	if !utf8.ValidString(userID) {
+		return nil, fmt.Errorf("userID [%s] is invalid utf8 string", userID)
+	}
+
+	filter := inLanguage(userID, lang)
+	filter[deckField] = bson.M{"$nin": bson.A{nil, ""}}
+
+	var docs []deckDoc
+	if err := r.findProjected(ctx, filter, bson.M{deckField: 1}, &docs); err != nil {
+		return nil, err
+	}
+
+	return lo.Map(docs, func(doc deckDoc, _ int) string {
+		return doc.Deck
+	}), nil
+}
+
+// inLanguage matches the user's cards in the language, language.Und matches the cards in all languages.
+func inLanguage(userID string, lang language.Tag) bson.M {
+	filter := bson.M{userIDField: userID}
+	if lang != language.Und {
+		filter[languageField] = lang.String()
+	}
+
+	return filter
+}
+
+// inStudy matches the user's cards in the language which are neither learnt nor suspended.
+func inStudy(userID string, lang language.Tag) bson.M {
+	filter := inLanguage(userID, lang)
+	filter[learntField] = bson.M{"$ne": true}
+	filter[suspendedField] = bson.M{"$ne": true}
+
+	return filter
+}
+
+// matching narrows the filter down to the cards in the deck and having all the tags of the card filter,
+// the deck and the tags are matched ignoring the case, see entity.CardFilter.Matches. The case is ignored
+// by the regular expressions rather than a collation, which would keep the queries off the indexes.
+func matching(filter bson.M, cardFilter entity.CardFilter) bson.M {
+	if cardFilter.Deck != "" {
+		filter[deckField] = equalFold(cardFilter.Deck)
+	}
+	if len(cardFilter.Tags) != 0 {
+		filter[tagsField] = bson.M{"$all": lo.Map(cardFilter.Tags, func(tag string, _ int) primitive.Regex {
+			return equalFold(tag)
+		})}
+	}
+
+	return filter
+}
+
+// equalFold matches the strings equal to the value ignoring the case.
+func equalFold(value string) primitive.Regex {
+	return primitive.Regex{Pattern: "^" + regexp.QuoteMeta(value) + "$", Options: "i"}
+}
+
+func (r *Repo) find(ctx context.Context, filter bson.M, opts ...*options.FindOptions) ([]entity.Card, error) {
+	cardsCollection := r.client.
+		Database(r.database).
+		Collection(r.collection)
+
+	cursor, err := cardsCollection.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("find: %w", err)
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			logrus.Errorf("failed to close cursor: %s", closeErr.Error())
+		}
+	}()
+
+	return r.tr.unmarshalCursor(ctx, cursor)
+}
+
+func (r *Repo) findProjected(ctx context.Context, filter, projection bson.M, docs any) error {
+	cardsCollection := r.client.
+		Database(r.database).
+		Collection(r.collection)
+
+	cursor, err := cardsCollection.Find(ctx, filter, options.Find().SetProjection(projection))
+	if err != nil {
+		return fmt.Errorf("find: %w", err)
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			logrus.Errorf("failed to close cursor: %s", closeErr.Error())
+		}
+	}()
+
+	if err = cursor.All(ctx, docs); err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+
+	return nil
+}
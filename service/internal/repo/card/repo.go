@@ -7,9 +7,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 	"unicode/utf8"
 
 	mongometrics "github.com/genvmoroz/lale/service/internal/observability/mongo"
+	"github.com/genvmoroz/lale/service/internal/repo/transaction"
 	"github.com/genvmoroz/lale/service/pkg/entity"
 	"github.com/genvmoroz/lale/service/pkg/gracefulmongo"
 	"github.com/samber/lo"
@@ -17,10 +19,15 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	mongo "go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/text/language"
 )
 
-// userIDField is the BSON field name for user ID on card documents.
-const userIDField = "userid"
+// BSON field names on card documents.
+const (
+	userIDField      = "userid"
+	nextDueDateField = "nextduedate"
+	versionField     = "version"
+)
 
 type (
 	Config struct {
@@ -40,6 +47,11 @@ type (
 		Pass string `envconfig:"APP_MONGO_PASS" required:"true"`
 	}
 
+	// dueDateDoc is the projection of a card document on its due date.
+	dueDateDoc struct {
+		NextDueDate time.Time `bson:"nextduedate"`
+	}
+
 	Repo struct {
 		client *mongo.Client
 
@@ -47,10 +59,25 @@ type (
 		collection string
 
 		tr transformer
+		tx *transaction.Runner
 	}
 )
 
-func NewRepo(ctx context.Context, cfg Config, metrics *mongometrics.Metrics) (*Repo, error) {
+// NewRepo creates the repo on top of the client shared by the repos of the same MongoDB deployment.
+func NewRepo(client *mongo.Client, cfg Config) *Repo {
+	return &Repo{
+		client: client,
+
+		database:   cfg.Database,
+		collection: cfg.Collection,
+
+		tr: newTransformer(),
+		tx: transaction.NewRunner(client),
+	}
+}
+
+// NewClient connects to the MongoDB deployment configured for the card repo.
+func NewClient(ctx context.Context, cfg Config, metrics *mongometrics.Metrics) (*mongo.Client, error) {
 	gmCfg := gracefulmongo.Config{
 		Protocol:    cfg.Protocol,
 		Host:        cfg.Host,
@@ -68,16 +95,7 @@ func NewRepo(ctx context.Context, cfg Config, metrics *mongometrics.Metrics) (*R
 		return nil, fmt.Errorf("new graceful client: %w", err)
 	}
 
-	repo := &Repo{
-		client: client,
-
-		database:   cfg.Database,
-		collection: cfg.Collection,
-
-		tr: newTransformer(),
-	}
-
-	return repo, nil
+	return client, nil
 }
 
 func (r *Repo) GetCardsByWords(ctx context.Context, userID string, words []string) ([]entity.Card, error) {
@@ -111,25 +129,24 @@ func (r *Repo) GetCardsByWords(ctx context.Context, userID string, words []strin
 	return r.tr.unmarshalCursor(ctx, cursor)
 }
 
-func (r *Repo) WordsExist(ctx context.Context, userID string, words []string) (bool, error) {
+// WordsExist reports whether the user has a card in the language having any of the words, the words are
+// matched ignoring the case like the unique word index does.
+func (r *Repo) WordsExist(ctx context.Context, userID string, lang language.Tag, words []string) (bool, error) {
 	if !utf8.ValidString(userID) {
 		return false, fmt.Errorf("userID [%s] is invalid utf8 string", userID)
 	}
 
 	filter := bson.M{
-		userIDField: userID,
-		"wordinformationlist": bson.M{
-			"$elemMatch": bson.M{
-				"word": bson.M{"$in": words},
-			},
-		},
+		userIDField:   userID,
+		languageField: lang.String(),
+		wordField:     bson.M{"$in": words},
 	}
 
 	collection := r.client.
 		Database(r.database).
 		Collection(r.collection)
 
-	result := collection.FindOne(ctx, filter)
+	result := collection.FindOne(ctx, filter, options.FindOne().SetCollation(wordCollation))
 	switch {
 	case errors.Is(result.Err(), mongo.ErrNoDocuments):
 		return false, nil
@@ -166,13 +183,49 @@ func (r *Repo) GetCardsForUser(ctx context.Context, userID string) ([]entity.Car
 	return r.tr.unmarshalCursor(ctx, cursor)
 }
 
-//todo: different functions for create and update cards
+// GetDueDates returns the due dates of the user's cards to repeat scheduled within [from, to),
+// the learnt and the suspended cards and the excluded card are left out.
+func (r *Repo) GetDueDates(
+	ctx context.Context,
+	userID, excludedCardID string,
+	from, to time.Time,
+) ([]time.Time, error) {
+	if !utf8.ValidString(userID) {
+		return nil, fmt.Errorf("userID [%s] is invalid utf8 string", userID)
+	}
+
+	cardsCollection := r.client.
+		Database(r.database).
+		Collection(r.collection)
 
-// TODO: implement search card by name on Repo side
-// func (r *Repo) FindCardsByWord(ctx context.Context, userID, word string) ([]entity.Card, error) {
-//	return nil, errors.New("not implemented yet")
-// }
+	query := bson.M{
+		userIDField:      userID,
+		idField:          bson.M{"$ne": excludedCardID},
+		learntField:      bson.M{"$ne": true},
+		suspendedField:   bson.M{"$ne": true},
+		nextDueDateField: bson.M{"$gte": from, "$lt": to},
+	}
+	cursor, err := cardsCollection.Find(ctx, query, options.Find().SetProjection(bson.M{nextDueDateField: 1}))
+	if err != nil {
+		return nil, fmt.Errorf("find: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close(context.Background())
+	}()
 
+	var docs []dueDateDoc
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	return lo.Map(docs, func(doc dueDateDoc, _ int) time.Time {
+		return doc.NextDueDate
+	}), nil
+}
+
+//todo: different functions for create and update cards
+
+// SaveCards stores the cards with their versions incremented, see saveCard.
 func (r *Repo) SaveCards(ctx context.Context, cards []entity.Card) error {
 	if len(cards) == 0 {
 		return nil
@@ -191,7 +244,7 @@ func (r *Repo) SaveCards(ctx context.Context, cards []entity.Card) error {
 		Database(r.database).
 		Collection(r.collection)
 
-	return r.transaction(ctx, func() error {
+	return r.tx.InTransaction(ctx, func(ctx context.Context) error {
 		for _, card := range cards {
 			if err := r.saveCard(ctx, cardsCollection, card); err != nil {
 				return err
@@ -202,30 +255,58 @@ func (r *Repo) SaveCards(ctx context.Context, cards []entity.Card) error {
 	})
 }
 
+// saveCard stores the card with the version incremented, the stored card is replaced only if it has
+// the version of the card, entity.ErrVersionConflict is returned otherwise. entity.ErrDuplicateWord is
+// returned if another card of the user in the language has a word of the card.
 func (r *Repo) saveCard(ctx context.Context, cardsCollection *mongo.Collection, card entity.Card) error {
+	version := card.Version
+	card.Version++
 	doc, err := r.tr.cardToDoc(card)
 	if err != nil {
 		return fmt.Errorf("marshal: %w", err)
 	}
 
-	filter := bson.M{"id": card.ID}
+	filter := bson.M{idField: card.ID}
 	existErr := cardsCollection.FindOne(ctx, filter).Err()
 	switch {
 	case errors.Is(existErr, mongo.ErrNoDocuments):
+		if version != 0 {
+			return fmt.Errorf("card %s has been deleted: %w", card.ID, entity.ErrVersionConflict)
+		}
 		if _, err = cardsCollection.InsertOne(ctx, doc); err != nil {
-			return fmt.Errorf("insert: %w", err)
+			return fmt.Errorf("insert: %w", duplicateWordError(err))
 		}
 	case existErr != nil:
 		return fmt.Errorf("check card existence: %w", existErr)
 	default:
-		if _, err = cardsCollection.ReplaceOne(ctx, filter, doc); err != nil {
-			return fmt.Errorf("replace card: %w", err)
+		// the cards stored before the versions have none, they're matched by version 0
+		var versionFilter any = version
+		if version == 0 {
+			versionFilter = bson.M{"$in": bson.A{0, nil}}
+		}
+		filter[versionField] = versionFilter
+
+		var result *mongo.UpdateResult
+		if result, err = cardsCollection.ReplaceOne(ctx, filter, doc); err != nil {
+			return fmt.Errorf("replace card: %w", duplicateWordError(err))
+		}
+		if result.MatchedCount == 0 {
+			return fmt.Errorf("card %s has been changed: %w", card.ID, entity.ErrVersionConflict)
 		}
 	}
 
 	return nil
 }
 
+// duplicateWordError wraps the duplicate key error with entity.ErrDuplicateWord, the unique word index
+// is the only unique index of the cards.
+func duplicateWordError(err error) error {
+	if mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("%w: %w", entity.ErrDuplicateWord, err)
+	}
+	return err
+}
+
 func (r *Repo) DeleteCard(ctx context.Context, cardID string) error {
 	if !utf8.ValidString(cardID) {
 		return fmt.Errorf("cardID [%s] is invalid utf8 string", cardID)
@@ -243,28 +324,3 @@ func (r *Repo) DeleteCard(ctx context.Context, cardID string) error {
 
 	return nil
 }
-
-func (r *Repo) transaction(ctx context.Context, operation func() error) error {
-	if err := r.client.UseSession(ctx, func(sessionContext mongo.SessionContext) error {
-		if err := sessionContext.StartTransaction(); err != nil {
-			return err
-		}
-
-		if err := operation(); err != nil {
-			abortTransaction(ctx, sessionContext)
-			return err
-		}
-
-		return sessionContext.CommitTransaction(ctx)
-	}); err != nil {
-		return fmt.Errorf("perform transaction: %w", err)
-	}
-
-	return nil
-}
-
-func abortTransaction(ctx context.Context, sessionContext mongo.SessionContext) {
-	if err := sessionContext.AbortTransaction(ctx); err != nil {
-		logrus.Errorf("failed to abort transaction: %s", err.Error())
-	}
-}
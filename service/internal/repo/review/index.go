@@ -0,0 +1,43 @@
+package review
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EnsureIndexes creates the indexes the reviews are queried by, the existing indexes are left untouched.
+func (r *Repo) EnsureIndexes(ctx context.Context) error {
+	reviewsCollection := r.client.
+		Database(r.database).
+		Collection(r.collection)
+
+	models := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: idField, Value: 1}},
+			Options: options.Index().SetName("id_unique").SetUnique(true),
+		},
+		{
+			// the reviews of a day, the history and the log the optimiser is fitted on
+			Keys:    bson.D{{Key: userIDField, Value: 1}, {Key: reviewedAtField, Value: -1}},
+			Options: options.Index().SetName("userid_reviewedat"),
+		},
+		{
+			// the history of a card and its last review to be undone
+			Keys: bson.D{
+				{Key: userIDField, Value: 1},
+				{Key: cardIDField, Value: 1},
+				{Key: reviewedAtField, Value: -1},
+			},
+			Options: options.Index().SetName("userid_cardid_reviewedat"),
+		},
+	}
+	if _, err := reviewsCollection.Indexes().CreateMany(ctx, models); err != nil {
+		return fmt.Errorf("create indexes: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,314 @@
+// Package review provides MongoDB-based append-only log of card reviews.
+package review
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/genvmoroz/lale/service/pkg/entity"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/text/language"
+)
+
+// BSON field names the reviews are queried by.
+const (
+	idField         = "id"
+	userIDField     = "userid"
+	cardIDField     = "cardid"
+	reviewedAtField = "reviewedat"
+	undoneField     = "undone"
+)
+
+// notUndone matches the reviews which haven't been undone.
+var notUndone = bson.M{"$ne": true}
+
+type (
+	Config struct {
+		Database   string
+		Collection string
+	}
+
+	Repo struct {
+		client *mongo.Client
+
+		database   string
+		collection string
+	}
+)
+
+// NewRepo creates the repo on top of the client shared by the repos of the same MongoDB deployment.
+func NewRepo(client *mongo.Client, cfg Config) *Repo {
+	return &Repo{
+		client: client,
+
+		database:   cfg.Database,
+		collection: cfg.Collection,
+	}
+}
+
+func (r *Repo) AddReview(ctx context.Context, review entity.Review) error {
+	reviewsCollection := r.client.
+		Database(r.database).
+		Collection(r.collection)
+
+	if _, err := reviewsCollection.InsertOne(ctx, review); err != nil {
+		return fmt.Errorf("insert: %w", err)
+	}
+
+	return nil
+}
+
+// MarkReviewUndone marks the review undone, the review is kept in the log. An error is returned if the review
+// is not found or has already been undone.
+func (r *Repo) MarkReviewUndone(ctx context.Context, reviewID string) error {
+	if !utf8.ValidString(reviewID) {
+		return fmt.Errorf("reviewID [%s] is invalid utf8 string", reviewID)
+	}
+
+	reviewsCollection := r.client.
+		Database(r.database).
+		Collection(r.collection)
+
+	result, err := reviewsCollection.UpdateOne(
+		ctx,
+		bson.M{idField: reviewID, undoneField: notUndone},
+		bson.M{"$set": bson.M{undoneField: true}},
+	)
+	if err != nil {
+		return fmt.Errorf("update: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("review %s not found or already undone", reviewID)
+	}
+
+	return nil
+}
+
+// GetReviewsForUser returns the reviews of the user done since the given time in chronological order,
+// zero since returns all the reviews. The undone reviews are left out.
+func (r *Repo) GetReviewsForUser(ctx context.Context, userID string, since time.Time) ([]entity.Review, error) {
+	if !utf8.ValidString(userID) {
+		return nil, fmt.Errorf("userID [%s] is invalid utf8 string", userID)
+	}
+
+	reviewsCollection := r.client.
+		Database(r.database).
+		Collection(r.collection)
+
+	filter := bson.M{userIDField: userID, undoneField: notUndone}
+	if !since.IsZero() {
+		filter[reviewedAtField] = bson.M{"$gte": since}
+	}
+
+	cursor, err := reviewsCollection.Find(
+		ctx,
+		filter,
+		options.Find().SetSort(bson.D{{Key: reviewedAtField, Value: 1}}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("find: %w", err)
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			logrus.Errorf("failed to close cursor: %s", closeErr.Error())
+		}
+	}()
+
+	var reviews []entity.Review
+	if err = cursor.All(ctx, &reviews); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	return reviews, nil
+}
+
+// The kinds of the study of a card during a day, a card studied a few times counts once as the kind
+// of the highest value.
+const (
+	usageReview = iota
+	usageMaintenance
+	usageNew
+)
+
+// GetDailyUsage counts the cards of the user in the language studied since the start of the day, the cards
+// are counted by the reviews of the day not undone, see entity.DailyUsage. language.Und counts the cards
+// in all languages.
+func (r *Repo) GetDailyUsage(
+	ctx context.Context,
+	userID string,
+	lang language.Tag,
+	since time.Time,
+) (entity.DailyUsage, error) {
+	if !utf8.ValidString(userID) {
+		return entity.DailyUsage{}, fmt.Errorf("userID [%s] is invalid utf8 string", userID)
+	}
+
+	reviewsCollection := r.client.
+		Database(r.database).
+		Collection(r.collection)
+
+	filter := bson.M{userIDField: userID, reviewedAtField: bson.M{"$gte": since}, undoneField: notUndone}
+	if lang != language.Und {
+		filter["language"] = lang.String()
+	}
+
+	// the reviews logged before the directions were introduced have no direction, they're production reviews
+	production := string(entity.DirectionProduction)
+	direction := bson.M{"$cond": bson.A{
+		bson.M{"$in": bson.A{bson.M{"$ifNull": bson.A{"$direction", ""}}, bson.A{"", production}}},
+		production,
+		"$direction",
+	}}
+	// the reviews logged before the snapshots were introduced have no card before them, they count as reviews;
+	// only the first production answer makes the card new
+	usage := bson.M{"$switch": bson.M{
+		"branches": bson.A{
+			bson.M{
+				"case": bson.M{"$eq": bson.A{bson.M{"$ifNull": bson.A{"$cardbefore", nil}}, nil}},
+				"then": usageReview,
+			},
+			bson.M{
+				"case": bson.M{"$and": bson.A{
+					bson.M{"$lte": bson.A{"$cardbefore.nextduedate", time.Time{}}},
+					bson.M{"$eq": bson.A{direction, production}},
+				}},
+				"then": usageNew,
+			},
+			bson.M{
+				"case": bson.M{"$eq": bson.A{"$cardbefore.learnt", true}},
+				"then": usageMaintenance,
+			},
+		},
+		"default": usageReview,
+	}}
+
+	cursor, err := reviewsCollection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"card": "$" + cardIDField, "direction": direction},
+			"usage": bson.M{"$max": usage},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$usage",
+			"cards": bson.M{"$sum": 1},
+		}}},
+	})
+	if err != nil {
+		return entity.DailyUsage{}, fmt.Errorf("aggregate: %w", err)
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			logrus.Errorf("failed to close cursor: %s", closeErr.Error())
+		}
+	}()
+
+	var docs []struct {
+		Usage int `bson:"_id"`
+		Cards int `bson:"cards"`
+	}
+	if err = cursor.All(ctx, &docs); err != nil {
+		return entity.DailyUsage{}, fmt.Errorf("decode: %w", err)
+	}
+
+	var daily entity.DailyUsage
+	for _, doc := range docs {
+		switch doc.Usage {
+		case usageNew:
+			daily.NewCards = doc.Cards
+		case usageMaintenance:
+			daily.Maintenance = doc.Cards
+		case usageReview:
+			daily.Reviews = doc.Cards
+		}
+	}
+
+	return daily, nil
+}
+
+// GetReviews returns a page of the user's reviews from the newest to the oldest,
+// the reviews of a single card if cardID is not empty. The undone reviews are left out.
+func (r *Repo) GetReviews(ctx context.Context, userID, cardID string, skip, limit int64) ([]entity.Review, error) {
+	if !utf8.ValidString(userID) {
+		return nil, fmt.Errorf("userID [%s] is invalid utf8 string", userID)
+	}
+	if !utf8.ValidString(cardID) {
+		return nil, fmt.Errorf("cardID [%s] is invalid utf8 string", cardID)
+	}
+
+	reviewsCollection := r.client.
+		Database(r.database).
+		Collection(r.collection)
+
+	filter := bson.M{userIDField: userID, undoneField: notUndone}
+	if cardID != "" {
+		filter[cardIDField] = cardID
+	}
+
+	cursor, err := reviewsCollection.Find(
+		ctx,
+		filter,
+		options.Find().
+			SetSort(bson.D{{Key: reviewedAtField, Value: -1}, {Key: idField, Value: -1}}).
+			SetSkip(skip).
+			SetLimit(limit),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("find: %w", err)
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			logrus.Errorf("failed to close cursor: %s", closeErr.Error())
+		}
+	}()
+
+	var reviews []entity.Review
+	if err = cursor.All(ctx, &reviews); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	return reviews, nil
+}
+
+// GetFirstReviewTimes returns the time every card has been reviewed the first time by card ID,
+// the undone reviews are left out.
+func (r *Repo) GetFirstReviewTimes(ctx context.Context) (map[string]time.Time, error) {
+	reviewsCollection := r.client.
+		Database(r.database).
+		Collection(r.collection)
+
+	cursor, err := reviewsCollection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{undoneField: notUndone}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$" + cardIDField,
+			"first": bson.M{"$min": "$" + reviewedAtField},
+		}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("aggregate: %w", err)
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			logrus.Errorf("failed to close cursor: %s", closeErr.Error())
+		}
+	}()
+
+	var docs []struct {
+		CardID string    `bson:"_id"`
+		First  time.Time `bson:"first"`
+	}
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	firstReviews := make(map[string]time.Time, len(docs))
+	for _, doc := range docs {
+		firstReviews[doc.CardID] = doc.First
+	}
+
+	return firstReviews, nil
+}
@@ -0,0 +1,53 @@
+// Package transaction provides MongoDB transactions spanning the repos of the same deployment.
+package transaction
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Runner runs operations in the transactions of the client shared by the repos.
+type Runner struct {
+	client *mongo.Client
+}
+
+// NewRunner creates the runner on top of the client shared by the repos of the same MongoDB deployment.
+func NewRunner(client *mongo.Client) *Runner {
+	return &Runner{client: client}
+}
+
+// InTransaction runs the operation in a transaction, the repos called with the context passed to the operation
+// take part in it, so their changes are committed together or not at all. The operation run within
+// a transaction already takes part in that transaction.
+func (r *Runner) InTransaction(ctx context.Context, operation func(ctx context.Context) error) error {
+	if mongo.SessionFromContext(ctx) != nil {
+		return operation(ctx)
+	}
+
+	if err := r.client.UseSession(ctx, func(sessionContext mongo.SessionContext) error {
+		if err := sessionContext.StartTransaction(); err != nil {
+			return err
+		}
+
+		if err := operation(sessionContext); err != nil {
+			abortTransaction(sessionContext)
+			return err
+		}
+
+		return sessionContext.CommitTransaction(sessionContext)
+	}); err != nil {
+		return fmt.Errorf("perform transaction: %w", err)
+	}
+
+	return nil
+}
+
+// abortTransaction aborts the transaction even if the operation has failed because the context is done.
+func abortTransaction(sessionContext mongo.SessionContext) {
+	if err := sessionContext.AbortTransaction(context.WithoutCancel(sessionContext)); err != nil {
+		logrus.Errorf("failed to abort transaction: %s", err.Error())
+	}
+}
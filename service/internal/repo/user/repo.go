@@ -0,0 +1,77 @@
+// Package user provides MongoDB-based repository for user entities.
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/genvmoroz/lale/service/pkg/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type (
+	Config struct {
+		Database   string
+		Collection string
+	}
+
+	Repo struct {
+		client *mongo.Client
+
+		database   string
+		collection string
+	}
+)
+
+// NewRepo creates the repo on top of the client shared by the repos of the same MongoDB deployment.
+func NewRepo(client *mongo.Client, cfg Config) *Repo {
+	return &Repo{
+		client: client,
+
+		database:   cfg.Database,
+		collection: cfg.Collection,
+	}
+}
+
+// GetUser returns the user with the given ID, the second value reports whether the user has been found.
+func (r *Repo) GetUser(ctx context.Context, userID string) (entity.User, bool, error) {
+	if !utf8.ValidString(userID) {
+		return entity.User{}, false, fmt.Errorf("userID [%s] is invalid utf8 string", userID)
+	}
+
+	usersCollection := r.client.
+		Database(r.database).
+		Collection(r.collection)
+
+	var user entity.User
+	err := usersCollection.FindOne(ctx, bson.M{"id": userID}).Decode(&user)
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return entity.User{}, false, nil
+	case err != nil:
+		return entity.User{}, false, fmt.Errorf("find one: %w", err)
+	default:
+		return user, true, nil
+	}
+}
+
+func (r *Repo) SaveUser(ctx context.Context, user entity.User) error {
+	if !utf8.ValidString(user.ID) {
+		return fmt.Errorf("userID [%s] is invalid utf8 string", user.ID)
+	}
+
+	usersCollection := r.client.
+		Database(r.database).
+		Collection(r.collection)
+
+	_, err := usersCollection.ReplaceOne(ctx, bson.M{"id": user.ID}, user, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("replace user: %w", err)
+	}
+
+	return nil
+}
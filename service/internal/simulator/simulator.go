@@ -0,0 +1,194 @@
+// Package simulator simulates the reviews of a user's cards day by day to compare the scheduling algorithms.
+package simulator
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/genvmoroz/lale/service/internal/algo"
+	"github.com/genvmoroz/lale/service/pkg/entity"
+)
+
+// Performances the simulated user answers with: the new cards are answered good, the due cards are answered
+// good if recalled and again otherwise.
+const (
+	performanceAgain uint32 = 0
+	performanceGood  uint32 = 4
+)
+
+const day = 24 * time.Hour
+
+type (
+	// Scheduler is the algorithm under simulation, the core AnkiAlgo implementations satisfy it.
+	Scheduler interface {
+		CalculateNextDueDate(user entity.User, performance uint32, card entity.Card) (time.Time, entity.MemoryState)
+	}
+
+	// NewScheduler creates the scheduler running on the simulated clock.
+	NewScheduler func(now func() time.Time) Scheduler
+
+	Config struct {
+		// Days is the number of days simulated.
+		Days int
+		// NewCardsPerDay is the number of new cards introduced per day.
+		NewCardsPerDay int
+		// MaxReviewsPerDay caps the due cards reviewed per day, the rest are carried over as the backlog,
+		// zero reviews all the due cards.
+		MaxReviewsPerDay int
+		// Start is the first simulated day.
+		Start time.Time
+	}
+
+	// Day is the outcome of a simulated day.
+	Day struct {
+		Day      int       `json:"day"`
+		Date     time.Time `json:"date"`
+		NewCards int       `json:"newCards"`
+		Reviews  int       `json:"reviews"`
+		Lapses   int       `json:"lapses"`
+		// Backlog is the number of the due cards left unreviewed by the end of the day.
+		Backlog int `json:"backlog"`
+		// ExpectedRetention is the mean probability to recall the cards introduced so far at the end of the day.
+		ExpectedRetention float64 `json:"expectedRetention"`
+	}
+
+	// Simulator simulates the user recalling the cards with the probability predicted by the recall model,
+	// random returns the numbers in [0, 1) the recalls are drawn with.
+	Simulator struct {
+		cfg    Config
+		recall *algo.RecallModel
+		random func() float64
+	}
+
+	// simulatedCard is the card as the scheduler sees it along with what the user actually remembers.
+	simulatedCard struct {
+		card           entity.Card
+		memory         entity.MemoryState
+		lastReviewedAt time.Time
+	}
+)
+
+func NewSimulator(cfg Config, recall *algo.RecallModel, random func() float64) *Simulator {
+	return &Simulator{
+		cfg:    cfg,
+		recall: recall,
+		random: random,
+	}
+}
+
+// Simulate schedules the user's cards with the scheduler for the configured number of days. The learnt
+// and the suspended cards are left out, the reviews of the cards tell what the user remembers at the start.
+func (s *Simulator) Simulate(
+	user entity.User,
+	cards []entity.Card,
+	reviews []entity.Review,
+	newScheduler NewScheduler,
+) []Day {
+	now := s.cfg.Start
+	scheduler := newScheduler(func() time.Time { return now })
+
+	introduced, fresh := s.prepareCards(cards, reviews)
+
+	days := make([]Day, 0, s.cfg.Days)
+	for i := range s.cfg.Days {
+		date := user.Profile.StartOfDay(s.cfg.Start.AddDate(0, 0, i))
+		now = date
+		result := Day{Day: i + 1, Date: date}
+
+		var due []*simulatedCard
+		for _, c := range introduced {
+			if c.card.NextDueDate.Before(date.Add(day)) {
+				due = append(due, c)
+			}
+		}
+		slices.SortStableFunc(due, func(a, b *simulatedCard) int {
+			return a.card.NextDueDate.Compare(b.card.NextDueDate)
+		})
+		if s.cfg.MaxReviewsPerDay > 0 && len(due) > s.cfg.MaxReviewsPerDay {
+			result.Backlog = len(due) - s.cfg.MaxReviewsPerDay
+			due = due[:s.cfg.MaxReviewsPerDay]
+		}
+
+		for _, c := range due {
+			performance := performanceGood
+			if s.random() >= s.recall.Retrievability(c.memory, c.lastReviewedAt, now) {
+				performance = performanceAgain
+				result.Lapses++
+			}
+			s.answer(user, scheduler, c, performance, now)
+			result.Reviews++
+		}
+
+		newCards := min(s.cfg.NewCardsPerDay, len(fresh))
+		for _, c := range fresh[:newCards] {
+			s.answer(user, scheduler, c, performanceGood, now)
+			introduced = append(introduced, c)
+		}
+		fresh = fresh[newCards:]
+		result.NewCards = newCards
+
+		result.ExpectedRetention = s.expectedRetention(introduced, date.Add(day))
+		days = append(days, result)
+	}
+
+	return days
+}
+
+// prepareCards splits the cards into the ones already introduced and the new ones, the new cards are
+// introduced in the order of their IDs, so every scheduler gets them in the same order.
+func (s *Simulator) prepareCards(cards []entity.Card, reviews []entity.Review) ([]*simulatedCard, []*simulatedCard) {
+	// the cards are simulated in production, the schedules of the other directions are left out
+	reviewsByCard := make(map[string][]entity.Review)
+	for _, review := range reviews {
+		if review.Direction.OrProduction() != entity.DirectionProduction {
+			continue
+		}
+		reviewsByCard[review.CardID] = append(reviewsByCard[review.CardID], review)
+	}
+
+	var introduced, fresh []*simulatedCard
+	for _, card := range cards {
+		if card.Learnt || card.Suspended {
+			continue
+		}
+		c := &simulatedCard{card: card}
+		if card.NextDueDate.IsZero() {
+			fresh = append(fresh, c)
+			continue
+		}
+		c.memory, c.lastReviewedAt = s.recall.MemoryState(card, reviewsByCard[card.ID])
+		// the steps are out of the simulation, the cards in the steps are simulated as scheduled in days
+		c.card.Learning = entity.LearningState{}
+		introduced = append(introduced, c)
+	}
+	slices.SortStableFunc(fresh, func(a, b *simulatedCard) int {
+		return cmp.Compare(a.card.ID, b.card.ID)
+	})
+
+	return introduced, fresh
+}
+
+func (s *Simulator) answer(user entity.User, scheduler Scheduler, c *simulatedCard, performance uint32, now time.Time) {
+	c.memory = s.recall.Next(c.memory, c.lastReviewedAt, now, performance)
+	c.lastReviewedAt = now
+
+	c.card.AddAnswer(performance >= performanceGood)
+	due, memoryState := scheduler.CalculateNextDueDate(user, performance, c.card)
+	c.card.NextDueDate = due
+	c.card.MemoryState = memoryState
+	c.card.LastReviewedAt = now
+}
+
+func (s *Simulator) expectedRetention(cards []*simulatedCard, at time.Time) float64 {
+	if len(cards) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, c := range cards {
+		sum += s.recall.Retrievability(c.memory, c.lastReviewedAt, at)
+	}
+
+	return sum / float64(len(cards))
+}
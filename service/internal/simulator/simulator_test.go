@@ -0,0 +1,92 @@
+package simulator_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/genvmoroz/lale/service/internal/algo"
+	"github.com/genvmoroz/lale/service/internal/simulator"
+	"github.com/genvmoroz/lale/service/pkg/entity"
+)
+
+// dailyScheduler schedules every card for the next day.
+type dailyScheduler struct {
+	now func() time.Time
+}
+
+func (s dailyScheduler) CalculateNextDueDate(entity.User, uint32, entity.Card) (time.Time, entity.MemoryState) {
+	return s.now().AddDate(0, 0, 1), entity.MemoryState{}
+}
+
+func newCards(n int) []entity.Card {
+	cards := make([]entity.Card, n)
+	for i := range cards {
+		cards[i] = entity.Card{ID: fmt.Sprintf("card-%02d", i)}
+	}
+	return cards
+}
+
+func TestSimulatorSimulate(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	newDaily := func(now func() time.Time) simulator.Scheduler { return dailyScheduler{now: now} }
+	alwaysRecalled := func() float64 { return 0 }
+
+	cfg := simulator.Config{Days: 4, NewCardsPerDay: 3, MaxReviewsPerDay: 4, Start: start}
+	s := simulator.NewSimulator(cfg, algo.NewRecallModel(entity.SchedulerParameters{}), alwaysRecalled)
+
+	cards := append(newCards(7), entity.Card{ID: "learnt", Learnt: true}, entity.Card{ID: "suspended", Suspended: true})
+	days := s.Simulate(entity.User{}, cards, nil, newDaily)
+
+	want := []simulator.Day{
+		{Day: 1, NewCards: 3, Reviews: 0, Backlog: 0},
+		{Day: 2, NewCards: 3, Reviews: 3, Backlog: 0},
+		{Day: 3, NewCards: 1, Reviews: 4, Backlog: 2},
+		{Day: 4, NewCards: 0, Reviews: 4, Backlog: 3},
+	}
+	if len(days) != len(want) {
+		t.Fatalf("Simulate() returned %d days, want %d", len(days), len(want))
+	}
+	for i, got := range days {
+		if got.NewCards != want[i].NewCards || got.Reviews != want[i].Reviews || got.Backlog != want[i].Backlog {
+			t.Fatalf("day %d = %+v, want %+v", i+1, got, want[i])
+		}
+		if got.Lapses != 0 {
+			t.Fatalf("day %d lapses = %d, want none as every card is recalled", i+1, got.Lapses)
+		}
+		if got.ExpectedRetention <= 0 || got.ExpectedRetention > 1 {
+			t.Fatalf("day %d expected retention = %v, want in (0, 1]", i+1, got.ExpectedRetention)
+		}
+	}
+}
+
+func TestSimulatorSimulateAlgorithms(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	cfg := simulator.Config{Days: 60, NewCardsPerDay: 5, Start: start}
+	neverRecalled := func() float64 { return 1 }
+
+	for name, newScheduler := range map[string]simulator.NewScheduler{
+		algo.AlgorithmAnki: func(now func() time.Time) simulator.Scheduler { return algo.NewAnki(now, 0.9) },
+		algo.AlgorithmFSRS: func(now func() time.Time) simulator.Scheduler { return algo.NewFSRS(now, 0.9) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			s := simulator.NewSimulator(cfg, algo.NewRecallModel(entity.SchedulerParameters{}), neverRecalled)
+			days := s.Simulate(entity.User{}, newCards(50), nil, newScheduler)
+
+			var reviews, lapses int
+			for _, d := range days {
+				reviews += d.Reviews
+				lapses += d.Lapses
+			}
+			if reviews == 0 || lapses != reviews {
+				t.Fatalf("Simulate() reviews = %d, lapses = %d, want every review lapsed", reviews, lapses)
+			}
+		})
+	}
+}
@@ -1,7 +1,12 @@
 package entity
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,38 +21,111 @@ type (
 		UserID   string
 		Language language.Tag
 
-		//todo: add another one field like "StartedLearningAt" to store the date when the user started learning the word,
-		//	so we can:
-		// 		1. calculate the interval between the current date and the "StartedLearningAt".
-		//		2. sort the words by the "StartedLearningAt" field for the repeat stag,
-		//			later the date is the earlier the word will be repeated.
+		// Tags label the card, e.g. "travel" or "book:Dune", they're matched case-insensitively.
+		Tags []string
+		// Deck is the name of the deck the card is grouped in, empty if the card is in no deck.
+		Deck string
 
-		//todo: add the "CreatedAt" field to store the date when the card was created.
-		//	so we can:
-		//		1. use this field to sort the words by the "CreatedAt" field for the learning stage,
-		//			later the date is the earlier the word will be learned.
+		// CreatedAt is the time the card has been created, the new cards are learnt from the latest created.
+		CreatedAt time.Time
+		// UpdatedAt is the time the card has been saved the last time.
+		UpdatedAt time.Time
+		// StartedLearningAt is the time the card has been answered the first time, zero for the new cards.
+		StartedLearningAt time.Time
+		// Version is incremented by every save of the card, the card is saved only over the version
+		// it has been read at, so the changes made since are never overwritten.
+		Version uint64
 
+		// Type tells what the card holds, the cards stored before the note types have none and are the word cards.
+		Type NoteType `yaml:"Type,omitempty"`
+		// WordInformationList holds the words of the word cards.
 		WordInformationList []WordInformation `yaml:"WordInformationList,omitempty"`
+		// Note holds the content of the cards of the other types.
+		Note Note `yaml:"Note,omitempty"`
 
 		ConsecutiveCorrectAnswersNumber uint32
 		NextDueDate                     time.Time
+		LastReviewedAt                  time.Time
+
+		// MemoryState is tracked by memory-model schedulers (FSRS) only, the Anki-like algorithm leaves it zero.
+		MemoryState MemoryState
+		// Learning is the learning or relearning step the card is at, zero for the cards scheduled in days.
+		Learning LearningState
+		// Lapses is the number of times the card has been forgotten after being scheduled.
+		Lapses uint32
+		// Leech is set once the card has lapsed too many times, such cards are better rewritten than repeated.
+		Leech bool
+		// Suspended cards are neither learnt nor repeated, their schedule is kept.
+		Suspended bool
+		// BuriedUntil hides the card until the start of the user's next day, zero if the card has not been buried.
+		BuriedUntil time.Time
 
 		Learnt   bool
 		LearntAt time.Time
 
+		// Directions hold the schedules of the recognition and listening directions, the fields above are
+		// the schedule of the production direction. A direction missing from the map hasn't been started.
+		// The directions share the suspension, the leech flag and the learnt status of the card.
+		Directions map[ReviewDirection]SchedulingState `yaml:"Directions,omitempty"`
+		// Direction is set on the cards served for the repetition only: it's the direction the card is due in,
+		// the schedule of the card is the one of the direction. Such cards are never stored.
+		Direction ReviewDirection `yaml:"Direction,omitempty" bson:"-"`
+
 		//todo: add the bool field "Learnt" to store the information about the word learning status.
 		//	so we can:
 		//		1. use this field analyze the learning progress.
 		//		2. shrink db memory by removing the words explanation but keeping the word itself.
 	}
 
+	// CardFilter narrows the cards down to the ones in the deck having all the tags,
+	// the zero filter matches every card.
+	CardFilter struct {
+		Tags []string
+		Deck string
+	}
+
+	// MemoryState is the FSRS model of how well a card is remembered.
+	MemoryState struct {
+		// Stability is the number of days after which the recall probability drops to 90%.
+		Stability float64
+		// Difficulty lies in [1, 10], the higher it is the slower the stability grows.
+		Difficulty float64
+	}
+
+	// LearningState is the position of a card in the learning steps of new cards or the relearning steps
+	// of forgotten cards, the steps schedule the card within minutes or hours until it graduates.
+	LearningState struct {
+		Phase LearningPhase
+		// Step is the index of the step the card is scheduled with.
+		Step uint32
+	}
+
+	// LearningStep is the way the learning steps schedule an answer.
+	LearningStep struct {
+		// Due is the end of the step the card is moved to, zero if the card graduates.
+		Due   time.Time
+		State LearningState
+		// Schedule reports whether the answer is scheduled by the algorithm: the consecutive correct answers
+		// and the memory state are updated by the answers lapsing or graduating a card only. The due date
+		// of the algorithm is used if the card graduates.
+		Schedule bool
+	}
+
 	WordInformation struct { // todo: rename to Word
-		Word            string            `yaml:"Word,omitempty"`
-		Translation     *Translation      `yaml:"Translation,omitempty"`
-		Origin          string            `yaml:"Origin,omitempty"`
-		Phonetics       []Phonetic        `yaml:"Phonetics,omitempty"`
-		Meanings        []Meaning         `yaml:"Meanings,omitempty"`
-		AudioByLanguage map[string][]byte `yaml:"AudioByLanguage,omitempty"`
+		Word        string       `yaml:"Word,omitempty"`
+		Translation *Translation `yaml:"Translation,omitempty"`
+		Origin      string       `yaml:"Origin,omitempty"`
+		Phonetics   []Phonetic   `yaml:"Phonetics,omitempty"`
+		Meanings    []Meaning    `yaml:"Meanings,omitempty"`
+		// AudioByLanguage is the audio embedded in the cards stored before the audio store,
+		// cmd/move-card-audio moves it to the store and replaces it with AudioIDs.
+		AudioByLanguage map[string][]byte `yaml:"AudioByLanguage,omitempty" bson:"audiobylanguage,omitempty"`
+		// AudioIDs are the IDs of the word's audio in the audio store by the voice language, e.g. en-GB.
+		AudioIDs map[string]string `yaml:"AudioIDs,omitempty"`
+		// Notes are the user's own notes on the word, e.g. a mnemonic.
+		Notes string `yaml:"Notes,omitempty"`
+		// ImageID is the ID of the image the user has attached to the word, empty if there is none.
+		ImageID string `yaml:"ImageID,omitempty"`
 
 		// todo: add details field with the following fields:
 		// Origin      string       `yaml:"Origin,omitempty"`
@@ -55,6 +133,31 @@ type (
 		// Meanings    []Meaning    `yaml:"Meanings,omitempty"`
 	}
 
+	// Note is the content of the phrase, cloze and grammar cards.
+	Note struct {
+		// Text is the phrase, the cloze sentence or the grammar prompt.
+		Text string `yaml:"Text,omitempty"`
+		// Answer is the meaning of the phrase or the answer to the grammar prompt, the cloze cards have none,
+		// their deletions are the answers.
+		Answer string `yaml:"Answer,omitempty"`
+		// Explanation is shown once the card has been answered, e.g. the grammar rule.
+		Explanation string `yaml:"Explanation,omitempty"`
+	}
+
+	// Image is a picture uploaded by a user to attach to the words.
+	Image struct {
+		ID          string
+		ContentType string
+		Data        []byte
+	}
+
+	// Audio is the pronunciation of a word, it's shared by all the words sounding the same.
+	Audio struct {
+		ID          string
+		ContentType string
+		Data        []byte
+	}
+
 	Translation struct {
 		Language     language.Tag `yaml:"Language,omitempty"`
 		Translations []string     `yaml:"Translations,omitempty"`
@@ -76,12 +179,129 @@ type (
 		Antonyms   []string `yaml:"Antonyms,omitempty"`
 	}
 
-	/*	temporary unused, but may be useful in the future
-		User struct {
-				id      string
-				created time.Time
-			}
-	*/
+	User struct {
+		ID        string
+		CreatedAt time.Time
+
+		Profile             Profile
+		SchedulerParameters SchedulerParameters
+		// DailyLimits are the limits set by the user per language, the languages without limits use the defaults.
+		DailyLimits map[string]DailyLimits
+		// Graduation marks the cards learnt automatically, the zero policy leaves it to the user.
+		Graduation GraduationPolicy
+	}
+
+	// GraduationPolicy tells when a correctly answered card is marked learnt, a zero field disables its criterion.
+	GraduationPolicy struct {
+		// CorrectAnswers is the number of consecutive correct answers the card graduates after.
+		CorrectAnswers uint32
+		// Interval is the interval until the next review the card graduates at.
+		Interval time.Duration
+	}
+
+	// DailyLimits cap the cards served to a user during a day in a language.
+	DailyLimits struct {
+		// NewCards is the number of new cards introduced per day.
+		NewCards uint32
+		// Reviews is the number of due cards reviewed per day.
+		Reviews uint32
+	}
+
+	// DailyUsage is the number of distinct cards a user has studied during a day in a language, every direction
+	// of a card counts on its own. A card introduced during the day counts as a new card only, even if it's been
+	// reviewed again, a learnt card failing the retention check counts as a checked card only.
+	DailyUsage struct {
+		// NewCards are the cards answered for the first time.
+		NewCards int
+		// Reviews are the cards answered after being scheduled before the day.
+		Reviews int
+		// Maintenance are the learnt cards checked for retention.
+		Maintenance int
+	}
+
+	// Profile defines the calendar of a user the cards are scheduled and filtered in.
+	Profile struct {
+		// TimeZone is an IANA time zone name, empty means UTC.
+		TimeZone string
+		// DayStart is the time after midnight the user's day starts at, e.g. 4h makes the day start at 04:00,
+		// so the reviews done after midnight still count to the previous day.
+		DayStart time.Duration
+		// Directions are the directions the user reviews the word cards in besides production,
+		// e.g. recognition and listening.
+		Directions []ReviewDirection
+	}
+
+	// SchedulerParameters tune the scheduling algorithms for a user,
+	// zero values are replaced with the defaults of the algorithm.
+	SchedulerParameters struct {
+		Anki AnkiParameters
+		// FSRSWeights are the 17 weights of the FSRS memory model.
+		FSRSWeights []float64
+		// DesiredRetention is the recall probability FSRS schedules the reviews at.
+		DesiredRetention float64
+	}
+
+	// AnkiParameters define the interval of the Anki-like algorithm after n consecutive correct answers:
+	// IntervalDays * (DifficultyIntercept + DifficultySlope*p + DifficultyCurvature*p^2)^(n-1),
+	// where p is the performance rating of the answer.
+	AnkiParameters struct {
+		IntervalDays        float64
+		DifficultyIntercept float64
+		DifficultySlope     float64
+		DifficultyCurvature float64
+	}
+
+	// SchedulerOptimisation is the result of fitting the scheduler parameters to the review history.
+	SchedulerOptimisation struct {
+		Parameters SchedulerParameters
+		// PredictedRetentionBefore and PredictedRetentionAfter are the average recall probabilities
+		// predicted at the reviews scheduled with the previous and the optimised parameters.
+		PredictedRetentionBefore float64
+		PredictedRetentionAfter  float64
+	}
+
+	// Review is a single answer given for a card.
+	Review struct {
+		ID          string
+		UserID      string
+		CardID      string
+		ReviewedAt  time.Time
+		Performance uint32
+		// TimeToAnswer is how long the user took to answer, zero if the client hasn't measured it.
+		TimeToAnswer time.Duration
+		// PreviousInterval is the interval the card was scheduled with before the review,
+		// zero for the first review of the card.
+		PreviousInterval time.Duration
+		// NewInterval is the interval until the next review scheduled by this one.
+		NewInterval time.Duration
+		// CardBefore is the scheduling state of the card before the review, it's used to undo the review.
+		// Nil for the reviews logged before the snapshots were introduced.
+		CardBefore *SchedulingState
+		// Direction is the direction the card has been reviewed in, empty for the reviews logged before
+		// the directions were introduced, they're production reviews.
+		Direction ReviewDirection
+		// Language is the language of the card as a BCP 47 tag, empty for the reviews logged before
+		// the language was logged, they count in the daily usage of no language.
+		Language string
+		// Undone is set once the review has been undone, the undone reviews are kept in the log
+		// but neither listed, nor counted, nor fitted on.
+		Undone bool
+	}
+
+	// SchedulingState is the part of a card changed by a review.
+	SchedulingState struct {
+		ConsecutiveCorrectAnswersNumber uint32
+		NextDueDate                     time.Time
+		LastReviewedAt                  time.Time
+		MemoryState                     MemoryState
+		Learning                        LearningState
+		Lapses                          uint32
+		Leech                           bool
+		Suspended                       bool
+		Learnt                          bool
+		LearntAt                        time.Time
+		StartedLearningAt               time.Time
+	}
 
 	UserSession struct {
 		ID      string     `json:"id"`
@@ -91,6 +311,75 @@ type (
 	}
 )
 
+// ErrVersionConflict is returned on saving a card changed or deleted since it has been read.
+var ErrVersionConflict = errors.New("version conflict")
+
+// ErrDuplicateWord is returned on saving a card having a word of another card of the user in the same language.
+var ErrDuplicateWord = errors.New("duplicate word")
+
+// LearningPhase tells which steps a card is scheduled with.
+type LearningPhase string
+
+const (
+	// LearningPhaseNone is the phase of the new cards and the cards scheduled in days.
+	LearningPhaseNone       LearningPhase = ""
+	LearningPhaseLearning   LearningPhase = "learning"
+	LearningPhaseRelearning LearningPhase = "relearning"
+)
+
+// NoteType is the kind of a card, it tells what the card holds and how it's asked.
+type NoteType string
+
+const (
+	// NoteTypeWords cards hold the words with their translations, the words are asked by their translations.
+	NoteTypeWords NoteType = "words"
+	// NoteTypePhrase cards hold a phrase or an idiom with its meaning, the phrase is asked by its meaning.
+	NoteTypePhrase NoteType = "phrase"
+	// NoteTypeCloze cards hold a sentence with the deleted parts in double braces, e.g. "I {{have been}} here",
+	// the deleted parts are asked with the rest of the sentence shown.
+	NoteTypeCloze NoteType = "cloze"
+	// NoteTypeGrammar cards hold a grammar prompt and the answer to it.
+	NoteTypeGrammar NoteType = "grammar"
+)
+
+// NoteTypes are all the note types.
+var NoteTypes = []NoteType{NoteTypeWords, NoteTypePhrase, NoteTypeCloze, NoteTypeGrammar}
+
+// ReviewDirection is the way a card is asked, every direction of a card has its own schedule.
+type ReviewDirection string
+
+const (
+	// DirectionProduction asks the word by its translation, every card is reviewed in this direction.
+	DirectionProduction ReviewDirection = "production"
+	// DirectionRecognition asks the translation of the word.
+	DirectionRecognition ReviewDirection = "recognition"
+	// DirectionListening asks the word by its audio.
+	DirectionListening ReviewDirection = "listening"
+)
+
+// ReviewDirections are all the review directions.
+var ReviewDirections = []ReviewDirection{DirectionProduction, DirectionRecognition, DirectionListening}
+
+// OrProduction returns the direction, the empty direction of the cards and the reviews stored before
+// the directions is production.
+func (d ReviewDirection) OrProduction() ReviewDirection {
+	if d == "" {
+		return DirectionProduction
+	}
+	return d
+}
+
+// Delimiters of the deleted parts of a cloze sentence.
+const (
+	ClozeOpen  = "{{"
+	ClozeClose = "}}"
+)
+
+// InSteps reports whether the card is scheduled with the learning or relearning steps.
+func (s LearningState) InSteps() bool {
+	return s.Phase != LearningPhaseNone
+}
+
 func NewUserSession(userID string) UserSession {
 	return UserSession{
 		ID:      uuid.NewString(),
@@ -99,27 +388,329 @@ func NewUserSession(userID string) UserSession {
 	}
 }
 
-// todo: receive an user time zone. time.Now must be replaced with time.Now().In(userTimeZone)
-func (c *Card) NeedToRepeat() bool {
-	if c.Learnt {
+// NeedToRepeat reports whether the card is due during the current day of the user,
+// the cards in the learning steps are due since the moment their step ends.
+func (c *Card) NeedToRepeat(profile Profile) bool {
+	if c.Learnt || c.Suspended || c.Buried() {
 		return false
 	}
 	if c.NextDueDate.IsZero() {
 		return false
 	}
-	return time.Now().UTC().After(c.NextDueDate.UTC())
+	if c.Learning.InSteps() {
+		return !c.NextDueDate.After(time.Now())
+	}
+	return c.NextDueDate.Before(profile.StartOfDay(time.Now()).AddDate(0, 0, 1))
 }
 
 func (c *Card) NeedToLearn() bool {
-	return !c.Learnt && c.NextDueDate.IsZero()
+	return !c.Learnt && !c.Suspended && !c.Buried() && c.NextDueDate.IsZero()
+}
+
+// Relearn returns the card to the repetition due at the given time, even if it has been learnt.
+// The streak is reset, the memory state is kept for the memory-model schedulers to lapse it on the next answer.
+func (c *Card) Relearn(due time.Time) {
+	c.Learnt = false
+	c.LearntAt = time.Time{}
+	c.ConsecutiveCorrectAnswersNumber = 0
+	c.NextDueDate = due
+	c.Learning = LearningState{}
+}
+
+// Forget returns the card to the new cards to learn, its schedule is cleared, the lapses are kept.
+func (c *Card) Forget() {
+	c.Learnt = false
+	c.LearntAt = time.Time{}
+	c.ConsecutiveCorrectAnswersNumber = 0
+	c.NextDueDate = time.Time{}
+	c.LastReviewedAt = time.Time{}
+	c.MemoryState = MemoryState{}
+	c.Learning = LearningState{}
+	c.StartedLearningAt = time.Time{}
+}
+
+// Buried reports whether the card is hidden until the start of the user's next day.
+func (c *Card) Buried() bool {
+	return c.BuriedUntil.After(time.Now())
+}
+
+// HasTag reports whether the card is tagged with the tag.
+func (c *Card) HasTag(tag string) bool {
+	return slices.ContainsFunc(c.Tags, func(t string) bool {
+		return strings.EqualFold(t, tag)
+	})
 }
 
+// AddTags tags the card with the tags it doesn't have yet.
+func (c *Card) AddTags(tags ...string) {
+	for _, tag := range tags {
+		if !c.HasTag(tag) {
+			c.Tags = append(c.Tags, tag)
+		}
+	}
+}
+
+// RemoveTags removes the tags from the card.
+func (c *Card) RemoveTags(tags ...string) {
+	c.Tags = slices.DeleteFunc(c.Tags, func(t string) bool {
+		return slices.ContainsFunc(tags, func(tag string) bool {
+			return strings.EqualFold(t, tag)
+		})
+	})
+}
+
+// LastChecked returns the time the learnt card has been reviewed the last time,
+// the time it has been learnt if it hasn't been reviewed since.
+func (c *Card) LastChecked() time.Time {
+	if c.LastReviewedAt.After(c.LearntAt) {
+		return c.LastReviewedAt
+	}
+
+	return c.LearntAt
+}
+
+// AddAnswer updates the streak of the card, a wrong answer to a card already scheduled counts as a lapse.
 func (c *Card) AddAnswer(correct bool) {
 	if correct {
 		c.ConsecutiveCorrectAnswersNumber++
 	} else {
 		c.ConsecutiveCorrectAnswersNumber = 0
+		if !c.NextDueDate.IsZero() {
+			c.Lapses++
+		}
+	}
+}
+
+func (c *Card) SchedulingState() SchedulingState {
+	return SchedulingState{
+		ConsecutiveCorrectAnswersNumber: c.ConsecutiveCorrectAnswersNumber,
+		NextDueDate:                     c.NextDueDate,
+		LastReviewedAt:                  c.LastReviewedAt,
+		MemoryState:                     c.MemoryState,
+		Learning:                        c.Learning,
+		Lapses:                          c.Lapses,
+		Leech:                           c.Leech,
+		Suspended:                       c.Suspended,
+		Learnt:                          c.Learnt,
+		LearntAt:                        c.LearntAt,
+		StartedLearningAt:               c.StartedLearningAt,
+	}
+}
+
+func (c *Card) RestoreSchedulingState(state SchedulingState) {
+	c.ConsecutiveCorrectAnswersNumber = state.ConsecutiveCorrectAnswersNumber
+	c.NextDueDate = state.NextDueDate
+	c.LastReviewedAt = state.LastReviewedAt
+	c.MemoryState = state.MemoryState
+	c.Learning = state.Learning
+	c.Lapses = state.Lapses
+	c.Leech = state.Leech
+	c.Suspended = state.Suspended
+	c.Learnt = state.Learnt
+	c.LearntAt = state.LearntAt
+	c.StartedLearningAt = state.StartedLearningAt
+}
+
+// Matches reports whether the card is in the deck of the filter and has all its tags.
+func (f CardFilter) Matches(card Card) bool {
+	if f.Deck != "" && !strings.EqualFold(card.Deck, f.Deck) {
+		return false
+	}
+
+	return !slices.ContainsFunc(f.Tags, func(tag string) bool {
+		return !card.HasTag(tag)
+	})
+}
+
+// SupportsDirection reports whether the card can be reviewed in the direction: every card is reviewed
+// in production, the word cards in recognition too and the word cards having audio in listening.
+func (c *Card) SupportsDirection(direction ReviewDirection) bool {
+	switch direction.OrProduction() {
+	case DirectionProduction:
+		return true
+	case DirectionRecognition:
+		return c.NoteType() == NoteTypeWords
+	case DirectionListening:
+		return c.NoteType() == NoteTypeWords && slices.ContainsFunc(c.WordInformationList, func(word WordInformation) bool {
+			return len(word.AudioIDs) != 0
+		})
+	default:
+		return false
+	}
+}
+
+// InDirection returns the card scheduled in the direction, the card's schedule is replaced with the one
+// of the direction. The directions not started yet have the schedule of a new card.
+func (c *Card) InDirection(direction ReviewDirection) Card {
+	view := *c
+	view.Direction = direction.OrProduction()
+	if view.Direction == DirectionProduction {
+		return view
+	}
+
+	view.RestoreSchedulingState(c.Directions[view.Direction])
+	view.Suspended = c.Suspended
+	view.Leech = c.Leech
+	view.Learnt = c.Learnt
+	view.LearntAt = c.LearntAt
+
+	return view
+}
+
+// NeedToRepeatIn reports whether the card is due in the direction. A direction not started yet is due
+// as soon as the card is out of the learning steps of production, so it's introduced by the reviews.
+func (c *Card) NeedToRepeatIn(direction ReviewDirection, profile Profile) bool {
+	direction = direction.OrProduction()
+	if direction == DirectionProduction {
+		return c.NeedToRepeat(profile)
+	}
+	if !c.SupportsDirection(direction) {
+		return false
+	}
+
+	view := c.InDirection(direction)
+	if view.NextDueDate.IsZero() {
+		return !c.Learnt && !c.Suspended && !c.Buried() && !c.NextDueDate.IsZero() && !c.Learning.InSteps()
+	}
+	return view.NeedToRepeat(profile)
+}
+
+// SetDirectionSchedule stores the schedule of the card reviewed in the direction, see InDirection.
+// The suspension and the leech flag of the reviewed card are the card's ones.
+func (c *Card) SetDirectionSchedule(direction ReviewDirection, reviewed Card) {
+	direction = direction.OrProduction()
+	if direction == DirectionProduction {
+		c.RestoreSchedulingState(reviewed.SchedulingState())
+		return
+	}
+
+	if c.Directions == nil {
+		c.Directions = make(map[ReviewDirection]SchedulingState)
 	}
+	c.Directions[direction] = reviewed.SchedulingState()
+	c.Suspended = reviewed.Suspended
+	c.Leech = reviewed.Leech
+}
+
+// NoteType returns the type of the card, the cards stored before the note types are the word cards.
+func (c *Card) NoteType() NoteType {
+	if c.Type == "" {
+		return NoteTypeWords
+	}
+	return c.Type
+}
+
+func (n Note) IsZero() bool {
+	return n == Note{}
+}
+
+// ClozeDeletions returns the deleted parts of the cloze sentence in their order, the sentence is invalid
+// if a deletion is empty, nested or not closed.
+func ClozeDeletions(text string) ([]string, error) {
+	var deletions []string
+	for rest := text; ; {
+		before, after, found := strings.Cut(rest, ClozeOpen)
+		if strings.Contains(before, ClozeClose) {
+			return nil, fmt.Errorf("%s without %s", ClozeClose, ClozeOpen)
+		}
+		if !found {
+			return deletions, nil
+		}
+
+		deletion, tail, closed := strings.Cut(after, ClozeClose)
+		switch {
+		case !closed:
+			return nil, fmt.Errorf("%s is not closed", ClozeOpen)
+		case strings.Contains(deletion, ClozeOpen):
+			return nil, errors.New("nested deletions are not allowed")
+		case strings.TrimSpace(deletion) == "":
+			return nil, errors.New("empty deletion")
+		}
+
+		deletions = append(deletions, strings.TrimSpace(deletion))
+		rest = tail
+	}
+}
+
+// ReplaceClozeDeletions returns the cloze sentence with every deleted part replaced by the result of replace,
+// e.g. with a blank to ask the deletions or with the deletion itself to show the answer.
+// The sentence is expected to be valid.
+func ReplaceClozeDeletions(text string, replace func(deletion string) string) string {
+	var out strings.Builder
+	for rest := text; ; {
+		before, after, found := strings.Cut(rest, ClozeOpen)
+		out.WriteString(before)
+		if !found {
+			return out.String()
+		}
+
+		deletion, tail, _ := strings.Cut(after, ClozeClose)
+		out.WriteString(replace(strings.TrimSpace(deletion)))
+		rest = tail
+	}
+}
+
+// AudioID returns the content-addressed ID of the audio, the same audio always gets the same ID,
+// so the words sounding the same share it in the audio store.
+func AudioID(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Graduates reports whether the card just answered at reviewedAt is learnt by the policy,
+// the cards in the learning steps never graduate.
+func (p GraduationPolicy) Graduates(card Card, reviewedAt time.Time) bool {
+	if card.Learnt || card.Learning.InSteps() || card.NextDueDate.IsZero() {
+		return false
+	}
+
+	return (p.CorrectAnswers > 0 && card.ConsecutiveCorrectAnswersNumber >= p.CorrectAnswers) ||
+		(p.Interval > 0 && card.NextDueDate.Sub(reviewedAt) >= p.Interval)
+}
+
+// EnabledDirections returns the directions the user reviews the cards in, production is always the first one.
+func (p Profile) EnabledDirections() []ReviewDirection {
+	directions := []ReviewDirection{DirectionProduction}
+	for _, direction := range p.Directions {
+		if !slices.Contains(directions, direction) {
+			directions = append(directions, direction)
+		}
+	}
+
+	return directions
+}
+
+// Location returns the time zone of the user, UTC if the time zone is not set or unknown.
+func (p Profile) Location() *time.Location {
+	if p.TimeZone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(p.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Now returns the current time in the time zone of the user.
+func (p Profile) Now() time.Time {
+	return time.Now().In(p.Location())
+}
+
+// StartOfDay returns the moment the user's day containing t has started at. The day start is a wall clock
+// time, so the day starts at the same local time on the days the clocks are changed.
+func (p Profile) StartOfDay(t time.Time) time.Time {
+	local := t.In(p.Location())
+	hour, minute := int(p.DayStart/time.Hour), int(p.DayStart%time.Hour/time.Minute)
+	start := time.Date(local.Year(), local.Month(), local.Day(), hour, minute, 0, 0, local.Location())
+	if local.Before(start) {
+		start = time.Date(local.Year(), local.Month(), local.Day()-1, hour, minute, 0, 0, local.Location())
+	}
+	return start
+}
+
+func (s MemoryState) IsZero() bool {
+	return s.Stability == 0 && s.Difficulty == 0
 }
 
 func (c *Card) GetConsecutiveCorrectAnswersNumber() uint32 {
@@ -1,10 +1,12 @@
 package entity_test
 
 import (
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/genvmoroz/lale/service/pkg/entity"
+	"golang.org/x/text/language"
 )
 
 func TestCard_NeedToLearn(t *testing.T) {
@@ -37,6 +39,21 @@ func TestCard_NeedToLearn(t *testing.T) {
 			card: entity.Card{Learnt: true, NextDueDate: tnow.Add(time.Hour)},
 			want: false,
 		},
+		{
+			name: "suspended new card",
+			card: entity.Card{Suspended: true},
+			want: false,
+		},
+		{
+			name: "buried new card",
+			card: entity.Card{BuriedUntil: tnow.Add(time.Hour)},
+			want: false,
+		},
+		{
+			name: "new card buried yesterday",
+			card: entity.Card{BuriedUntil: tnow.Add(-time.Hour)},
+			want: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -49,6 +66,164 @@ func TestCard_NeedToLearn(t *testing.T) {
 	}
 }
 
+func TestCard_AddAnswer(t *testing.T) {
+	t.Parallel()
+
+	card := entity.Card{}
+	card.AddAnswer(false)
+	if card.Lapses != 0 {
+		t.Fatalf("Lapses = %d after failing a new card, want 0", card.Lapses)
+	}
+
+	card.AddAnswer(true)
+	card.NextDueDate = time.Now().Add(24 * time.Hour)
+	card.AddAnswer(true)
+	if card.ConsecutiveCorrectAnswersNumber != 2 {
+		t.Fatalf("ConsecutiveCorrectAnswersNumber = %d, want 2", card.ConsecutiveCorrectAnswersNumber)
+	}
+
+	card.AddAnswer(false)
+	if card.ConsecutiveCorrectAnswersNumber != 0 || card.Lapses != 1 {
+		t.Fatalf("after a lapse streak = %d, lapses = %d, want 0 and 1", card.ConsecutiveCorrectAnswersNumber, card.Lapses)
+	}
+}
+
+func TestGraduationPolicy_Graduates(t *testing.T) {
+	t.Parallel()
+
+	reviewedAt := time.Now().UTC()
+	card := func(correctAnswers uint32, interval time.Duration) entity.Card {
+		return entity.Card{ConsecutiveCorrectAnswersNumber: correctAnswers, NextDueDate: reviewedAt.Add(interval)}
+	}
+	const day = 24 * time.Hour
+
+	testcases := map[string]struct {
+		policy entity.GraduationPolicy
+		card   entity.Card
+		want   bool
+	}{
+		"disabled": {
+			card: card(10, 100*day),
+		},
+		"correct answers reached": {
+			policy: entity.GraduationPolicy{CorrectAnswers: 5},
+			card:   card(5, 10*day),
+			want:   true,
+		},
+		"correct answers not reached": {
+			policy: entity.GraduationPolicy{CorrectAnswers: 5},
+			card:   card(4, 10*day),
+		},
+		"interval reached": {
+			policy: entity.GraduationPolicy{Interval: 60 * day},
+			card:   card(1, 60*day),
+			want:   true,
+		},
+		"interval not reached": {
+			policy: entity.GraduationPolicy{CorrectAnswers: 5, Interval: 60 * day},
+			card:   card(1, 59*day),
+		},
+		"in learning steps": {
+			policy: entity.GraduationPolicy{CorrectAnswers: 1},
+			card: entity.Card{
+				ConsecutiveCorrectAnswersNumber: 1,
+				NextDueDate:                     reviewedAt.Add(time.Hour),
+				Learning:                        entity.LearningState{Phase: entity.LearningPhaseRelearning},
+			},
+		},
+	}
+	for name, testcase := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := testcase.policy.Graduates(testcase.card, reviewedAt); got != testcase.want {
+				t.Fatalf("Graduates() = %v, want %v", got, testcase.want)
+			}
+		})
+	}
+}
+
+func TestCard_Tags(t *testing.T) {
+	t.Parallel()
+
+	card := entity.Card{Tags: []string{"work"}}
+	card.AddTags("Work", "book:Dune", "travel")
+	if want := []string{"work", "book:Dune", "travel"}; !slices.Equal(card.Tags, want) {
+		t.Fatalf("AddTags() = %v, want %v", card.Tags, want)
+	}
+
+	card.RemoveTags("WORK", "unknown")
+	if want := []string{"book:Dune", "travel"}; !slices.Equal(card.Tags, want) {
+		t.Fatalf("RemoveTags() = %v, want %v", card.Tags, want)
+	}
+}
+
+func TestCardFilter_Matches(t *testing.T) {
+	t.Parallel()
+
+	card := entity.Card{Tags: []string{"travel", "book:Dune"}, Deck: "Spanish trip"}
+
+	testcases := map[string]struct {
+		filter entity.CardFilter
+		want   bool
+	}{
+		"zero filter":       {want: true},
+		"deck":              {filter: entity.CardFilter{Deck: "spanish trip"}, want: true},
+		"another deck":      {filter: entity.CardFilter{Deck: "work"}},
+		"all tags":          {filter: entity.CardFilter{Tags: []string{"Book:Dune", "travel"}}, want: true},
+		"missing tag":       {filter: entity.CardFilter{Tags: []string{"travel", "work"}}},
+		"deck and tags":     {filter: entity.CardFilter{Deck: "Spanish trip", Tags: []string{"travel"}}, want: true},
+		"deck, missing tag": {filter: entity.CardFilter{Deck: "Spanish trip", Tags: []string{"work"}}},
+	}
+	for name, testcase := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := testcase.filter.Matches(card); got != testcase.want {
+				t.Fatalf("Matches() = %v, want %v", got, testcase.want)
+			}
+		})
+	}
+}
+
+func TestCard_Reset(t *testing.T) {
+	t.Parallel()
+
+	tnow := time.Now().UTC()
+	learnt := func() entity.Card {
+		return entity.Card{
+			ConsecutiveCorrectAnswersNumber: 5,
+			NextDueDate:                     tnow.Add(30 * 24 * time.Hour),
+			LastReviewedAt:                  tnow.Add(-time.Hour),
+			MemoryState:                     entity.MemoryState{Stability: 30, Difficulty: 4},
+			Lapses:                          2,
+			Learnt:                          true,
+			LearntAt:                        tnow,
+			StartedLearningAt:               tnow.Add(-60 * 24 * time.Hour),
+		}
+	}
+
+	relearnt := learnt()
+	relearnt.Relearn(tnow)
+	if !relearnt.NeedToRepeat(entity.Profile{}) || relearnt.NeedToLearn() {
+		t.Fatalf("Relearn() card is not due for repeat: %+v", relearnt)
+	}
+	if relearnt.ConsecutiveCorrectAnswersNumber != 0 || relearnt.MemoryState.IsZero() {
+		t.Fatalf("Relearn() streak = %d, memory state = %+v, want the streak reset and the memory state kept",
+			relearnt.ConsecutiveCorrectAnswersNumber, relearnt.MemoryState)
+	}
+
+	forgotten := learnt()
+	forgotten.Forget()
+	if !forgotten.NeedToLearn() {
+		t.Fatalf("Forget() card is not new: %+v", forgotten)
+	}
+	if !forgotten.LastReviewedAt.IsZero() || !forgotten.MemoryState.IsZero() ||
+		!forgotten.StartedLearningAt.IsZero() || forgotten.Lapses != 2 {
+		t.Fatalf("Forget() = %+v, want the schedule cleared and the lapses kept", forgotten)
+	}
+}
+
 func TestCard_NeedToRepeat(t *testing.T) {
 	t.Parallel()
 
@@ -69,9 +244,14 @@ func TestCard_NeedToRepeat(t *testing.T) {
 			card: entity.Card{NextDueDate: tnow.Add(-time.Hour)},
 			want: true,
 		},
+		{
+			name: "due later today",
+			card: entity.Card{NextDueDate: tnow.Truncate(24 * time.Hour).Add(24*time.Hour - time.Nanosecond)},
+			want: true,
+		},
 		{
 			name: "not yet due",
-			card: entity.Card{NextDueDate: tnow.Add(time.Hour)},
+			card: entity.Card{NextDueDate: tnow.Truncate(24 * time.Hour).Add(24 * time.Hour)},
 			want: false,
 		},
 		{
@@ -79,14 +259,376 @@ func TestCard_NeedToRepeat(t *testing.T) {
 			card: entity.Card{Learnt: true, NextDueDate: tnow.Add(-time.Hour)},
 			want: false,
 		},
+		{
+			name: "due but suspended",
+			card: entity.Card{Suspended: true, NextDueDate: tnow.Add(-time.Hour)},
+			want: false,
+		},
+		{
+			name: "due but buried",
+			card: entity.Card{BuriedUntil: tnow.Add(time.Hour), NextDueDate: tnow.Add(-time.Hour)},
+			want: false,
+		},
+		{
+			name: "learning step ended",
+			card: entity.Card{
+				NextDueDate: tnow.Add(-time.Minute),
+				Learning:    entity.LearningState{Phase: entity.LearningPhaseLearning},
+			},
+			want: true,
+		},
+		{
+			name: "learning step not ended",
+			card: entity.Card{
+				NextDueDate: tnow.Add(10 * time.Minute),
+				Learning:    entity.LearningState{Phase: entity.LearningPhaseRelearning},
+			},
+			want: false,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			if got := tt.card.NeedToRepeat(); got != tt.want {
+			if got := tt.card.NeedToRepeat(entity.Profile{}); got != tt.want {
 				t.Fatalf("NeedToRepeat() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestCard_RestoreSchedulingState(t *testing.T) {
+	t.Parallel()
+
+	tnow := time.Now().UTC()
+
+	card := entity.Card{
+		ID:                              "CardID",
+		ConsecutiveCorrectAnswersNumber: 3,
+		NextDueDate:                     tnow.Add(24 * time.Hour),
+		LastReviewedAt:                  tnow.Add(-24 * time.Hour),
+		MemoryState:                     entity.MemoryState{Stability: 2, Difficulty: 5},
+		Learning:                        entity.LearningState{Phase: entity.LearningPhaseLearning, Step: 1},
+	}
+	before := card.SchedulingState()
+
+	card.StartedLearningAt = tnow
+	card.AddAnswer(false)
+	card.Leech, card.Suspended = true, true
+	card.NextDueDate = tnow.Add(time.Hour)
+	card.LastReviewedAt = tnow
+	card.MemoryState = entity.MemoryState{Stability: 1, Difficulty: 6}
+	card.Learning = entity.LearningState{}
+
+	card.RestoreSchedulingState(before)
+	if got := card.SchedulingState(); got != before {
+		t.Fatalf("SchedulingState() = %v, want %v", got, before)
+	}
+	if card.ID != "CardID" {
+		t.Fatalf("RestoreSchedulingState() changed card ID to %q", card.ID)
+	}
+}
+
+func TestProfile_StartOfDay(t *testing.T) {
+	t.Parallel()
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		profile entity.Profile
+		time    time.Time
+		want    time.Time
+	}{
+		{
+			name:    "default profile",
+			profile: entity.Profile{},
+			time:    time.Date(2024, time.March, 10, 15, 30, 0, 0, time.UTC),
+			want:    time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "time zone",
+			profile: entity.Profile{TimeZone: "Asia/Tokyo"},
+			time:    time.Date(2024, time.March, 10, 15, 30, 0, 0, time.UTC),
+			want:    time.Date(2024, time.March, 11, 0, 0, 0, 0, tokyo),
+		},
+		{
+			name:    "after the day start",
+			profile: entity.Profile{TimeZone: "Asia/Tokyo", DayStart: 4 * time.Hour},
+			time:    time.Date(2024, time.March, 11, 5, 0, 0, 0, tokyo),
+			want:    time.Date(2024, time.March, 11, 4, 0, 0, 0, tokyo),
+		},
+		{
+			name:    "before the day start",
+			profile: entity.Profile{TimeZone: "Asia/Tokyo", DayStart: 4 * time.Hour},
+			time:    time.Date(2024, time.March, 11, 2, 0, 0, 0, tokyo),
+			want:    time.Date(2024, time.March, 10, 4, 0, 0, 0, tokyo),
+		},
+		{
+			name:    "clocks changed forward",
+			profile: entity.Profile{TimeZone: "Europe/Berlin", DayStart: 4 * time.Hour},
+			time:    time.Date(2024, time.March, 31, 4, 30, 0, 0, berlin),
+			want:    time.Date(2024, time.March, 31, 4, 0, 0, 0, berlin),
+		},
+		{
+			name:    "clocks changed back",
+			profile: entity.Profile{TimeZone: "Europe/Berlin", DayStart: 4 * time.Hour},
+			time:    time.Date(2024, time.October, 27, 12, 0, 0, 0, berlin),
+			want:    time.Date(2024, time.October, 27, 4, 0, 0, 0, berlin),
+		},
+		{
+			name:    "unknown time zone",
+			profile: entity.Profile{TimeZone: "Nowhere/Unknown"},
+			time:    time.Date(2024, time.March, 10, 15, 30, 0, 0, time.UTC),
+			want:    time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.profile.StartOfDay(tt.time); !got.Equal(tt.want) {
+				t.Fatalf("StartOfDay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCard_NoteType(t *testing.T) {
+	t.Parallel()
+
+	if got := (&entity.Card{}).NoteType(); got != entity.NoteTypeWords {
+		t.Fatalf("NoteType() of a card without type = %s, want %s", got, entity.NoteTypeWords)
+	}
+	if got := (&entity.Card{Type: entity.NoteTypeCloze}).NoteType(); got != entity.NoteTypeCloze {
+		t.Fatalf("NoteType() = %s, want %s", got, entity.NoteTypeCloze)
+	}
+}
+
+func TestClozeDeletions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		text    string
+		want    []string
+		wantErr bool
+	}{
+		{name: "no deletions", text: "plain sentence", want: nil},
+		{name: "one deletion", text: "I {{have been}} here", want: []string{"have been"}},
+		{name: "two deletions", text: "{{If}} I were you, I {{would}} go", want: []string{"If", "would"}},
+		{name: "trimmed deletion", text: "I {{ am }} here", want: []string{"am"}},
+		{name: "empty deletion", text: "I {{ }} here", wantErr: true},
+		{name: "not closed", text: "I {{am here", wantErr: true},
+		{name: "not opened", text: "I am}} here", wantErr: true},
+		{name: "nested", text: "I {{a {{m}} }} here", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := entity.ClozeDeletions(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ClozeDeletions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("ClozeDeletions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplaceClozeDeletions(t *testing.T) {
+	t.Parallel()
+
+	text := "{{If}} I were you, I {{ would }} go"
+	got := entity.ReplaceClozeDeletions(text, func(string) string { return "___" })
+	if want := "___ I were you, I ___ go"; got != want {
+		t.Fatalf("ReplaceClozeDeletions() = %q, want %q", got, want)
+	}
+
+	got = entity.ReplaceClozeDeletions(text, func(deletion string) string { return "[" + deletion + "]" })
+	if want := "[If] I were you, I [would] go"; got != want {
+		t.Fatalf("ReplaceClozeDeletions() = %q, want %q", got, want)
+	}
+}
+
+func TestAudioID(t *testing.T) {
+	t.Parallel()
+
+	id := entity.AudioID([]byte("audio"))
+	if want := "6ed8919ce20490a5e3ad8630a4fab69475297abd07db73918dd5f36fcfaeb11b"; id != want {
+		t.Fatalf("AudioID() = %q, want %q", id, want)
+	}
+	if got := entity.AudioID([]byte("other")); got == id {
+		t.Fatalf("AudioID() = %q for different audio", got)
+	}
+}
+
+func TestCard_NeedToRepeatIn(t *testing.T) {
+	t.Parallel()
+
+	tnow := time.Now().UTC()
+	words := []entity.WordInformation{{Word: "word"}}
+
+	tests := []struct {
+		name      string
+		card      entity.Card
+		direction entity.ReviewDirection
+		want      bool
+	}{
+		{
+			name:      "production due",
+			card:      entity.Card{WordInformationList: words, NextDueDate: tnow.Add(-time.Hour)},
+			direction: entity.DirectionProduction,
+			want:      true,
+		},
+		{
+			name:      "recognition not started, production new",
+			card:      entity.Card{WordInformationList: words},
+			direction: entity.DirectionRecognition,
+			want:      false,
+		},
+		{
+			name: "recognition not started, production in steps",
+			card: entity.Card{
+				WordInformationList: words,
+				NextDueDate:         tnow.Add(time.Minute),
+				Learning:            entity.LearningState{Phase: entity.LearningPhaseLearning},
+			},
+			direction: entity.DirectionRecognition,
+			want:      false,
+		},
+		{
+			name:      "recognition not started, production scheduled",
+			card:      entity.Card{WordInformationList: words, NextDueDate: tnow.Add(72 * time.Hour)},
+			direction: entity.DirectionRecognition,
+			want:      true,
+		},
+		{
+			name: "recognition not due",
+			card: entity.Card{
+				WordInformationList: words,
+				NextDueDate:         tnow.Add(-time.Hour),
+				Directions: map[entity.ReviewDirection]entity.SchedulingState{
+					entity.DirectionRecognition: {NextDueDate: tnow.Add(72 * time.Hour)},
+				},
+			},
+			direction: entity.DirectionRecognition,
+			want:      false,
+		},
+		{
+			name: "recognition due but suspended",
+			card: entity.Card{
+				WordInformationList: words,
+				Suspended:           true,
+				Directions: map[entity.ReviewDirection]entity.SchedulingState{
+					entity.DirectionRecognition: {NextDueDate: tnow.Add(-time.Hour)},
+				},
+			},
+			direction: entity.DirectionRecognition,
+			want:      false,
+		},
+		{
+			name:      "listening without audio",
+			card:      entity.Card{WordInformationList: words, NextDueDate: tnow.Add(72 * time.Hour)},
+			direction: entity.DirectionListening,
+			want:      false,
+		},
+		{
+			name: "listening with audio",
+			card: entity.Card{
+				WordInformationList: []entity.WordInformation{
+					{Word: "word", AudioIDs: map[string]string{"en-GB": "id"}},
+				},
+				NextDueDate: tnow.Add(72 * time.Hour),
+			},
+			direction: entity.DirectionListening,
+			want:      true,
+		},
+		{
+			name: "recognition of a phrase",
+			card: entity.Card{
+				Type:        entity.NoteTypePhrase,
+				NextDueDate: tnow.Add(72 * time.Hour),
+			},
+			direction: entity.DirectionRecognition,
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			card := tt.card
+			card.Language = language.English
+			if got := card.NeedToRepeatIn(tt.direction, entity.Profile{}); got != tt.want {
+				t.Fatalf("NeedToRepeatIn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCard_SetDirectionSchedule(t *testing.T) {
+	t.Parallel()
+
+	tnow := time.Now().UTC()
+
+	card := entity.Card{
+		ID:                              "CardID",
+		ConsecutiveCorrectAnswersNumber: 3,
+		NextDueDate:                     tnow.Add(72 * time.Hour),
+		LastReviewedAt:                  tnow.Add(-24 * time.Hour),
+	}
+	production := card.SchedulingState()
+
+	view := card.InDirection(entity.DirectionRecognition)
+	if view.Direction != entity.DirectionRecognition {
+		t.Fatalf("InDirection() direction = %q, want %q", view.Direction, entity.DirectionRecognition)
+	}
+	if view.SchedulingState() != (entity.SchedulingState{}) {
+		t.Fatalf("InDirection() of a new direction = %v, want a new schedule", view.SchedulingState())
+	}
+
+	view.AddAnswer(true)
+	view.NextDueDate = tnow.Add(24 * time.Hour)
+	view.LastReviewedAt = tnow
+	view.Leech = true
+	card.SetDirectionSchedule(entity.DirectionRecognition, view)
+
+	if got := card.SchedulingState(); got.NextDueDate != production.NextDueDate ||
+		got.ConsecutiveCorrectAnswersNumber != production.ConsecutiveCorrectAnswersNumber {
+		t.Fatalf("SetDirectionSchedule() changed the production schedule to %v", got)
+	}
+	if !card.Leech {
+		t.Fatal("SetDirectionSchedule() didn't carry the leech flag over to the card")
+	}
+	recognition := card.InDirection(entity.DirectionRecognition)
+	if got := recognition.SchedulingState(); got != view.SchedulingState() {
+		t.Fatalf("InDirection() = %v, want %v", got, view.SchedulingState())
+	}
+	if card.Direction != "" {
+		t.Fatalf("SetDirectionSchedule() set the card direction to %q", card.Direction)
+	}
+}
+
+func TestProfile_EnabledDirections(t *testing.T) {
+	t.Parallel()
+
+	profile := entity.Profile{
+		Directions: []entity.ReviewDirection{entity.DirectionListening, entity.DirectionProduction},
+	}
+	want := []entity.ReviewDirection{entity.DirectionProduction, entity.DirectionListening}
+	if got := profile.EnabledDirections(); !slices.Equal(got, want) {
+		t.Fatalf("EnabledDirections() = %v, want %v", got, want)
+	}
+}
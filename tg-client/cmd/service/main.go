@@ -11,20 +11,31 @@ import (
 	"sync"
 	"syscall"
 	"time"
+	_ "time/tzdata" // time zones sent to /profile are validated without relying on the tzdata of the image
 
 	"github.com/genvmoroz/bot-engine/dispatcher"
 	"github.com/genvmoroz/bot-engine/processor"
-	"github.com/genvmoroz/bot-engine/tg"
+	"github.com/genvmoroz/lale-tg-client/internal/bot"
 	"github.com/genvmoroz/lale-tg-client/internal/options"
 	"github.com/genvmoroz/lale-tg-client/internal/repository"
+	"github.com/genvmoroz/lale-tg-client/internal/state/bury"
 	createstate "github.com/genvmoroz/lale-tg-client/internal/state/create"
+	"github.com/genvmoroz/lale-tg-client/internal/state/deck"
 	getallstate "github.com/genvmoroz/lale-tg-client/internal/state/getall"
+	"github.com/genvmoroz/lale-tg-client/internal/state/graduation"
 	helpstate "github.com/genvmoroz/lale-tg-client/internal/state/help"
 	inspectstate "github.com/genvmoroz/lale-tg-client/internal/state/inspect"
 	"github.com/genvmoroz/lale-tg-client/internal/state/learn"
 	learntstate "github.com/genvmoroz/lale-tg-client/internal/state/learnt"
+	"github.com/genvmoroz/lale-tg-client/internal/state/leeches"
+	"github.com/genvmoroz/lale-tg-client/internal/state/limits"
+	"github.com/genvmoroz/lale-tg-client/internal/state/profile"
 	"github.com/genvmoroz/lale-tg-client/internal/state/repeat"
+	"github.com/genvmoroz/lale-tg-client/internal/state/reset"
 	"github.com/genvmoroz/lale-tg-client/internal/state/story"
+	"github.com/genvmoroz/lale-tg-client/internal/state/suspend"
+	"github.com/genvmoroz/lale-tg-client/internal/state/tag"
+	"github.com/genvmoroz/lale-tg-client/internal/state/undo"
 	"github.com/genvmoroz/lale-tg-client/internal/state/update"
 	"github.com/sirupsen/logrus"
 )
@@ -54,7 +65,7 @@ func launch() error {
 
 	logrus.SetLevel(cfg.LogLevel)
 
-	baseBot, err := tg.NewClient(cfg.TelegramToken)
+	baseBot, err := bot.NewClient(cfg.TelegramToken)
 	if err != nil {
 		return err
 	}
@@ -70,14 +81,28 @@ func launch() error {
 	}
 
 	states := map[string]processor.StateProcessor{
-		createstate.Command:  createstate.NewState(laleRepo),
-		inspectstate.Command: inspectstate.NewState(laleRepo),
-		getallstate.Command:  getallstate.NewState(laleRepo),
-		learntstate.Command:  learntstate.NewState(laleRepo),
-		repeat.Command:       repeat.NewState(laleRepo),
-		story.Command:        story.NewState(laleRepo),
-		learn.Command:        learn.NewState(laleRepo),
-		update.Command:       update.NewState(laleRepo),
+		createstate.Command:      createstate.NewState(laleRepo),
+		inspectstate.Command:     inspectstate.NewState(laleRepo),
+		getallstate.Command:      getallstate.NewState(laleRepo),
+		learntstate.Command:      learntstate.NewState(laleRepo),
+		repeat.Command:           repeat.NewState(laleRepo),
+		repeat.CramCommand:       repeat.NewCramState(laleRepo),
+		story.Command:            story.NewState(laleRepo),
+		learn.Command:            learn.NewState(laleRepo),
+		update.Command:           update.NewState(laleRepo),
+		undo.Command:             undo.NewState(laleRepo),
+		profile.Command:          profile.NewState(laleRepo),
+		limits.Command:           limits.NewState(laleRepo),
+		graduation.Command:       graduation.NewState(laleRepo),
+		leeches.Command:          leeches.NewState(laleRepo),
+		reset.Command:            reset.NewState(laleRepo),
+		suspend.Command:          suspend.NewState(laleRepo),
+		suspend.UnsuspendCommand: suspend.NewUnsuspendState(laleRepo),
+		bury.Command:             bury.NewState(laleRepo),
+		bury.UnburyCommand:       bury.NewUnburyState(laleRepo),
+		tag.Command:              tag.NewState(laleRepo),
+		deck.Command:             deck.NewState(laleRepo),
+		deck.DecksCommand:        deck.NewDecksState(laleRepo),
 		helpstate.Command: helpstate.NewState([]processor.StateProcessor{
 			&createstate.State{},
 			&inspectstate.State{},
@@ -85,9 +110,23 @@ func launch() error {
 			&learntstate.State{},
 			&helpstate.State{},
 			&repeat.State{},
+			&repeat.CramState{},
 			&story.State{},
 			&learn.State{},
 			&update.State{},
+			&undo.State{},
+			&profile.State{},
+			&limits.State{},
+			&graduation.State{},
+			&leeches.State{},
+			&reset.State{},
+			&suspend.State{},
+			&suspend.UnsuspendState{},
+			&bury.State{},
+			&bury.UnburyState{},
+			&tag.State{},
+			&deck.State{},
+			&deck.DecksState{},
 		}),
 	}
 
@@ -0,0 +1,140 @@
+package auxl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/genvmoroz/bot-engine/processor"
+	"github.com/genvmoroz/bot-engine/tg"
+	"github.com/genvmoroz/lale/service/api"
+)
+
+// PhotoSender is implemented by the clients able to send photos, the bot-engine client is not one of them.
+type PhotoSender interface {
+	SendPhoto(chatID int64, name string, bytes []byte) error
+}
+
+// SendPhoto sends the photo if the client is able to, otherwise it tells the user the photo is not shown.
+func SendPhoto(chatID int64, client processor.Client, name string, bytes []byte) error {
+	sender, ok := client.(PhotoSender)
+	if !ok {
+		return client.Send(chatID, "Sending photos is not supported, the image is not shown")
+	}
+
+	return sender.SendPhoto(chatID, name, bytes)
+}
+
+// Attachment is what the user attaches to a word: the notes and the image.
+type Attachment struct {
+	Notes string
+	Image []byte
+}
+
+const skipAttachment = "skip"
+
+// RequestAttachment asks the user to attach a photo, the caption of the photo becomes the notes,
+// or to send the notes alone. The zero Attachment is returned if the user skips it.
+func RequestAttachment(
+	ctx context.Context,
+	chatID int64,
+	message string,
+	client processor.Client,
+	updateChan tg.UpdatesChannel,
+) (Attachment, bool, error) {
+	if err := client.SendWithParseMode(chatID, message, tg.ModeHTML); err != nil {
+		return Attachment{}, false, err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return Attachment{}, false, nil
+		case update, ok := <-updateChan:
+			if !ok {
+				return Attachment{}, false, errors.New("updateChan is closed")
+			}
+			if update.Message == nil {
+				continue
+			}
+
+			if photo := update.Message.Photo; len(photo) != 0 {
+				// the sizes of the photo go from the smallest to the largest
+				image, err := client.DownloadFile(ctx, photo[len(photo)-1].FileID)
+				if err != nil {
+					return Attachment{}, false, fmt.Errorf("download photo: %w", err)
+				}
+				return Attachment{
+					Notes: strings.TrimSpace(update.Message.Caption),
+					Image: image,
+				}, false, nil
+			}
+
+			text := strings.TrimSpace(update.Message.Text)
+			switch {
+			case text == "/back":
+				return Attachment{}, true, client.Send(chatID, "Back to previous state")
+			case text == "":
+				if err := client.Send(chatID, "Empty value is not allowed"); err != nil {
+					return Attachment{}, false, err
+				}
+			case strings.EqualFold(text, skipAttachment):
+				return Attachment{}, false, nil
+			default:
+				return Attachment{Notes: text}, false, nil
+			}
+		}
+	}
+}
+
+// RequestWordAttachments asks the user to attach the notes and a photo to every word, the photos are uploaded
+// to the service and the words refer to them.
+func RequestWordAttachments(
+	ctx context.Context,
+	chatID int64,
+	userName string,
+	words []*api.WordInformation,
+	laleClient api.LaleServiceClient,
+	client processor.Client,
+	updateChan tg.UpdatesChannel,
+) (bool, error) {
+	for _, word := range words {
+		attachment, back, err := RequestAttachment(
+			ctx,
+			chatID,
+			fmt.Sprintf(
+				"Attach a photo to <code>%s</code>, the caption is kept as the notes, send the notes alone "+
+					"(e.g. a mnemonic) or <code>%s</code>",
+				word.GetWord(), skipAttachment,
+			),
+			client,
+			updateChan,
+		)
+		if err != nil {
+			return false, fmt.Errorf("request attachment: %w", err)
+		}
+		if back {
+			return true, nil
+		}
+
+		word.Notes = attachment.Notes
+		if len(attachment.Image) == 0 {
+			continue
+		}
+
+		image, err := laleClient.UploadImage(ctx, &api.UploadImageRequest{
+			UserID: userName,
+			Data:   attachment.Image,
+		})
+		if err != nil {
+			if err = client.SendWithParseMode(chatID, fmt.Sprintf("<code>grpc [UploadImage] err: %s</code>, the word is left without the image", err.Error()), tg.ModeHTML); err != nil {
+				return false, err
+			}
+			continue
+		}
+		word.ImageID = image.GetId()
+	}
+
+	return false, nil
+}
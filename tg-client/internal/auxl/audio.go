@@ -0,0 +1,64 @@
+package auxl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/genvmoroz/bot-engine/processor"
+	"github.com/genvmoroz/lale/service/api"
+)
+
+// GetAudio fetches the audio the service streams in chunks.
+func GetAudio(ctx context.Context, laleClient api.LaleServiceClient, userID, audioID string) ([]byte, error) {
+	stream, err := laleClient.GetAudio(ctx, &api.GetAudioRequest{
+		UserID:  userID,
+		AudioID: audioID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("grpc [GetAudio]: %w", err)
+	}
+
+	var audio []byte
+	for {
+		chunk, err := stream.Recv()
+		switch {
+		case errors.Is(err, io.EOF):
+			return audio, nil
+		case err != nil:
+			return nil, fmt.Errorf("receive audio: %w", err)
+		}
+		audio = append(audio, chunk.GetData()...)
+	}
+}
+
+// SendAudioByLanguage sends the audio of the word in every voice language, the audio is fetched by its IDs.
+// The audio failed to be fetched or sent is reported and the rest is sent.
+func SendAudioByLanguage(
+	ctx context.Context,
+	chatID int64,
+	client processor.Client,
+	laleClient api.LaleServiceClient,
+	userID string,
+	audioIDs map[string]string,
+) error {
+	languages := slices.Sorted(maps.Keys(audioIDs))
+	slices.Reverse(languages)
+
+	for _, language := range languages {
+		audio, err := GetAudio(ctx, laleClient, userID, audioIDs[language])
+		if err == nil {
+			err = client.SendAudio(chatID, language, audio)
+		}
+		if err != nil {
+			if err = client.Send(chatID, fmt.Sprintf("sending audio (%s) error: %v", language, err.Error())); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -4,13 +4,51 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"slices"
 	"strings"
 
 	"github.com/genvmoroz/bot-engine/processor"
 	"github.com/genvmoroz/bot-engine/tg"
+	"github.com/genvmoroz/lale/service/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// Recall ratings reported to UpdateCardPerformance, the service grades answers on the 0-5 scale.
+const (
+	RatingAgain uint32 = 0
+	RatingHard  uint32 = 3
+	RatingGood  uint32 = 4
+	RatingEasy  uint32 = 5
+)
+
+// FilterHint explains how the cards are narrowed down to a deck or tags after the language.
+const FilterHint = "Add <code>#tags</code> and a deck name after the language to study only them, " +
+	"ex: <code>en #travel Spanish trip</code>"
+
+// ParseLanguageAndFilter splits the input into the language, the first word, and the filter of the cards:
+// the words starting with # are the tags, the rest of the words are the deck name.
+func ParseLanguageAndFilter(input string) (string, *api.CardFilter) {
+	fields := strings.Fields(input)
+	if len(fields) == 0 {
+		return "", nil
+	}
+
+	filter := &api.CardFilter{}
+	var deck []string
+	for _, field := range fields[1:] {
+		if tag, ok := strings.CutPrefix(field, "#"); ok {
+			if tag != "" {
+				filter.Tags = append(filter.Tags, tag)
+			}
+			continue
+		}
+		deck = append(deck, field)
+	}
+	filter.Deck = strings.Join(deck, " ")
+
+	return fields[0], filter
+}
+
 func RequestInput[T any](
 	ctx context.Context,
 	until func(T) bool,
@@ -60,38 +98,87 @@ func RequestInput[T any](
 	return val, userName, false, nil
 }
 
-func SendAudioByLanguage(chatID int64, client processor.Client, audioByLanguage map[string][]byte) error {
-	tuple := []struct {
-		language string
-		audio    []byte
-	}{}
-
-	for language, audio := range audioByLanguage {
-		tuple = append(tuple, struct {
-			language string
-			audio    []byte
-		}{
-			language: language,
-			audio:    audio,
-		})
+// RequestRating asks the user how easy it was to recall the answer.
+func RequestRating(
+	ctx context.Context,
+	chatID int64,
+	client processor.Client,
+	updateChan tg.UpdatesChannel,
+) (uint32, bool, error) {
+	rating, _, back, err := RequestInput(
+		ctx,
+		func(r *uint32) bool {
+			return r != nil
+		},
+		chatID,
+		"How easy was it? Send <code>hard</code>, <code>good</code> or <code>easy</code>",
+		func(input string, chatID int64, client processor.Client) (*uint32, error) {
+			text := strings.ToLower(strings.TrimSpace(input))
+			switch text {
+			case "hard":
+				r := RatingHard
+				return &r, nil
+			case "good":
+				r := RatingGood
+				return &r, nil
+			case "easy":
+				r := RatingEasy
+				return &r, nil
+			default:
+				return nil, client.SendWithParseMode(chatID, fmt.Sprintf("Invalid value <code>%s</code>, enter <code>/back</code> to go to the previous state", text), tg.ModeHTML)
+			}
+		},
+		client,
+		updateChan,
+	)
+	if err != nil || back || rating == nil {
+		return 0, back, err
+	}
+
+	return *rating, false, nil
+}
+
+// IsConflict reports whether the service has rejected the change of a card because the card has been changed
+// meanwhile by another session or tool.
+func IsConflict(err error) bool {
+	return status.Code(err) == codes.Aborted
+}
+
+// AnswerConflictMessage tells the user the answer has not been saved because the card has been reviewed
+// meanwhile by another session.
+const AnswerConflictMessage = "The Card has been reviewed meanwhile, the answer is not saved"
+
+// UpdateCardPerformance reports the answer to the service. The answer rejected because the card has been changed
+// meanwhile is reported once again only if the card fetched again has not been reviewed since it was shown,
+// otherwise the conflict is returned.
+func UpdateCardPerformance(
+	ctx context.Context,
+	laleClient api.LaleServiceClient,
+	shown *api.Card,
+	req *api.UpdateCardPerformanceRequest,
+) (*api.UpdateCardPerformanceResponse, error) {
+	resp, err := laleClient.UpdateCardPerformance(ctx, req)
+	if !IsConflict(err) {
+		return resp, err
 	}
 
-	slices.SortFunc(tuple, func(a, b struct {
-		language string
-		audio    []byte
-	},
-	) int {
-		return strings.Compare(b.language, a.language)
-	})
-
-	for _, item := range tuple {
-		err := client.SendAudio(chatID, item.language, item.audio)
-		if err != nil {
-			if err = client.Send(chatID, fmt.Sprintf("sending audio (%s) error: %v", item.language, err.Error())); err != nil {
-				return err
-			}
-		}
+	card, getErr := GetCard(ctx, laleClient, req.GetUserID(), req.GetCardID())
+	if getErr != nil {
+		return nil, fmt.Errorf("get card: %w", getErr)
+	}
+	if card == nil || !card.GetLastReviewedAt().AsTime().Equal(shown.GetLastReviewedAt().AsTime()) {
+		return nil, err
+	}
+
+	return laleClient.UpdateCardPerformance(ctx, req)
+}
+
+// GetCard returns the card of the user with the ID, nil if the user has no such card.
+func GetCard(ctx context.Context, laleClient api.LaleServiceClient, userID, cardID string) (*api.Card, error) {
+	card, err := laleClient.GetCard(ctx, &api.GetCardRequest{UserID: userID, CardID: cardID})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
 	}
 
-	return nil
+	return card, err
 }
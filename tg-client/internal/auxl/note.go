@@ -0,0 +1,244 @@
+package auxl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/genvmoroz/bot-engine/processor"
+	"github.com/genvmoroz/bot-engine/tg"
+	"github.com/genvmoroz/lale-tg-client/internal/pretty"
+	"github.com/genvmoroz/lale/service/api"
+	"github.com/genvmoroz/lale/service/pkg/entity"
+)
+
+// IsNoteCard reports whether the card is a phrase, cloze or grammar card rather than a word card.
+func IsNoteCard(card *api.Card) bool {
+	noteType := entity.NoteType(card.GetType())
+	return noteType != "" && noteType != entity.NoteTypeWords
+}
+
+// RequestNoteType asks the user for the type of the card.
+func RequestNoteType(
+	ctx context.Context,
+	chatID int64,
+	client processor.Client,
+	updateChan tg.UpdatesChannel,
+) (entity.NoteType, bool, error) {
+	noteType, _, back, err := RequestInput(
+		ctx,
+		func(t entity.NoteType) bool {
+			return t != ""
+		},
+		chatID,
+		`Send the type of the Card:
+<code>words</code> - words with their translations
+<code>phrase</code> - a phrase or an idiom with its meaning
+<code>cloze</code> - a sentence with missing parts
+<code>grammar</code> - a grammar prompt with its answer`,
+		func(input string, chatID int64, client processor.Client) (entity.NoteType, error) {
+			noteType := entity.NoteType(strings.ToLower(strings.TrimSpace(input)))
+			switch noteType {
+			case entity.NoteTypeWords, entity.NoteTypePhrase, entity.NoteTypeCloze, entity.NoteTypeGrammar:
+				return noteType, nil
+			default:
+				return "", client.SendWithParseMode(chatID, fmt.Sprintf("Unknown type <code>%s</code>", input), tg.ModeHTML)
+			}
+		},
+		client,
+		updateChan,
+	)
+	if err != nil {
+		return "", false, fmt.Errorf("request note type: %w", err)
+	}
+
+	return noteType, back, nil
+}
+
+// RequestNote asks the user for the content of a phrase, cloze or grammar card.
+func RequestNote(
+	ctx context.Context,
+	chatID int64,
+	noteType entity.NoteType,
+	client processor.Client,
+	updateChan tg.UpdatesChannel,
+) (*api.Note, string, bool, error) {
+	var message string
+	switch noteType {
+	case entity.NoteTypePhrase:
+		message = "Send the phrase and its meaning, ex: <code>break the ice - to start a conversation</code>"
+	case entity.NoteTypeCloze:
+		message = "Send the sentence with the missing parts in double braces, ex: <code>I {{have been}} here for an hour</code>"
+	case entity.NoteTypeGrammar:
+		message = "Send the prompt and its answer on two lines, ex:\n<code>Past participle of go\ngone</code>"
+	default:
+		return nil, "", false, fmt.Errorf("unexpected note type [%s]", noteType)
+	}
+
+	note, userName, back, err := RequestInput(
+		ctx,
+		func(n *api.Note) bool {
+			return n != nil
+		},
+		chatID,
+		message,
+		func(input string, chatID int64, client processor.Client) (*api.Note, error) {
+			note, ok := parseNote(noteType, input)
+			if !ok {
+				return nil, client.SendWithParseMode(chatID, "Invalid value, "+message, tg.ModeHTML)
+			}
+			return note, nil
+		},
+		client,
+		updateChan,
+	)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("request note: %w", err)
+	}
+	if back {
+		return nil, "", true, nil
+	}
+
+	explanation, _, back, err := RequestInput(
+		ctx,
+		func(s string) bool {
+			return s != ""
+		},
+		chatID,
+		"Send the explanation shown after the answer, e.g. the grammar rule, or <code>skip</code>",
+		func(input string, _ int64, _ processor.Client) (string, error) {
+			return strings.TrimSpace(input), nil
+		},
+		client,
+		updateChan,
+	)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("request explanation: %w", err)
+	}
+	if back {
+		return nil, "", true, nil
+	}
+	if !strings.EqualFold(explanation, skipAttachment) {
+		note.Explanation = explanation
+	}
+
+	return note, userName, false, nil
+}
+
+func parseNote(noteType entity.NoteType, input string) (*api.Note, bool) {
+	switch noteType {
+	case entity.NoteTypePhrase:
+		text, answer, found := strings.Cut(input, " - ")
+		if !found || strings.TrimSpace(text) == "" || strings.TrimSpace(answer) == "" {
+			return nil, false
+		}
+		return &api.Note{Text: strings.TrimSpace(text), Answer: strings.TrimSpace(answer)}, true
+	case entity.NoteTypeCloze:
+		deletions, err := entity.ClozeDeletions(input)
+		if err != nil || len(deletions) == 0 {
+			return nil, false
+		}
+		return &api.Note{Text: strings.TrimSpace(input)}, true
+	case entity.NoteTypeGrammar:
+		text, answer, found := strings.Cut(input, "\n")
+		if !found || strings.TrimSpace(text) == "" || strings.TrimSpace(answer) == "" {
+			return nil, false
+		}
+		return &api.Note{Text: strings.TrimSpace(text), Answer: strings.TrimSpace(answer)}, true
+	default:
+		return nil, false
+	}
+}
+
+// AskNote asks the phrase, cloze or grammar card and shows the answer, it reports whether the card has been
+// answered correctly and the time it took.
+func AskNote(
+	ctx context.Context,
+	chatID int64,
+	card *api.Card,
+	client processor.Client,
+	updateChan tg.UpdatesChannel,
+) (bool, time.Duration, bool, error) {
+	if err := client.SendWithParseMode(chatID, pretty.NoteQuestion(card), tg.ModeHTML); err != nil {
+		return false, 0, false, err
+	}
+
+	answers, err := noteAnswers(card)
+	if err != nil {
+		return false, 0, false, client.SendWithParseMode(chatID, fmt.Sprintf("Card <code>%s</code> is invalid: %s", card.GetId(), err.Error()), tg.ModeHTML)
+	}
+
+	var (
+		correct      = true
+		timeToAnswer time.Duration
+	)
+	for i, answer := range answers {
+		message := "Send the answer"
+		if len(answers) > 1 {
+			message = fmt.Sprintf("Send the missing part %d of %d", i+1, len(answers))
+		}
+
+		askedAt := time.Now()
+		input, _, back, err := RequestInput(
+			ctx,
+			func(s string) bool {
+				return s != ""
+			},
+			chatID,
+			message,
+			func(input string, _ int64, _ processor.Client) (string, error) {
+				return strings.TrimSpace(input), nil
+			},
+			client,
+			updateChan,
+		)
+		if err != nil {
+			return false, 0, false, fmt.Errorf("request answer: %w", err)
+		}
+		if back {
+			return false, 0, true, nil
+		}
+		timeToAnswer += time.Since(askedAt)
+
+		correct = correct && MatchAnswer(input, answer)
+	}
+
+	verdict := "Correct"
+	if !correct {
+		verdict = "Incorrect"
+	}
+	if err = client.Send(chatID, verdict); err != nil {
+		return false, 0, false, err
+	}
+	if err = client.SendWithParseMode(chatID, pretty.NoteAnswer(card), tg.ModeHTML); err != nil {
+		return false, 0, false, err
+	}
+
+	return correct, timeToAnswer, false, nil
+}
+
+// noteAnswers returns the answers the card is asked for: the phrase, the missing parts of the cloze sentence
+// or the answer to the grammar prompt.
+func noteAnswers(card *api.Card) ([]string, error) {
+	switch entity.NoteType(card.GetType()) {
+	case entity.NoteTypePhrase:
+		return []string{card.GetNote().GetText()}, nil
+	case entity.NoteTypeCloze:
+		return entity.ClozeDeletions(card.GetNote().GetText())
+	case entity.NoteTypeGrammar:
+		return []string{card.GetNote().GetAnswer()}, nil
+	default:
+		return nil, fmt.Errorf("unexpected note type [%s]", card.GetType())
+	}
+}
+
+// MatchAnswer reports whether the input is the expected answer ignoring the case, the spacing
+// and the trailing punctuation.
+func MatchAnswer(input, expected string) bool {
+	normalise := func(s string) string {
+		return strings.TrimRight(strings.Join(strings.Fields(strings.ToLower(s)), " "), ".!?")
+	}
+
+	return normalise(input) == normalise(expected)
+}
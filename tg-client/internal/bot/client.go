@@ -0,0 +1,44 @@
+// Package bot provides the Telegram client of the states, it extends the bot-engine client with the
+// messages the engine doesn't send, e.g. photos.
+package bot
+
+import (
+	"fmt"
+
+	"github.com/genvmoroz/bot-engine/tg"
+	base "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+type Client struct {
+	*tg.Client
+
+	api *base.BotAPI
+}
+
+func NewClient(token string) (*Client, error) {
+	client, err := tg.NewClient(token)
+	if err != nil {
+		return nil, fmt.Errorf("create bot-engine client: %w", err)
+	}
+
+	api, err := base.NewBotAPI(token)
+	if err != nil {
+		return nil, fmt.Errorf("create bot api: %w", err)
+	}
+
+	return &Client{
+		Client: client,
+		api:    api,
+	}, nil
+}
+
+func (c *Client) SendPhoto(chatID int64, name string, bytes []byte) error {
+	photo := base.NewPhoto(chatID,
+		base.FileBytes{
+			Name:  name,
+			Bytes: bytes,
+		},
+	)
+	_, err := c.api.Send(photo)
+	return err
+}
@@ -1,13 +1,17 @@
 package pretty
 
 import (
+	"cmp"
 	"fmt"
+	"html"
 	"log"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/genvmoroz/lale-tg-client/internal/transform"
 	"github.com/genvmoroz/lale/service/api"
+	"github.com/genvmoroz/lale/service/pkg/entity"
 	"gopkg.in/yaml.v3"
 )
 
@@ -22,8 +26,10 @@ func Card(card *api.Card, withWords bool) []string {
 CardID: <code>%s</code>
 UserID: <code>%s</code>
 Language: <code>%s</code>
+Type: <code>%s</code>
 NextDueDate: <code>%s</code>
 ConsecutiveCorrectAnswersNumber: <code>%s</code>
+Lapses: <code>%s</code>
 `
 	p = append(p,
 		fmt.Sprintf(
@@ -31,8 +37,10 @@ ConsecutiveCorrectAnswersNumber: <code>%s</code>
 			card.GetId(),
 			card.GetUserID(),
 			card.GetLanguage(),
+			cmp.Or(card.GetType(), string(entity.NoteTypeWords)),
 			card.GetNextDueDate().AsTime().Format(time.RFC3339),
 			strconv.Itoa(int(card.GetConsecutiveCorrectAnswersNumber())),
+			strconv.Itoa(int(card.GetLapses())),
 		),
 	)
 
@@ -40,6 +48,10 @@ ConsecutiveCorrectAnswersNumber: <code>%s</code>
 		return p
 	}
 
+	if card.GetNote() != nil {
+		p = append(p, Note(card))
+	}
+
 	for _, word := range transform.DefaultTransformer.ToCoreWordInformationList(card.GetWordInformationList()) {
 		out, err := yaml.Marshal(word)
 		if err != nil {
@@ -119,3 +131,96 @@ func MeaningWithoutExamples(m *api.Meaning) string {
 
 	return Meaning(copyM)
 }
+
+// Note renders the content of a phrase, cloze or grammar card.
+func Note(card *api.Card) string {
+	note := card.GetNote()
+
+	var out string
+	switch entity.NoteType(card.GetType()) {
+	case entity.NoteTypePhrase:
+		out = fmt.Sprintf("Phrase: <b>%s</b>\nMeaning: %s", html.EscapeString(note.GetText()), html.EscapeString(note.GetAnswer()))
+	case entity.NoteTypeCloze:
+		out = "Cloze: " + cloze(note.GetText(), func(deletion string) string {
+			return "<b>" + html.EscapeString(deletion) + "</b>"
+		})
+	case entity.NoteTypeGrammar:
+		out = fmt.Sprintf("Prompt: %s\nAnswer: <b>%s</b>", html.EscapeString(note.GetText()), html.EscapeString(note.GetAnswer()))
+	default:
+		return ""
+	}
+
+	return out + explanation(note)
+}
+
+// NoteQuestion renders the phrase, cloze or grammar card the way it's asked: the meaning of the phrase,
+// the cloze sentence with the missing parts blanked out or the grammar prompt.
+func NoteQuestion(card *api.Card) string {
+	note := card.GetNote()
+
+	switch entity.NoteType(card.GetType()) {
+	case entity.NoteTypePhrase:
+		return "Meaning: " + html.EscapeString(note.GetAnswer())
+	case entity.NoteTypeCloze:
+		var n int
+		return "Fill in: " + cloze(note.GetText(), func(string) string {
+			n++
+			return fmt.Sprintf("<code>[%d ...]</code>", n)
+		})
+	case entity.NoteTypeGrammar:
+		return "Prompt: " + html.EscapeString(note.GetText())
+	default:
+		return ""
+	}
+}
+
+// NoteAnswer renders the answer to the phrase, cloze or grammar card shown once it has been answered.
+func NoteAnswer(card *api.Card) string {
+	note := card.GetNote()
+
+	var out string
+	switch entity.NoteType(card.GetType()) {
+	case entity.NoteTypePhrase:
+		out = "Phrase: <b>" + html.EscapeString(note.GetText()) + "</b>"
+	case entity.NoteTypeCloze:
+		out = cloze(note.GetText(), func(deletion string) string {
+			return "<b>" + html.EscapeString(deletion) + "</b>"
+		})
+	case entity.NoteTypeGrammar:
+		out = "Answer: <b>" + html.EscapeString(note.GetAnswer()) + "</b>"
+	default:
+		return ""
+	}
+
+	return out + explanation(note)
+}
+
+// cloze renders the cloze sentence escaping the text around the deletions, the deletions are rendered by replace.
+func cloze(text string, replace func(deletion string) string) string {
+	const placeholder = "\x00"
+
+	var deletions []string
+	masked := entity.ReplaceClozeDeletions(text, func(deletion string) string {
+		deletions = append(deletions, replace(deletion))
+		return placeholder
+	})
+
+	parts := strings.Split(html.EscapeString(masked), placeholder)
+	var out strings.Builder
+	for i, part := range parts {
+		out.WriteString(part)
+		if i < len(deletions) {
+			out.WriteString(deletions[i])
+		}
+	}
+
+	return out.String()
+}
+
+func explanation(note *api.Note) string {
+	if note.GetExplanation() == "" {
+		return ""
+	}
+
+	return "\n\n<i>" + html.EscapeString(note.GetExplanation()) + "</i>"
+}
@@ -0,0 +1,129 @@
+package bury
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/genvmoroz/bot-engine/processor"
+	"github.com/genvmoroz/bot-engine/tg"
+	"github.com/genvmoroz/lale-tg-client/internal/auxl"
+	"github.com/genvmoroz/lale-tg-client/internal/repository"
+	"github.com/genvmoroz/lale/service/api"
+)
+
+type (
+	// State buries a card, it's hidden until the start of the next day.
+	State struct {
+		laleRepo *repository.LaleRepo
+	}
+
+	// UnburyState shows a buried card again.
+	UnburyState struct {
+		laleRepo *repository.LaleRepo
+	}
+)
+
+const (
+	Command       = "/bury"
+	UnburyCommand = "/unbury"
+)
+
+func NewState(laleRepo *repository.LaleRepo) *State {
+	return &State{laleRepo: laleRepo}
+}
+
+func NewUnburyState(laleRepo *repository.LaleRepo) *UnburyState {
+	return &UnburyState{laleRepo: laleRepo}
+}
+
+func (s *State) Process(ctx context.Context, client processor.Client, chatID int64, updateChan tg.UpdatesChannel) error {
+	return process(ctx, s.laleRepo, true, client, chatID, updateChan)
+}
+
+func (s *State) Command() string {
+	return Command
+}
+
+func (s *State) Description() string {
+	return "Hide a card until tomorrow"
+}
+
+func (s *UnburyState) Process(
+	ctx context.Context,
+	client processor.Client,
+	chatID int64,
+	updateChan tg.UpdatesChannel,
+) error {
+	return process(ctx, s.laleRepo, false, client, chatID, updateChan)
+}
+
+func (s *UnburyState) Command() string {
+	return UnburyCommand
+}
+
+func (s *UnburyState) Description() string {
+	return "Show a buried card again"
+}
+
+func process(
+	ctx context.Context,
+	laleRepo *repository.LaleRepo,
+	buried bool,
+	client processor.Client,
+	chatID int64,
+	updateChan tg.UpdatesChannel,
+) error {
+	action := "bury"
+	if !buried {
+		action = "unbury"
+	}
+
+	cardID, userName, back, err := auxl.RequestInput(
+		ctx,
+		func(s string) bool {
+			return s != ""
+		},
+		chatID,
+		fmt.Sprintf("Send the card ID to %s", action),
+		func(input string, _ int64, _ processor.Client) (string, error) {
+			return strings.TrimSpace(input), nil
+		},
+		client,
+		updateChan,
+	)
+	if err != nil {
+		return fmt.Errorf("request card ID: %w", err)
+	}
+	if back {
+		return nil
+	}
+
+	req := &api.BuryCardRequest{
+		UserID: strings.TrimSpace(userName),
+		CardID: cardID,
+		Buried: buried,
+	}
+
+	resp, err := laleRepo.Client.BuryCard(ctx, req)
+	if err != nil {
+		return client.SendWithParseMode(
+			chatID,
+			fmt.Sprintf("<code>grpc [BuryCard] err: %s</code>", err.Error()),
+			tg.ModeHTML,
+		)
+	}
+
+	if resp.GetBuriedUntil() != nil {
+		return client.SendWithParseMode(
+			chatID,
+			fmt.Sprintf(
+				"Card <code>%s</code> buried until <code>%s</code>",
+				resp.GetId(), resp.GetBuriedUntil().AsTime().Format(time.RFC3339),
+			),
+			tg.ModeHTML,
+		)
+	}
+	return client.SendWithParseMode(chatID, fmt.Sprintf("Card <code>%s</code> unburied", resp.GetId()), tg.ModeHTML)
+}
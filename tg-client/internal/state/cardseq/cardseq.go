@@ -149,3 +149,13 @@ func (r *Cards) Remaining() int {
 
 	return len(r.cards) - int(r.index)
 }
+
+// RepeatLast makes Next return the last returned card again, replaced with the given one.
+func (r *Cards) RepeatLast(card *api.Card) {
+	if r.index == 0 {
+		return
+	}
+
+	r.index--
+	r.cards[r.index].Card = card
+}
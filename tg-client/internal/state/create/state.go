@@ -12,6 +12,7 @@ import (
 	"github.com/genvmoroz/lale-tg-client/internal/pretty"
 	"github.com/genvmoroz/lale-tg-client/internal/repository"
 	"github.com/genvmoroz/lale/service/api"
+	"github.com/genvmoroz/lale/service/pkg/entity"
 	"github.com/samber/lo"
 )
 
@@ -61,6 +62,17 @@ func (s *State) Process(ctx context.Context, client processor.Client, chatID int
 		return nil
 	}
 
+	noteType, back, err := auxl.RequestNoteType(ctx, chatID, client, updateChan)
+	if err != nil {
+		return err
+	}
+	if back {
+		return nil
+	}
+	if noteType != entity.NoteTypeWords {
+		return s.createNote(ctx, client, chatID, updateChan, userName, language, noteType)
+	}
+
 	prompt, _, back, err := auxl.RequestInput[*bool](
 		ctx,
 		func(s *bool) bool {
@@ -152,6 +164,14 @@ func (s *State) Process(ctx context.Context, client processor.Client, chatID int
 		})
 	}
 
+	back, err = auxl.RequestWordAttachments(ctx, chatID, userName, req.WordInformationList, s.laleRepo.Client, client, updateChan)
+	if err != nil {
+		return fmt.Errorf("request word attachments: %w", err)
+	}
+	if back {
+		return nil
+	}
+
 	req.UserID = userName
 	req.Language = strings.ToLower(language)
 
@@ -176,6 +196,47 @@ func (s *State) Process(ctx context.Context, client processor.Client, chatID int
 	return nil
 }
 
+// createNote creates the phrase, cloze or grammar card.
+func (s *State) createNote(
+	ctx context.Context,
+	client processor.Client,
+	chatID int64,
+	updateChan tg.UpdatesChannel,
+	userName string,
+	language string,
+	noteType entity.NoteType,
+) error {
+	note, _, back, err := auxl.RequestNote(ctx, chatID, noteType, client, updateChan)
+	if err != nil {
+		return err
+	}
+	if back {
+		return nil
+	}
+
+	resp, err := s.laleRepo.Client.CreateCard(ctx, &api.CreateCardRequest{
+		UserID:   userName,
+		Language: strings.ToLower(language),
+		Type:     string(noteType),
+		Note:     note,
+	})
+	if err != nil {
+		return client.SendWithParseMode(chatID, fmt.Sprintf("<code>grpc [CreateCard] err: %s</code>", err.Error()), tg.ModeHTML)
+	}
+
+	if err = client.Send(chatID, "Card created"); err != nil {
+		return err
+	}
+
+	for _, msg := range pretty.Card(resp, true) {
+		if err = client.SendWithParseMode(chatID, msg, tg.ModeHTML); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (s *State) requestCardPrompt(ctx context.Context, language string, client processor.Client, chatID int64, updateChan tg.UpdatesChannel) (bool, error) {
 	word, userName, back, err := auxl.RequestInput[*string](
 		ctx,
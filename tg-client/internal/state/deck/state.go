@@ -0,0 +1,189 @@
+package deck
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/genvmoroz/bot-engine/processor"
+	"github.com/genvmoroz/bot-engine/tg"
+	"github.com/genvmoroz/lale-tg-client/internal/auxl"
+	"github.com/genvmoroz/lale-tg-client/internal/repository"
+	"github.com/genvmoroz/lale/service/api"
+)
+
+type (
+	// State moves a card to a deck or out of its deck.
+	State struct {
+		laleRepo *repository.LaleRepo
+	}
+
+	// DecksState lists the decks with the number of cards in them.
+	DecksState struct {
+		laleRepo *repository.LaleRepo
+	}
+)
+
+const (
+	Command      = "/deck"
+	DecksCommand = "/decks"
+)
+
+// Inputs selecting no deck and all the languages.
+const (
+	noDeck       = "none"
+	allLanguages = "all"
+)
+
+func NewState(laleRepo *repository.LaleRepo) *State {
+	return &State{laleRepo: laleRepo}
+}
+
+func NewDecksState(laleRepo *repository.LaleRepo) *DecksState {
+	return &DecksState{laleRepo: laleRepo}
+}
+
+func (s *State) Process(ctx context.Context, client processor.Client, chatID int64, updateChan tg.UpdatesChannel) error {
+	cardID, userName, back, err := auxl.RequestInput(
+		ctx,
+		func(s string) bool {
+			return s != ""
+		},
+		chatID,
+		"Send the card ID to move to a deck",
+		func(input string, _ int64, _ processor.Client) (string, error) {
+			return strings.TrimSpace(input), nil
+		},
+		client,
+		updateChan,
+	)
+	if err != nil {
+		return fmt.Errorf("request card ID: %w", err)
+	}
+	if back {
+		return nil
+	}
+
+	deck, _, back, err := auxl.RequestInput(
+		ctx,
+		func(s *string) bool {
+			return s != nil
+		},
+		chatID,
+		fmt.Sprintf("Send the deck name, ex: <code>Spanish trip</code>, or <code>%s</code> to remove the card from its deck", noDeck),
+		func(input string, _ int64, _ processor.Client) (*string, error) {
+			deck := strings.Join(strings.Fields(input), " ")
+			if strings.EqualFold(deck, noDeck) {
+				deck = ""
+			}
+			return &deck, nil
+		},
+		client,
+		updateChan,
+	)
+	if err != nil {
+		return fmt.Errorf("request deck: %w", err)
+	}
+	if back {
+		return nil
+	}
+
+	req := &api.SetCardDeckRequest{
+		UserID: strings.TrimSpace(userName),
+		CardID: cardID,
+		Deck:   *deck,
+	}
+
+	resp, err := s.laleRepo.Client.SetCardDeck(ctx, req)
+	if err != nil {
+		return client.SendWithParseMode(
+			chatID,
+			fmt.Sprintf("<code>grpc [SetCardDeck] err: %s</code>", err.Error()),
+			tg.ModeHTML,
+		)
+	}
+
+	if resp.GetDeck() == "" {
+		return client.SendWithParseMode(chatID, fmt.Sprintf("Card <code>%s</code> is in no deck", resp.GetId()), tg.ModeHTML)
+	}
+	return client.SendWithParseMode(
+		chatID,
+		fmt.Sprintf("Card <code>%s</code> moved to deck <code>%s</code>", resp.GetId(), resp.GetDeck()),
+		tg.ModeHTML,
+	)
+}
+
+func (s *State) Command() string {
+	return Command
+}
+
+func (s *State) Description() string {
+	return "Move a card to a deck"
+}
+
+func (s *DecksState) Process(
+	ctx context.Context,
+	client processor.Client,
+	chatID int64,
+	updateChan tg.UpdatesChannel,
+) error {
+	language, userName, back, err := auxl.RequestInput(
+		ctx,
+		func(s *string) bool {
+			return s != nil
+		},
+		chatID,
+		fmt.Sprintf("Send the language, ex: <code>en</code>, or <code>%s</code> to list the decks in all languages", allLanguages),
+		func(input string, _ int64, _ processor.Client) (*string, error) {
+			language := strings.TrimSpace(input)
+			if strings.EqualFold(language, allLanguages) {
+				language = ""
+			}
+			return &language, nil
+		},
+		client,
+		updateChan,
+	)
+	if err != nil {
+		return fmt.Errorf("request language: %w", err)
+	}
+	if back {
+		return nil
+	}
+
+	req := &api.GetDecksRequest{
+		UserID:   strings.TrimSpace(userName),
+		Language: *language,
+	}
+
+	resp, err := s.laleRepo.Client.GetDecks(ctx, req)
+	if err != nil {
+		return client.SendWithParseMode(
+			chatID,
+			fmt.Sprintf("<code>grpc [GetDecks] err: %s</code>", err.Error()),
+			tg.ModeHTML,
+		)
+	}
+
+	if len(resp.GetDecks()) == 0 {
+		return client.Send(chatID, "No decks found, move a card to a deck with "+Command)
+	}
+
+	var msg strings.Builder
+	msg.WriteString("Decks:\n")
+	for _, deck := range resp.GetDecks() {
+		fmt.Fprintf(&msg, "<code>%s</code> - %d cards\n", deck.GetName(), deck.GetCards())
+	}
+	msg.WriteString("Study a deck by adding its name after the language in " +
+		"<code>/learn</code>, <code>/repeat</code>, <code>/getall</code> or <code>/story</code>")
+
+	return client.SendWithParseMode(chatID, msg.String(), tg.ModeHTML)
+}
+
+func (s *DecksState) Command() string {
+	return DecksCommand
+}
+
+func (s *DecksState) Description() string {
+	return "List the decks"
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/genvmoroz/bot-engine/processor"
 	"github.com/genvmoroz/bot-engine/tg"
+	"github.com/genvmoroz/lale-tg-client/internal/auxl"
 	"github.com/genvmoroz/lale-tg-client/internal/pretty"
 	"github.com/genvmoroz/lale-tg-client/internal/repository"
 	"github.com/genvmoroz/lale/service/api"
@@ -35,7 +36,7 @@ func (s *State) Process(ctx context.Context, client processor.Client, chatID int
 	var req *api.GetCardsRequest
 
 	for req == nil {
-		if err := client.SendWithParseMode(chatID, "Send the ISO 1 Letter Language Code. Ex. <code>en</code>. Or  <code>all</code> to request cards without filtering by language", tg.ModeHTML); err != nil {
+		if err := client.SendWithParseMode(chatID, "Send the ISO 1 Letter Language Code. Ex. <code>en</code>. Or  <code>all</code> to request cards without filtering by language\n"+auxl.FilterHint, tg.ModeHTML); err != nil {
 			return err
 		}
 
@@ -46,8 +47,8 @@ func (s *State) Process(ctx context.Context, client processor.Client, chatID int
 			if !ok {
 				return errors.New("updateChan is closed")
 			}
-			text := strings.ToLower(strings.TrimSpace(update.Message.Text))
-			switch text {
+			language, filter := auxl.ParseLanguageAndFilter(update.Message.Text)
+			switch language = strings.ToLower(language); language {
 			case "/back":
 				return client.Send(chatID, "Back to previous state")
 			case "":
@@ -58,11 +59,13 @@ func (s *State) Process(ctx context.Context, client processor.Client, chatID int
 				req = &api.GetCardsRequest{
 					UserID:   strings.TrimSpace(update.Message.From.UserName),
 					Language: "",
+					Filter:   filter,
 				}
 			default:
 				req = &api.GetCardsRequest{
 					UserID:   strings.TrimSpace(update.Message.From.UserName),
-					Language: text,
+					Language: language,
+					Filter:   filter,
 				}
 			}
 		}
@@ -0,0 +1,128 @@
+package graduation
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/genvmoroz/bot-engine/processor"
+	"github.com/genvmoroz/bot-engine/tg"
+	"github.com/genvmoroz/lale-tg-client/internal/auxl"
+	"github.com/genvmoroz/lale-tg-client/internal/repository"
+	"github.com/genvmoroz/lale/service/api"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+type State struct {
+	laleRepo *repository.LaleRepo
+}
+
+const Command = "/graduation"
+
+const day = 24 * time.Hour
+
+func NewState(laleRepo *repository.LaleRepo) *State {
+	return &State{laleRepo: laleRepo}
+}
+
+const initialMessage = `
+Graduation
+Mark the cards learnt automatically after a number of correct answers in a row
+or once the interval until the next repeat is long enough, send 0 to disable a criterion
+`
+
+func (s *State) Process(ctx context.Context, client processor.Client, chatID int64, updateChan tg.UpdatesChannel) error {
+	if err := client.Send(chatID, initialMessage); err != nil {
+		return err
+	}
+
+	correctAnswers, userName, back, err := requestNumber(
+		ctx, chatID, "Send the number of correct answers in a row, ex: <code>8</code>", client, updateChan,
+	)
+	if err != nil {
+		return fmt.Errorf("request correct answers: %w", err)
+	}
+	if back {
+		return nil
+	}
+
+	intervalDays, _, back, err := requestNumber(
+		ctx, chatID, "Send the interval in days, ex: <code>90</code>", client, updateChan,
+	)
+	if err != nil {
+		return fmt.Errorf("request interval: %w", err)
+	}
+	if back {
+		return nil
+	}
+
+	req := &api.UpdateGraduationPolicyRequest{
+		UserID: strings.TrimSpace(userName),
+		Policy: &api.GraduationPolicy{
+			CorrectAnswers: correctAnswers,
+			Interval:       durationpb.New(time.Duration(intervalDays) * day),
+		},
+	}
+
+	resp, err := s.laleRepo.Client.UpdateGraduationPolicy(ctx, req)
+	if err != nil {
+		return client.SendWithParseMode(
+			chatID,
+			fmt.Sprintf("<code>grpc [UpdateGraduationPolicy] err: %s</code>", err.Error()),
+			tg.ModeHTML,
+		)
+	}
+
+	return client.SendWithParseMode(
+		chatID,
+		fmt.Sprintf(
+			"Graduation updated, <code>%d</code> correct answers in a row or <code>%d</code> days interval",
+			resp.GetCorrectAnswers(), int64(resp.GetInterval().AsDuration()/day),
+		),
+		tg.ModeHTML,
+	)
+}
+
+// requestNumber requests a non-negative number, zero is a valid value, so the input is kept behind a pointer
+// until a valid number is received.
+func requestNumber(
+	ctx context.Context,
+	chatID int64,
+	msg string,
+	client processor.Client,
+	updateChan tg.UpdatesChannel,
+) (uint32, string, bool, error) {
+	number, userName, back, err := auxl.RequestInput(
+		ctx,
+		func(n *uint32) bool {
+			return n != nil
+		},
+		chatID,
+		msg,
+		func(input string, chatID int64, client processor.Client) (*uint32, error) {
+			parsed, err := strconv.ParseUint(strings.TrimSpace(input), 10, 32)
+			if err != nil {
+				return nil, client.SendWithParseMode(chatID, fmt.Sprintf("Invalid number <code>%s</code>", input), tg.ModeHTML)
+			}
+			n := uint32(parsed)
+			return &n, nil
+		},
+		client,
+		updateChan,
+	)
+	if err != nil || back || number == nil {
+		return 0, userName, back, err
+	}
+
+	return *number, userName, false, nil
+}
+
+func (s *State) Command() string {
+	return Command
+}
+
+func (s *State) Description() string {
+	return "Set when the cards are marked learnt automatically"
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/genvmoroz/lale-tg-client/internal/state/cardseq"
 	"github.com/genvmoroz/lale/service/api"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
 )
 
 type State struct {
@@ -36,12 +37,12 @@ func (s *State) Process(ctx context.Context, client processor.Client, chatID int
 		return err
 	}
 
-	language, userName, back, err := auxl.RequestInput[string](
+	input, userName, back, err := auxl.RequestInput[string](
 		ctx,
 		isStringNotBlank,
 		chatID,
 
-		"Send the language, ex: <code>en</code>",
+		"Send the language, ex: <code>en</code>\n"+auxl.FilterHint,
 		func(input string, _ int64, _ processor.Client) (string, error) {
 			return strings.TrimSpace(input), nil
 		},
@@ -55,9 +56,11 @@ func (s *State) Process(ctx context.Context, client processor.Client, chatID int
 		return nil
 	}
 
+	language, filter := auxl.ParseLanguageAndFilter(input)
 	req := &api.GetCardsRequest{
 		UserID:   userName,
 		Language: language,
+		Filter:   filter,
 	}
 
 	resp, err := s.laleRepo.Client.GetCardsToLearn(ctx, req)
@@ -75,6 +78,12 @@ func (s *State) Process(ctx context.Context, client processor.Client, chatID int
 	if err = client.SendWithParseMode(chatID, fmt.Sprintf("Found <code>%d</code> Cards to learn", len(resp.GetCards())), tg.ModeHTML); err != nil {
 		return err
 	}
+	if resp.GetPostponed() > 0 {
+		msg := fmt.Sprintf("Daily limit reached, <code>%d</code> Cards postponed until tomorrow", resp.GetPostponed())
+		if err = client.SendWithParseMode(chatID, msg, tg.ModeHTML); err != nil {
+			return err
+		}
+	}
 
 	cards := cardseq.NewCards(ctx, s.laleRepo, resp, 1, 1)
 
@@ -91,7 +100,7 @@ func (s *State) Process(ctx context.Context, client processor.Client, chatID int
 			continue
 		}
 
-		if len(card.Words) == 0 {
+		if len(card.Words) == 0 && !auxl.IsNoteCard(card.Card) {
 			if err = client.SendWithParseMode(chatID, fmt.Sprintf("No words for Card <code>%s</code>. Inspect the card if it has no words", card.Card.GetId()), tg.ModeHTML); err != nil {
 				return err
 			}
@@ -104,6 +113,16 @@ func (s *State) Process(ctx context.Context, client processor.Client, chatID int
 			}
 		}
 
+		if auxl.IsNoteCard(card.Card) {
+			_, _, back, err = auxl.AskNote(ctx, chatID, card.Card, client, updateChan)
+			if err != nil {
+				return err
+			}
+			if back {
+				return nil
+			}
+		}
+
 		for i, word := range card.Words {
 			if err = client.Send(chatID, "Word:"); err != nil {
 				return err
@@ -117,7 +136,7 @@ func (s *State) Process(ctx context.Context, client processor.Client, chatID int
 				}
 			}
 
-			err = auxl.SendAudioByLanguage(chatID, client, word.GetAudioByLanguage())
+			err = auxl.SendAudioByLanguage(ctx, chatID, client, s.laleRepo.Client, card.Card.GetUserID(), word.GetAudioIDs())
 			if err != nil {
 				if err = client.Send(chatID, fmt.Sprintf("sending audio error: %v", err.Error())); err != nil {
 					return err
@@ -220,13 +239,17 @@ func (s *State) Process(ctx context.Context, client processor.Client, chatID int
 		}
 
 		perfReq := &api.UpdateCardPerformanceRequest{
-			UserID:         card.Card.GetUserID(),
-			CardID:         card.Card.GetId(),
-			IsInputCorrect: false,
+			UserID:      card.Card.GetUserID(),
+			CardID:      card.Card.GetId(),
+			Performance: proto.Uint32(auxl.RatingAgain),
 		}
 
-		resp, err := s.laleRepo.Client.UpdateCardPerformance(ctx, perfReq)
-		if err != nil {
+		resp, err := auxl.UpdateCardPerformance(ctx, s.laleRepo.Client, card.Card, perfReq)
+		if auxl.IsConflict(err) {
+			if err = client.Send(chatID, auxl.AnswerConflictMessage); err != nil {
+				return err
+			}
+		} else if err != nil {
 			if err = client.SendWithParseMode(chatID, fmt.Sprintf("<code>grpc [UpdateCardPerformance] err: %s</code>", err.Error()), tg.ModeHTML); err != nil {
 				return err
 			}
@@ -287,7 +310,7 @@ func (s *State) processFirstReview(
 	updateChan tg.UpdatesChannel,
 	card cardseq.Card,
 ) (bool, error) {
-	if len(card.Words) == 0 {
+	if len(card.Words) == 0 && !auxl.IsNoteCard(card.Card) {
 		return false, client.SendWithParseMode(chatID, fmt.Sprintf("No words for Card <code>%s</code>. Inspect the card if it has no words", card.Card.GetId()), tg.ModeHTML)
 	}
 
@@ -297,6 +320,12 @@ func (s *State) processFirstReview(
 		}
 	}
 
+	if auxl.IsNoteCard(card.Card) {
+		if err := client.SendWithParseMode(chatID, pretty.Note(card.Card), tg.ModeHTML); err != nil {
+			return false, err
+		}
+	}
+
 	for i, word := range card.Words {
 		if err := client.Send(chatID, fmt.Sprintf("Word: %s", word.GetWord())); err != nil {
 			return false, err
@@ -310,7 +339,7 @@ func (s *State) processFirstReview(
 			}
 		}
 
-		err := auxl.SendAudioByLanguage(chatID, client, word.GetAudioByLanguage())
+		err := auxl.SendAudioByLanguage(ctx, chatID, client, s.laleRepo.Client, card.Card.GetUserID(), word.GetAudioIDs())
 		if err != nil {
 			if err = client.Send(chatID, fmt.Sprintf("sending audio error: %v", err.Error())); err != nil {
 				return false, err
@@ -371,13 +400,17 @@ func (s *State) processFirstReview(
 	}
 
 	perfReq := &api.UpdateCardPerformanceRequest{
-		UserID:         card.Card.GetUserID(),
-		CardID:         card.Card.GetId(),
-		IsInputCorrect: false,
+		UserID:      card.Card.GetUserID(),
+		CardID:      card.Card.GetId(),
+		Performance: proto.Uint32(auxl.RatingAgain),
 	}
 
-	resp, err := s.laleRepo.Client.UpdateCardPerformance(ctx, perfReq)
-	if err != nil {
+	resp, err := auxl.UpdateCardPerformance(ctx, s.laleRepo.Client, card.Card, perfReq)
+	if auxl.IsConflict(err) {
+		if err = client.Send(chatID, auxl.AnswerConflictMessage); err != nil {
+			return false, err
+		}
+	} else if err != nil {
 		if err = client.SendWithParseMode(chatID, fmt.Sprintf("<code>grpc [UpdateCardPerformance] err: %s</code>", err.Error()), tg.ModeHTML); err != nil {
 			return false, err
 		}
@@ -0,0 +1,87 @@
+package leeches
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/genvmoroz/bot-engine/processor"
+	"github.com/genvmoroz/bot-engine/tg"
+	"github.com/genvmoroz/lale-tg-client/internal/auxl"
+	"github.com/genvmoroz/lale-tg-client/internal/pretty"
+	"github.com/genvmoroz/lale-tg-client/internal/repository"
+	"github.com/genvmoroz/lale/service/api"
+)
+
+type State struct {
+	laleRepo *repository.LaleRepo
+}
+
+const Command = "/leeches"
+
+func NewState(laleRepo *repository.LaleRepo) *State {
+	return &State{laleRepo: laleRepo}
+}
+
+const initialMessage = `
+Leeches
+The cards you keep forgetting, rewrite them with /update to give them another chance
+`
+
+func (s *State) Process(ctx context.Context, client processor.Client, chatID int64, updateChan tg.UpdatesChannel) error {
+	if err := client.Send(chatID, initialMessage); err != nil {
+		return err
+	}
+
+	language, userName, back, err := auxl.RequestInput(
+		ctx,
+		func(s string) bool {
+			return s != ""
+		},
+		chatID,
+		"Send the language, ex: <code>en</code>",
+		func(input string, _ int64, _ processor.Client) (string, error) {
+			return strings.ToLower(strings.TrimSpace(input)), nil
+		},
+		client,
+		updateChan,
+	)
+	if err != nil {
+		return fmt.Errorf("request language: %w", err)
+	}
+	if back {
+		return nil
+	}
+
+	req := &api.GetCardsRequest{
+		UserID:   strings.TrimSpace(userName),
+		Language: language,
+	}
+
+	resp, err := s.laleRepo.Client.GetLeeches(ctx, req)
+	if err != nil {
+		return client.SendWithParseMode(
+			chatID,
+			fmt.Sprintf("<code>grpc [GetLeeches] err: %s</code>", err.Error()),
+			tg.ModeHTML,
+		)
+	}
+
+	for _, card := range resp.GetCards() {
+		for _, msg := range pretty.Card(card, true) {
+			if err = client.SendWithParseMode(chatID, msg, tg.ModeHTML); err != nil {
+				return err
+			}
+		}
+	}
+
+	return client.SendWithParseMode(chatID, fmt.Sprintf("Leeches found <code>%d</code>", len(resp.GetCards())), tg.ModeHTML)
+}
+
+func (s *State) Command() string {
+	return Command
+}
+
+func (s *State) Description() string {
+	return "List the cards you keep forgetting"
+}
@@ -0,0 +1,140 @@
+package limits
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/genvmoroz/bot-engine/processor"
+	"github.com/genvmoroz/bot-engine/tg"
+	"github.com/genvmoroz/lale-tg-client/internal/auxl"
+	"github.com/genvmoroz/lale-tg-client/internal/repository"
+	"github.com/genvmoroz/lale/service/api"
+)
+
+type State struct {
+	laleRepo *repository.LaleRepo
+}
+
+const Command = "/limits"
+
+func NewState(laleRepo *repository.LaleRepo) *State {
+	return &State{laleRepo: laleRepo}
+}
+
+const initialMessage = `
+Daily Limits
+Set how many new cards and reviews of the language you study per day
+`
+
+func (s *State) Process(ctx context.Context, client processor.Client, chatID int64, updateChan tg.UpdatesChannel) error {
+	if err := client.Send(chatID, initialMessage); err != nil {
+		return err
+	}
+
+	language, userName, back, err := auxl.RequestInput(
+		ctx,
+		func(s string) bool {
+			return strings.TrimSpace(s) != ""
+		},
+		chatID,
+		"Send the language, ex: <code>en</code>",
+		func(input string, _ int64, _ processor.Client) (string, error) {
+			return strings.TrimSpace(input), nil
+		},
+		client,
+		updateChan,
+	)
+	if err != nil {
+		return fmt.Errorf("request language: %w", err)
+	}
+	if back {
+		return nil
+	}
+
+	newCards, back, err := requestNumber(ctx, chatID, "Send the number of new cards per day, ex: <code>20</code>", client, updateChan)
+	if err != nil {
+		return fmt.Errorf("request new cards limit: %w", err)
+	}
+	if back {
+		return nil
+	}
+
+	reviews, back, err := requestNumber(ctx, chatID, "Send the number of reviews per day, ex: <code>200</code>", client, updateChan)
+	if err != nil {
+		return fmt.Errorf("request reviews limit: %w", err)
+	}
+	if back {
+		return nil
+	}
+
+	req := &api.UpdateDailyLimitsRequest{
+		UserID:   strings.TrimSpace(userName),
+		Language: language,
+		Limits: &api.DailyLimits{
+			NewCards: newCards,
+			Reviews:  reviews,
+		},
+	}
+
+	resp, err := s.laleRepo.Client.UpdateDailyLimits(ctx, req)
+	if err != nil {
+		return client.SendWithParseMode(
+			chatID,
+			fmt.Sprintf("<code>grpc [UpdateDailyLimits] err: %s</code>", err.Error()),
+			tg.ModeHTML,
+		)
+	}
+
+	return client.SendWithParseMode(
+		chatID,
+		fmt.Sprintf(
+			"Daily limits updated, <code>%d</code> new cards and <code>%d</code> reviews of <code>%s</code> per day",
+			resp.GetNewCards(), resp.GetReviews(), language,
+		),
+		tg.ModeHTML,
+	)
+}
+
+// requestNumber requests a non-negative number, zero is a valid value, so the input is kept behind a pointer
+// until a valid number is received.
+func requestNumber(
+	ctx context.Context,
+	chatID int64,
+	msg string,
+	client processor.Client,
+	updateChan tg.UpdatesChannel,
+) (uint32, bool, error) {
+	number, _, back, err := auxl.RequestInput(
+		ctx,
+		func(n *uint32) bool {
+			return n != nil
+		},
+		chatID,
+		msg,
+		func(input string, chatID int64, client processor.Client) (*uint32, error) {
+			parsed, err := strconv.ParseUint(input, 10, 32)
+			if err != nil {
+				return nil, client.SendWithParseMode(chatID, fmt.Sprintf("Invalid number <code>%s</code>", input), tg.ModeHTML)
+			}
+			n := uint32(parsed)
+			return &n, nil
+		},
+		client,
+		updateChan,
+	)
+	if err != nil || back || number == nil {
+		return 0, back, err
+	}
+
+	return *number, false, nil
+}
+
+func (s *State) Command() string {
+	return Command
+}
+
+func (s *State) Description() string {
+	return "Set daily limits of new cards and reviews"
+}
@@ -0,0 +1,159 @@
+package profile
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/genvmoroz/bot-engine/processor"
+	"github.com/genvmoroz/bot-engine/tg"
+	"github.com/genvmoroz/lale-tg-client/internal/auxl"
+	"github.com/genvmoroz/lale-tg-client/internal/repository"
+	"github.com/genvmoroz/lale/service/api"
+	"github.com/genvmoroz/lale/service/pkg/entity"
+)
+
+type State struct {
+	laleRepo *repository.LaleRepo
+}
+
+const Command = "/profile"
+
+func NewState(laleRepo *repository.LaleRepo) *State {
+	return &State{laleRepo: laleRepo}
+}
+
+const initialMessage = `
+Profile
+Set the time zone and the time your day starts at, cards become due at the start of your day,
+and the directions the word cards are repeated in
+`
+
+const directionsMessage = `
+Send the directions to repeat the word cards in besides the translation to word, separated by spaces:
+<code>recognition</code> - the word to its translation
+<code>listening</code> - the audio to the word
+or <code>none</code>
+`
+
+// noDirections keeps repeating the cards by the translation only.
+const noDirections = "none"
+
+func (s *State) Process(ctx context.Context, client processor.Client, chatID int64, updateChan tg.UpdatesChannel) error {
+	if err := client.Send(chatID, initialMessage); err != nil {
+		return err
+	}
+
+	timeZone, userName, back, err := auxl.RequestInput(
+		ctx,
+		func(s string) bool {
+			return s != ""
+		},
+		chatID,
+		"Send the time zone, ex: <code>Asia/Tokyo</code> or <code>UTC</code>",
+		func(input string, chatID int64, client processor.Client) (string, error) {
+			if _, err := time.LoadLocation(input); err != nil {
+				return "", client.SendWithParseMode(chatID, fmt.Sprintf("Unknown time zone <code>%s</code>", input), tg.ModeHTML)
+			}
+			return input, nil
+		},
+		client,
+		updateChan,
+	)
+	if err != nil {
+		return fmt.Errorf("request time zone: %w", err)
+	}
+	if back {
+		return nil
+	}
+
+	dayStart, _, back, err := auxl.RequestInput(
+		ctx,
+		func(s string) bool {
+			return s != ""
+		},
+		chatID,
+		"Send the time your day starts at, ex: <code>04:00</code>",
+		func(input string, chatID int64, client processor.Client) (string, error) {
+			if _, err := time.Parse("15:04", input); err != nil {
+				return "", client.SendWithParseMode(chatID, fmt.Sprintf("Invalid time <code>%s</code>, use HH:MM", input), tg.ModeHTML)
+			}
+			return input, nil
+		},
+		client,
+		updateChan,
+	)
+	if err != nil {
+		return fmt.Errorf("request day start: %w", err)
+	}
+	if back {
+		return nil
+	}
+
+	directions, _, back, err := auxl.RequestInput(
+		ctx,
+		func(directions []string) bool {
+			return directions != nil
+		},
+		chatID,
+		directionsMessage,
+		func(input string, chatID int64, client processor.Client) ([]string, error) {
+			fields := strings.Fields(strings.ToLower(input))
+			if len(fields) == 1 && fields[0] == noDirections {
+				return []string{}, nil
+			}
+			for _, field := range fields {
+				if !slices.Contains(entity.ReviewDirections, entity.ReviewDirection(field)) {
+					return nil, client.SendWithParseMode(chatID, fmt.Sprintf("Unknown direction <code>%s</code>", field), tg.ModeHTML)
+				}
+			}
+			if len(fields) == 0 {
+				return nil, nil
+			}
+			return fields, nil
+		},
+		client,
+		updateChan,
+	)
+	if err != nil {
+		return fmt.Errorf("request directions: %w", err)
+	}
+	if back {
+		return nil
+	}
+
+	req := &api.UpdateUserProfileRequest{
+		UserID: strings.TrimSpace(userName),
+		Profile: &api.UserProfile{
+			TimeZone:   timeZone,
+			DayStart:   dayStart,
+			Directions: directions,
+		},
+	}
+
+	resp, err := s.laleRepo.Client.UpdateUserProfile(ctx, req)
+	if err != nil {
+		return client.SendWithParseMode(
+			chatID,
+			fmt.Sprintf("<code>grpc [UpdateUserProfile] err: %s</code>", err.Error()),
+			tg.ModeHTML,
+		)
+	}
+
+	msg := fmt.Sprintf("Profile updated, your day starts at <code>%s</code> in <code>%s</code>", resp.GetDayStart(), resp.GetTimeZone())
+	if len(resp.GetDirections()) != 0 {
+		msg += fmt.Sprintf(", the word cards are also repeated in <code>%s</code>", strings.Join(resp.GetDirections(), " "))
+	}
+
+	return client.SendWithParseMode(chatID, msg, tg.ModeHTML)
+}
+
+func (s *State) Command() string {
+	return Command
+}
+
+func (s *State) Description() string {
+	return "Set time zone, day start and repeat directions"
+}
@@ -0,0 +1,218 @@
+package repeat
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/genvmoroz/bot-engine/processor"
+	"github.com/genvmoroz/bot-engine/tg"
+	"github.com/genvmoroz/lale-tg-client/internal/auxl"
+	"github.com/genvmoroz/lale-tg-client/internal/repository"
+	"github.com/genvmoroz/lale-tg-client/internal/state/cardseq"
+	"github.com/genvmoroz/lale/service/api"
+)
+
+// CramState drills the cards the same way they are repeated, but the answers don't change their schedule.
+type CramState struct {
+	laleRepo *repository.LaleRepo
+}
+
+const CramCommand = "/cram"
+
+// Cram orders accepted by the service.
+const (
+	cramOrderHardest = "hardest"
+	cramOrderRecent  = "recent"
+	cramOrderRandom  = "random"
+)
+
+// allCards selects all the cards of the language instead of a subset.
+const allCards = "all"
+
+func NewCramState(laleRepo *repository.LaleRepo) *CramState {
+	return &CramState{laleRepo: laleRepo}
+}
+
+const cramInitialMessage = `
+Cram Words State
+Drill the Words with the language before an exam, the answers don't affect the repetition schedule
+`
+
+const cramOrderMessage = `
+Send the order of the Cards:
+<code>hardest</code> - the most forgotten and difficult first
+<code>recent</code> - the most recently added first
+<code>random</code> - shuffled
+`
+
+func (s *CramState) Process(
+	ctx context.Context,
+	client processor.Client,
+	chatID int64,
+	updateChan tg.UpdatesChannel,
+) error {
+	if err := client.Send(chatID, cramInitialMessage); err != nil {
+		return err
+	}
+
+	language, userName, back, err := auxl.RequestInput(
+		ctx,
+		isStringNotBlank,
+		chatID,
+		"Send the language, ex: <code>en</code>",
+		func(input string, _ int64, _ processor.Client) (string, error) {
+			return strings.TrimSpace(input), nil
+		},
+		client,
+		updateChan,
+	)
+	if err != nil {
+		return fmt.Errorf("request language: %w", err)
+	}
+	if back {
+		return nil
+	}
+
+	cardIDs, _, back, err := auxl.RequestInput(
+		ctx,
+		func(ids []string) bool {
+			return ids != nil
+		},
+		chatID,
+		fmt.Sprintf("Send the Card IDs separated by spaces or <code>%s</code> to cram all the Cards", allCards),
+		func(input string, _ int64, _ processor.Client) ([]string, error) {
+			fields := strings.Fields(input)
+			if len(fields) == 1 && strings.EqualFold(fields[0], allCards) {
+				return []string{}, nil
+			}
+			if len(fields) == 0 {
+				return nil, nil
+			}
+			return fields, nil
+		},
+		client,
+		updateChan,
+	)
+	if err != nil {
+		return fmt.Errorf("request card IDs: %w", err)
+	}
+	if back {
+		return nil
+	}
+
+	order, _, back, err := auxl.RequestInput(
+		ctx,
+		isStringNotBlank,
+		chatID,
+		cramOrderMessage,
+		func(input string, chatID int64, client processor.Client) (string, error) {
+			order := strings.ToLower(strings.TrimSpace(input))
+			switch order {
+			case cramOrderHardest, cramOrderRecent, cramOrderRandom:
+				return order, nil
+			default:
+				return "", client.SendWithParseMode(chatID, fmt.Sprintf("Unknown order <code>%s</code>", input), tg.ModeHTML)
+			}
+		},
+		client,
+		updateChan,
+	)
+	if err != nil {
+		return fmt.Errorf("request order: %w", err)
+	}
+	if back {
+		return nil
+	}
+
+	req := &api.GetCardsForCramRequest{
+		UserID:   userName,
+		Language: language,
+		CardIDs:  cardIDs,
+		Order:    order,
+	}
+
+	resp, err := s.laleRepo.Client.GetCardsForCram(ctx, req)
+	if err != nil {
+		return client.SendWithParseMode(chatID, fmt.Sprintf("<code>grpc [GetCardsForCram] err: %s</code>", err.Error()), tg.ModeHTML)
+	}
+
+	if err = client.SendWithParseMode(chatID, fmt.Sprintf("Found <code>%d</code> Cards to cram", len(resp.GetCards())), tg.ModeHTML); err != nil {
+		return err
+	}
+
+	cards := cardseq.NewCards(ctx, s.laleRepo, resp, 1, 1)
+
+	var forgotten int
+	for cards.HasNext() {
+		card := cards.Next(ctx)
+
+		if len(card.Words) == 0 && !auxl.IsNoteCard(card.Card) {
+			if err = client.SendWithParseMode(chatID, fmt.Sprintf("No words for Card <code>%s</code>. Inspect the card if it has no words", card.Card.GetId()), tg.ModeHTML); err != nil {
+				return err
+			}
+			continue
+		}
+
+		performance, _, back, err := askWords(ctx, s.laleRepo, client, chatID, updateChan, card)
+		if err != nil {
+			return err
+		}
+		if back {
+			return nil
+		}
+		if performance == auxl.RatingAgain {
+			forgotten++
+		}
+
+		if err = client.Send(chatID, fmt.Sprintf("Remaining %d cards to cram", cards.Remaining())); err != nil {
+			return err
+		}
+		if cards.Remaining() == 0 {
+			break
+		}
+
+		_, _, back, err = auxl.RequestInput(
+			ctx,
+			func(s *bool) bool {
+				return s != nil
+			},
+			chatID,
+			"Write <code>next</code> to cram next Card",
+			func(input string, chatID int64, client processor.Client) (*bool, error) {
+				text := strings.ToLower(strings.TrimSpace(input))
+				switch text {
+				case "":
+					return nil, client.Send(chatID, "Empty value is not allowed")
+				case "next":
+					t := true
+					return &t, nil
+				default:
+					return nil, client.SendWithParseMode(chatID, fmt.Sprintf("Invalid value <code>%s</code>, enter <code>/back</code> to go to the previous state", text), tg.ModeHTML)
+				}
+			},
+			client,
+			updateChan,
+		)
+		if err != nil {
+			return fmt.Errorf("request next card: %w", err)
+		}
+		if back {
+			return nil
+		}
+	}
+
+	return client.SendWithParseMode(
+		chatID,
+		fmt.Sprintf("Cram finished, crammed <code>%d</code> cards, forgot <code>%d</code>", len(resp.GetCards()), forgotten),
+		tg.ModeHTML,
+	)
+}
+
+func (s *CramState) Command() string {
+	return CramCommand
+}
+
+func (s *CramState) Description() string {
+	return "Cram Cards without affecting their schedule"
+}
@@ -3,7 +3,10 @@ package repeat
 import (
 	"context"
 	"fmt"
+	"html"
+	"maps"
 	"math/rand"
+	"slices"
 	"strings"
 	"time"
 
@@ -14,8 +17,11 @@ import (
 	"github.com/genvmoroz/lale-tg-client/internal/repository"
 	"github.com/genvmoroz/lale-tg-client/internal/state/cardseq"
 	"github.com/genvmoroz/lale/service/api"
+	"github.com/genvmoroz/lale/service/pkg/entity"
 	"github.com/hako/durafmt"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 type State struct {
@@ -38,12 +44,12 @@ func (s *State) Process(ctx context.Context, client processor.Client, chatID int
 		return err
 	}
 
-	language, userName, back, err := auxl.RequestInput(
+	input, userName, back, err := auxl.RequestInput(
 		ctx,
 		isStringNotBlank,
 		chatID,
 
-		"Send the language, ex: <code>en</code>",
+		"Send the language, ex: <code>en</code>\n"+auxl.FilterHint,
 		func(input string, _ int64, _ processor.Client) (string, error) {
 			return strings.TrimSpace(input), nil
 		},
@@ -57,9 +63,11 @@ func (s *State) Process(ctx context.Context, client processor.Client, chatID int
 		return nil
 	}
 
+	language, filter := auxl.ParseLanguageAndFilter(input)
 	req := &api.GetCardsRequest{
 		UserID:   userName,
 		Language: language,
+		Filter:   filter,
 	}
 
 	resp, err := s.laleRepo.Client.GetCardsToRepeat(ctx, req)
@@ -77,15 +85,20 @@ func (s *State) Process(ctx context.Context, client processor.Client, chatID int
 	if err = client.SendWithParseMode(chatID, fmt.Sprintf("Found <code>%d</code> Cards to repeat", len(resp.GetCards())), tg.ModeHTML); err != nil {
 		return err
 	}
+	if resp.GetPostponed() > 0 {
+		msg := fmt.Sprintf("Daily limit reached, <code>%d</code> Cards postponed until tomorrow", resp.GetPostponed())
+		if err = client.SendWithParseMode(chatID, msg, tg.ModeHTML); err != nil {
+			return err
+		}
+	}
 
 	cards := cardseq.NewCards(ctx, s.laleRepo, resp, 1, 1)
 
 	for cards.HasNext() {
 		card := cards.Next(ctx)
 
-		isAnswerCorrect := true
-
-		if card.Card.GetNextDueDate().AsTime().Equal(time.Time{}) {
+		// a direction other than production starts with the card already introduced in production
+		if card.Card.GetNextDueDate().AsTime().Equal(time.Time{}) && isProduction(card.Card) {
 			if back, err = s.processFirstRepeat(ctx, client, chatID, updateChan, card); err != nil {
 				return err
 			}
@@ -95,195 +108,45 @@ func (s *State) Process(ctx context.Context, client processor.Client, chatID int
 			continue
 		}
 
-		if len(card.Words) == 0 {
+		if len(card.Words) == 0 && !auxl.IsNoteCard(card.Card) {
 			if err = client.SendWithParseMode(chatID, fmt.Sprintf("No words for Card <code>%s</code>. Inspect the card if it has no words", card.Card.GetId()), tg.ModeHTML); err != nil {
 				return err
 			}
 			continue
 		}
 
-		for _, msg := range pretty.Card(card.Card, false) {
-			if err = client.SendWithParseMode(chatID, msg, tg.ModeHTML); err != nil {
+		if card.Card.GetLearnt() {
+			if err = client.Send(chatID, "Retention check of a learnt card, a wrong answer returns it to the repetition"); err != nil {
 				return err
 			}
 		}
 
-		for i, word := range card.Words {
-			if err = client.Send(chatID, "Word:"); err != nil {
-				return err
-			}
-			if err = client.SendWithParseMode(chatID, pretty.Translation(word.GetTranslation()), tg.ModeHTML); err != nil {
-				return err
-			}
-			for _, meaning := range word.GetMeanings() {
-				if err = client.Send(chatID, pretty.MeaningWithoutExamples(meaning)); err != nil {
-					return err
-				}
-			}
-
-			// todo: implement hinting on the server side
-			if card.Card.ConsecutiveCorrectAnswersNumber <= 8 {
-				hint := ""
-				switch card.Card.ConsecutiveCorrectAnswersNumber {
-				case 0, 1, 2:
-					hint = shuffleLetters(word.GetWord())
-				default:
-					hint = maskWord(word.GetWord(), card.Card.ConsecutiveCorrectAnswersNumber)
-				}
-				if err = client.Send(chatID, "Hint: "+hint); err != nil {
-					return err
-				}
-			}
-
-			var lastIncorrectInput string
-			checkWord := func(input string, chtID int64, cl processor.Client) (*bool, error) {
-				text := strings.ToLower(strings.TrimSpace(input))
-				switch text {
-				case "/back":
-					return nil, cl.Send(chtID, "Back to previous state")
-				case "":
-					return nil, cl.Send(chtID, "Empty value is not allowed")
-				default:
-					if strings.EqualFold(text, word.GetWord()) {
-						t := true
-						return &t, nil
-					}
-					lastIncorrectInput = text
-					t := false
-					return &t, nil
-				}
-			}
-
-			const secondAttemptErrorThresholdPct = 20 // give second attempt only if error < 20%
-
-			correct, _, back, err := auxl.RequestInput(
-				ctx,
-				func(u *bool) bool {
-					return u != nil
-				},
-				chatID,
-				"Send the Word",
-				checkWord,
-				client,
-				updateChan,
-			)
-			if err != nil {
-				return fmt.Errorf("request word: %w", err)
-			}
-			if back {
-				return nil
-			}
-
-			if correct != nil && *correct {
-				if err = client.Send(chatID, "Correct"); err != nil {
-					return err
-				}
-			} else {
-				errorPct := wordErrorPercent(lastIncorrectInput, word.GetWord())
-				if errorPct >= secondAttemptErrorThresholdPct {
-					if err = client.SendWithParseMode(chatID, fmt.Sprintf("Incorrect, inspect word <code>%s</code> first", word.GetWord()), tg.ModeHTML); err != nil {
-						return err
-					}
-					isAnswerCorrect = false
-				} else {
-					if err = client.Send(chatID, "Incorrect, try again"); err != nil {
-						return err
-					}
-					correct, _, back, err = auxl.RequestInput(
-						ctx,
-						func(u *bool) bool {
-							return u != nil
-						},
-						chatID,
-						"Send the Word (second attempt)",
-						checkWord,
-						client,
-						updateChan,
-					)
-					if err != nil {
-						return fmt.Errorf("request word second attempt: %w", err)
-					}
-					if back {
-						return nil
-					}
-					if correct != nil && *correct {
-						if err = client.Send(chatID, "Correct"); err != nil {
-							return err
-						}
-					} else {
-						if err = client.SendWithParseMode(chatID, fmt.Sprintf("Incorrect, inspect word <code>%s</code> first", word.GetWord()), tg.ModeHTML); err != nil {
-							return err
-						}
-						isAnswerCorrect = false
-					}
-				}
-			}
-			err = auxl.SendAudioByLanguage(chatID, client, word.GetAudioByLanguage())
-			if err != nil {
-				if err = client.Send(chatID, fmt.Sprintf("sending audio error: %v", err.Error())); err != nil {
-					return err
-				}
-			}
-			if err = client.Send(chatID, "Sentences:"); err != nil {
-				return err
-			}
-
-			task := card.Sentences[word.GetWord()]
-			if task == nil {
-				return fmt.Errorf("sentences task missing for word %q", word.GetWord())
-			}
-			sentences, err := task.Get(time.Minute)
-			if err != nil {
-				if sendErr := client.Send(chatID, fmt.Sprintf("getting sentences error: %s", err.Error())); sendErr != nil {
-					return fmt.Errorf("send error [%s]: %w", err.Error(), sendErr)
-				}
-			}
-			for _, sentence := range sentences {
-				if err = client.Send(chatID, sentence); err != nil {
-					return err
-				}
-			}
-
-			if i != len(card.Words)-1 {
-				_, _, back, err = auxl.RequestInput[*bool](
-					ctx,
-					func(s *bool) bool {
-						return s != nil
-					},
-					chatID,
-					"Write <code>next</code> to repeat next word",
-					func(input string, chatID int64, client processor.Client) (*bool, error) {
-						text := strings.ToLower(strings.TrimSpace(input))
-						switch text {
-						case "":
-							return nil, client.Send(chatID, "Empty value is not allowed")
-						case "next":
-							t := true
-							return &t, nil
-						default:
-							return nil, client.SendWithParseMode(chatID, fmt.Sprintf("Invalid value <code>%s</code>, enter <code>/back</code> to go to the previous state", text), tg.ModeHTML)
-						}
-					},
-					client,
-					updateChan,
-				)
-				if err != nil {
-					return fmt.Errorf("request input: %w", err)
-				}
-				if back {
-					return nil
-				}
-			}
+		var (
+			performance  uint32
+			timeToAnswer time.Duration
+		)
+		performance, timeToAnswer, back, err = askWords(ctx, s.laleRepo, client, chatID, updateChan, card)
+		if err != nil {
+			return err
+		}
+		if back {
+			return nil
 		}
 
 		perfReq := &api.UpdateCardPerformanceRequest{
-			UserID:         card.Card.GetUserID(),
-			CardID:         card.Card.GetId(),
-			IsInputCorrect: isAnswerCorrect,
+			UserID:       card.Card.GetUserID(),
+			CardID:       card.Card.GetId(),
+			Performance:  proto.Uint32(performance),
+			TimeToAnswer: durationpb.New(timeToAnswer),
+			Direction:    card.Card.GetDirection(),
 		}
 
-		resp, err := s.laleRepo.Client.UpdateCardPerformance(ctx, perfReq)
-		if err != nil {
+		resp, err := auxl.UpdateCardPerformance(ctx, s.laleRepo.Client, card.Card, perfReq)
+		if auxl.IsConflict(err) {
+			if err = client.Send(chatID, auxl.AnswerConflictMessage); err != nil {
+				return err
+			}
+		} else if err != nil {
 			if err = client.SendWithParseMode(chatID, fmt.Sprintf("<code>grpc [UpdateCardPerformance] err: %s</code>", err.Error()), tg.ModeHTML); err != nil {
 				return err
 			}
@@ -295,6 +158,11 @@ func (s *State) Process(ctx context.Context, client processor.Client, chatID int
 		if err = client.Send(chatID, fmt.Sprintf("At %s", resp.GetNextDueDate().AsTime())); err != nil {
 			return err
 		}
+		if resp.GetGraduated() {
+			if err = client.Send(chatID, "The card graduated and is marked learnt"); err != nil {
+				return err
+			}
+		}
 		if err = client.Send(chatID, fmt.Sprintf("Remaining %d cards to repeat", cards.Remaining())); err != nil {
 			return err
 		}
@@ -302,23 +170,22 @@ func (s *State) Process(ctx context.Context, client processor.Client, chatID int
 			break
 		}
 
-		_, _, back, err := auxl.RequestInput[*bool](
+		action, _, back, err := auxl.RequestInput(
 			ctx,
-			func(s *bool) bool {
-				return s != nil
+			func(s string) bool {
+				return s != ""
 			},
 			chatID,
-			"Write <code>next</code> to repeat next Card",
-			func(input string, chatID int64, client processor.Client) (*bool, error) {
+			fmt.Sprintf("Write <code>next</code> to repeat next Card or <code>%s</code> to answer this Card again", undoCommand),
+			func(input string, chatID int64, client processor.Client) (string, error) {
 				text := strings.ToLower(strings.TrimSpace(input))
 				switch text {
 				case "":
-					return nil, client.Send(chatID, "Empty value is not allowed")
-				case "next":
-					t := true
-					return &t, nil
+					return "", client.Send(chatID, "Empty value is not allowed")
+				case "next", undoCommand:
+					return text, nil
 				default:
-					return nil, client.SendWithParseMode(chatID, fmt.Sprintf("Invalid value <code>%s</code>, enter <code>/back</code> to go to the previous state", text), tg.ModeHTML)
+					return "", client.SendWithParseMode(chatID, fmt.Sprintf("Invalid value <code>%s</code>, enter <code>/back</code> to go to the previous state", text), tg.ModeHTML)
 				}
 			},
 			client,
@@ -330,6 +197,11 @@ func (s *State) Process(ctx context.Context, client processor.Client, chatID int
 		if back {
 			return nil
 		}
+		if action == undoCommand {
+			if err = s.undoLastReview(ctx, client, chatID, &cards, card); err != nil {
+				return err
+			}
+		}
 	}
 
 	if err = client.SendWithParseMode(chatID, fmt.Sprintf("Repeat finished, repeated <code>%d</code> cards", len(resp.GetCards())), tg.ModeHTML); err != nil {
@@ -339,6 +211,420 @@ func (s *State) Process(ctx context.Context, client processor.Client, chatID int
 	return nil
 }
 
+// askNote asks the phrase, cloze or grammar card, the correct answers are rated by the user.
+func askNote(
+	ctx context.Context,
+	client processor.Client,
+	chatID int64,
+	updateChan tg.UpdatesChannel,
+	card *api.Card,
+) (uint32, time.Duration, bool, error) {
+	correct, timeToAnswer, back, err := auxl.AskNote(ctx, chatID, card, client, updateChan)
+	if err != nil || back {
+		return 0, 0, back, err
+	}
+	if !correct {
+		return auxl.RatingAgain, timeToAnswer, false, nil
+	}
+
+	rating, back, err := auxl.RequestRating(ctx, chatID, client, updateChan)
+	if err != nil {
+		return 0, 0, false, fmt.Errorf("request rating: %w", err)
+	}
+
+	return rating, timeToAnswer, back, nil
+}
+
+// sendImage shows the image attached to the word as a cue, a missing image is reported and the repeat goes on.
+func sendImage(
+	ctx context.Context,
+	laleRepo *repository.LaleRepo,
+	client processor.Client,
+	chatID int64,
+	userID string,
+	word *api.WordInformation,
+) error {
+	if word.GetImageID() == "" {
+		return nil
+	}
+
+	image, err := laleRepo.Client.GetImage(ctx, &api.GetImageRequest{
+		UserID:  userID,
+		ImageID: word.GetImageID(),
+	})
+	if err != nil {
+		return client.SendWithParseMode(chatID, fmt.Sprintf("<code>grpc [GetImage] err: %s</code>", err.Error()), tg.ModeHTML)
+	}
+
+	if err = auxl.SendPhoto(chatID, client, image.GetId(), image.GetData()); err != nil {
+		return client.Send(chatID, fmt.Sprintf("sending image error: %v", err.Error()))
+	}
+
+	return nil
+}
+
+// askWords asks the user to recall every word of the card in the direction it is due in and returns the performance of the worst answer
+// along with the time spent answering, the answers aren't reported to the service.
+func askWords(
+	ctx context.Context,
+	laleRepo *repository.LaleRepo,
+	client processor.Client,
+	chatID int64,
+	updateChan tg.UpdatesChannel,
+	card cardseq.Card,
+) (uint32, time.Duration, bool, error) {
+	performance := auxl.RatingEasy
+	var (
+		timeToAnswer time.Duration
+		err          error
+	)
+
+	for _, msg := range pretty.Card(card.Card, false) {
+		if err = client.SendWithParseMode(chatID, msg, tg.ModeHTML); err != nil {
+			return 0, 0, false, err
+		}
+	}
+
+	if auxl.IsNoteCard(card.Card) {
+		return askNote(ctx, client, chatID, updateChan, card.Card)
+	}
+
+	for i, word := range card.Words {
+		var (
+			wordPerformance uint32
+			wordTime        time.Duration
+			back            bool
+		)
+		switch entity.ReviewDirection(card.Card.GetDirection()) {
+		case entity.DirectionRecognition:
+			wordPerformance, wordTime, back, err = askTranslation(ctx, client, chatID, updateChan, word)
+		case entity.DirectionListening:
+			wordPerformance, wordTime, back, err = askWordByAudio(ctx, laleRepo, client, chatID, updateChan, card.Card, word)
+		default:
+			wordPerformance, wordTime, back, err = askWord(ctx, laleRepo, client, chatID, updateChan, card.Card, word)
+		}
+		if err != nil {
+			return 0, 0, false, err
+		}
+		if back {
+			return 0, 0, true, nil
+		}
+		performance = min(performance, wordPerformance)
+		timeToAnswer += wordTime
+
+		if notes := word.GetNotes(); notes != "" {
+			if err = client.Send(chatID, "Notes: "+notes); err != nil {
+				return 0, 0, false, err
+			}
+		}
+		err = auxl.SendAudioByLanguage(ctx, chatID, client, laleRepo.Client, card.Card.GetUserID(), word.GetAudioIDs())
+		if err != nil {
+			if err = client.Send(chatID, fmt.Sprintf("sending audio error: %v", err.Error())); err != nil {
+				return 0, 0, false, err
+			}
+		}
+		if err = client.Send(chatID, "Sentences:"); err != nil {
+			return 0, 0, false, err
+		}
+
+		task := card.Sentences[word.GetWord()]
+		if task == nil {
+			return 0, 0, false, fmt.Errorf("sentences task missing for word %q", word.GetWord())
+		}
+		sentences, err := task.Get(time.Minute)
+		if err != nil {
+			if sendErr := client.Send(chatID, fmt.Sprintf("getting sentences error: %s", err.Error())); sendErr != nil {
+				return 0, 0, false, fmt.Errorf("send error [%s]: %w", err.Error(), sendErr)
+			}
+		}
+		for _, sentence := range sentences {
+			if err = client.Send(chatID, sentence); err != nil {
+				return 0, 0, false, err
+			}
+		}
+
+		if i != len(card.Words)-1 {
+			_, _, back, err = auxl.RequestInput[*bool](
+				ctx,
+				func(s *bool) bool {
+					return s != nil
+				},
+				chatID,
+				"Write <code>next</code> to repeat next word",
+				func(input string, chatID int64, client processor.Client) (*bool, error) {
+					text := strings.ToLower(strings.TrimSpace(input))
+					switch text {
+					case "":
+						return nil, client.Send(chatID, "Empty value is not allowed")
+					case "next":
+						t := true
+						return &t, nil
+					default:
+						return nil, client.SendWithParseMode(chatID, fmt.Sprintf("Invalid value <code>%s</code>, enter <code>/back</code> to go to the previous state", text), tg.ModeHTML)
+					}
+				},
+				client,
+				updateChan,
+			)
+			if err != nil {
+				return 0, 0, false, fmt.Errorf("request input: %w", err)
+			}
+			if back {
+				return 0, 0, true, nil
+			}
+		}
+	}
+
+	return performance, timeToAnswer, false, nil
+}
+
+// askWord shows the translation and the meanings of the word along with a hint and its image,
+// then asks for the word.
+func askWord(
+	ctx context.Context,
+	laleRepo *repository.LaleRepo,
+	client processor.Client,
+	chatID int64,
+	updateChan tg.UpdatesChannel,
+	card *api.Card,
+	word *api.WordInformation,
+) (uint32, time.Duration, bool, error) {
+	if err := client.Send(chatID, "Word:"); err != nil {
+		return 0, 0, false, err
+	}
+	if err := client.SendWithParseMode(chatID, pretty.Translation(word.GetTranslation()), tg.ModeHTML); err != nil {
+		return 0, 0, false, err
+	}
+	for _, meaning := range word.GetMeanings() {
+		if err := client.Send(chatID, pretty.MeaningWithoutExamples(meaning)); err != nil {
+			return 0, 0, false, err
+		}
+	}
+
+	// todo: implement hinting on the server side
+	if card.ConsecutiveCorrectAnswersNumber <= 8 {
+		hint := ""
+		switch card.ConsecutiveCorrectAnswersNumber {
+		case 0, 1, 2:
+			hint = shuffleLetters(word.GetWord())
+		default:
+			hint = maskWord(word.GetWord(), card.ConsecutiveCorrectAnswersNumber)
+		}
+		if err := client.Send(chatID, "Hint: "+hint); err != nil {
+			return 0, 0, false, err
+		}
+	}
+
+	if err := sendImage(ctx, laleRepo, client, chatID, card.GetUserID(), word); err != nil {
+		return 0, 0, false, err
+	}
+
+	return answerWord(ctx, client, chatID, updateChan, word)
+}
+
+// askWordByAudio sends only the audio of the word in one of its voices and asks for the word,
+// the words having no audio are asked by their translation.
+func askWordByAudio(
+	ctx context.Context,
+	laleRepo *repository.LaleRepo,
+	client processor.Client,
+	chatID int64,
+	updateChan tg.UpdatesChannel,
+	card *api.Card,
+	word *api.WordInformation,
+) (uint32, time.Duration, bool, error) {
+	audioIDs := word.GetAudioIDs()
+	if len(audioIDs) == 0 {
+		return askWord(ctx, laleRepo, client, chatID, updateChan, card, word)
+	}
+	voices := slices.Collect(maps.Keys(audioIDs))
+	voice := voices[rand.Intn(len(voices))]
+
+	if err := client.Send(chatID, "Listen to the Word:"); err != nil {
+		return 0, 0, false, err
+	}
+	audio, err := auxl.GetAudio(ctx, laleRepo.Client, card.GetUserID(), audioIDs[voice])
+	if err == nil {
+		err = client.SendAudio(chatID, voice, audio)
+	}
+	if err != nil {
+		if err = client.Send(chatID, fmt.Sprintf("sending audio error: %v", err.Error())); err != nil {
+			return 0, 0, false, err
+		}
+	}
+
+	return answerWord(ctx, client, chatID, updateChan, word)
+}
+
+// answerWord asks for the word and returns the performance of the answer, a wrong answer close
+// to the word gets the second attempt.
+func answerWord(
+	ctx context.Context,
+	client processor.Client,
+	chatID int64,
+	updateChan tg.UpdatesChannel,
+	word *api.WordInformation,
+) (uint32, time.Duration, bool, error) {
+	var lastIncorrectInput string
+	checkWord := func(input string, chtID int64, cl processor.Client) (*bool, error) {
+		text := strings.ToLower(strings.TrimSpace(input))
+		switch text {
+		case "/back":
+			return nil, cl.Send(chtID, "Back to previous state")
+		case "":
+			return nil, cl.Send(chtID, "Empty value is not allowed")
+		default:
+			if strings.EqualFold(text, word.GetWord()) {
+				t := true
+				return &t, nil
+			}
+			lastIncorrectInput = text
+			t := false
+			return &t, nil
+		}
+	}
+
+	const secondAttemptErrorThresholdPct = 20 // give second attempt only if error < 20%
+
+	askedAt := time.Now()
+	correct, _, back, err := auxl.RequestInput(
+		ctx,
+		func(u *bool) bool {
+			return u != nil
+		},
+		chatID,
+		"Send the Word",
+		checkWord,
+		client,
+		updateChan,
+	)
+	if err != nil {
+		return 0, 0, false, fmt.Errorf("request word: %w", err)
+	}
+	if back {
+		return 0, 0, true, nil
+	}
+	timeToAnswer := time.Since(askedAt)
+
+	if correct != nil && *correct {
+		if err = client.Send(chatID, "Correct"); err != nil {
+			return 0, 0, false, err
+		}
+		var rating uint32
+		rating, back, err = auxl.RequestRating(ctx, chatID, client, updateChan)
+		if err != nil {
+			return 0, 0, false, fmt.Errorf("request rating: %w", err)
+		}
+		return rating, timeToAnswer, back, nil
+	}
+
+	errorPct := wordErrorPercent(lastIncorrectInput, word.GetWord())
+	if errorPct >= secondAttemptErrorThresholdPct {
+		if err = client.SendWithParseMode(chatID, fmt.Sprintf("Incorrect, inspect word <code>%s</code> first", word.GetWord()), tg.ModeHTML); err != nil {
+			return 0, 0, false, err
+		}
+		return auxl.RatingAgain, timeToAnswer, false, nil
+	}
+
+	if err = client.Send(chatID, "Incorrect, try again"); err != nil {
+		return 0, 0, false, err
+	}
+	askedAt = time.Now()
+	correct, _, back, err = auxl.RequestInput(
+		ctx,
+		func(u *bool) bool {
+			return u != nil
+		},
+		chatID,
+		"Send the Word (second attempt)",
+		checkWord,
+		client,
+		updateChan,
+	)
+	if err != nil {
+		return 0, 0, false, fmt.Errorf("request word second attempt: %w", err)
+	}
+	if back {
+		return 0, 0, true, nil
+	}
+	timeToAnswer += time.Since(askedAt)
+	if correct != nil && *correct {
+		if err = client.Send(chatID, "Correct"); err != nil {
+			return 0, 0, false, err
+		}
+		return auxl.RatingHard, timeToAnswer, false, nil
+	}
+	if err = client.SendWithParseMode(chatID, fmt.Sprintf("Incorrect, inspect word <code>%s</code> first", word.GetWord()), tg.ModeHTML); err != nil {
+		return 0, 0, false, err
+	}
+
+	return auxl.RatingAgain, timeToAnswer, false, nil
+}
+
+// askTranslation shows the word and asks for any of its translations, the translation and the meanings
+// are shown after the answer.
+func askTranslation(
+	ctx context.Context,
+	client processor.Client,
+	chatID int64,
+	updateChan tg.UpdatesChannel,
+	word *api.WordInformation,
+) (uint32, time.Duration, bool, error) {
+	if err := client.SendWithParseMode(chatID, fmt.Sprintf("Word: <code>%s</code>", html.EscapeString(word.GetWord())), tg.ModeHTML); err != nil {
+		return 0, 0, false, err
+	}
+
+	askedAt := time.Now()
+	input, _, back, err := auxl.RequestInput(
+		ctx,
+		isStringNotBlank,
+		chatID,
+		"Send the Translation",
+		func(input string, _ int64, _ processor.Client) (string, error) {
+			return strings.TrimSpace(input), nil
+		},
+		client,
+		updateChan,
+	)
+	if err != nil {
+		return 0, 0, false, fmt.Errorf("request translation: %w", err)
+	}
+	if back {
+		return 0, 0, true, nil
+	}
+	timeToAnswer := time.Since(askedAt)
+
+	correct := slices.ContainsFunc(word.GetTranslation().GetTranslations(), func(translation string) bool {
+		return auxl.MatchAnswer(input, translation)
+	})
+	if !correct {
+		if err = client.Send(chatID, "Incorrect"); err != nil {
+			return 0, 0, false, err
+		}
+	}
+	if err = client.SendWithParseMode(chatID, pretty.Translation(word.GetTranslation()), tg.ModeHTML); err != nil {
+		return 0, 0, false, err
+	}
+	for _, meaning := range word.GetMeanings() {
+		if err = client.Send(chatID, pretty.MeaningWithoutExamples(meaning)); err != nil {
+			return 0, 0, false, err
+		}
+	}
+	if !correct {
+		return auxl.RatingAgain, timeToAnswer, false, nil
+	}
+
+	if err = client.Send(chatID, "Correct"); err != nil {
+		return 0, 0, false, err
+	}
+	rating, back, err := auxl.RequestRating(ctx, chatID, client, updateChan)
+	if err != nil {
+		return 0, 0, false, fmt.Errorf("request rating: %w", err)
+	}
+
+	return rating, timeToAnswer, back, nil
+}
+
 func (s *State) processFirstRepeat(
 	ctx context.Context,
 	client processor.Client,
@@ -346,7 +632,7 @@ func (s *State) processFirstRepeat(
 	updateChan tg.UpdatesChannel,
 	card cardseq.Card,
 ) (bool, error) {
-	if len(card.Words) == 0 {
+	if len(card.Words) == 0 && !auxl.IsNoteCard(card.Card) {
 		return false, client.SendWithParseMode(chatID, fmt.Sprintf("No words for Card <code>%s</code>. Inspect the card if it has no words", card.Card.GetId()), tg.ModeHTML)
 	}
 
@@ -356,6 +642,12 @@ func (s *State) processFirstRepeat(
 		}
 	}
 
+	if auxl.IsNoteCard(card.Card) {
+		if err := client.SendWithParseMode(chatID, pretty.Note(card.Card), tg.ModeHTML); err != nil {
+			return false, err
+		}
+	}
+
 	for i, word := range card.Words {
 		if err := client.Send(chatID, fmt.Sprintf("Word: %s", word.GetWord())); err != nil {
 			return false, err
@@ -369,7 +661,7 @@ func (s *State) processFirstRepeat(
 			}
 		}
 
-		err := auxl.SendAudioByLanguage(chatID, client, word.GetAudioByLanguage())
+		err := auxl.SendAudioByLanguage(ctx, chatID, client, s.laleRepo.Client, card.Card.GetUserID(), word.GetAudioIDs())
 		if err != nil {
 			if err = client.Send(chatID, fmt.Sprintf("sending audio error: %v", err.Error())); err != nil {
 				return false, err
@@ -429,13 +721,17 @@ func (s *State) processFirstRepeat(
 	}
 
 	perfReq := &api.UpdateCardPerformanceRequest{
-		UserID:         card.Card.GetUserID(),
-		CardID:         card.Card.GetId(),
-		IsInputCorrect: false,
+		UserID:      card.Card.GetUserID(),
+		CardID:      card.Card.GetId(),
+		Performance: proto.Uint32(auxl.RatingAgain),
 	}
 
-	resp, err := s.laleRepo.Client.UpdateCardPerformance(ctx, perfReq)
-	if err != nil {
+	resp, err := auxl.UpdateCardPerformance(ctx, s.laleRepo.Client, card.Card, perfReq)
+	if auxl.IsConflict(err) {
+		if err = client.Send(chatID, auxl.AnswerConflictMessage); err != nil {
+			return false, err
+		}
+	} else if err != nil {
 		if err = client.SendWithParseMode(chatID, fmt.Sprintf("<code>grpc [UpdateCardPerformance] err: %s</code>", err.Error()), tg.ModeHTML); err != nil {
 			return false, err
 		}
@@ -479,6 +775,37 @@ func (s *State) processFirstRepeat(
 	return false, nil
 }
 
+// undoCommand undoes the answer given for the last repeated card, so it's repeated again.
+const undoCommand = "/undo"
+
+func (s *State) undoLastReview(
+	ctx context.Context,
+	client processor.Client,
+	chatID int64,
+	cards *cardseq.Cards,
+	card cardseq.Card,
+) error {
+	req := &api.UndoLastReviewRequest{
+		UserID: card.Card.GetUserID(),
+		CardID: card.Card.GetId(),
+	}
+
+	restored, err := s.laleRepo.Client.UndoLastReview(ctx, req)
+	if err != nil {
+		return client.SendWithParseMode(chatID, fmt.Sprintf("<code>grpc [UndoLastReview] err: %s</code>", err.Error()), tg.ModeHTML)
+	}
+
+	cards.RepeatLast(restored)
+
+	return client.Send(chatID, "Answer undone, repeat the Card again")
+}
+
+// isProduction reports whether the card is asked by the translation of its words.
+func isProduction(card *api.Card) bool {
+	direction := entity.ReviewDirection(card.GetDirection())
+	return direction == "" || direction == entity.DirectionProduction
+}
+
 func isStringNotBlank(s string) bool {
 	return len(strings.TrimSpace(s)) != 0
 }
@@ -0,0 +1,115 @@
+package reset
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/genvmoroz/bot-engine/processor"
+	"github.com/genvmoroz/bot-engine/tg"
+	"github.com/genvmoroz/lale-tg-client/internal/auxl"
+	"github.com/genvmoroz/lale-tg-client/internal/repository"
+	"github.com/genvmoroz/lale/service/api"
+)
+
+// State returns a card to the study, even if it has been learnt.
+type State struct {
+	laleRepo *repository.LaleRepo
+}
+
+const Command = "/reset"
+
+const (
+	modeRelearn = "relearn"
+	modeForget  = "forget"
+)
+
+func NewState(laleRepo *repository.LaleRepo) *State {
+	return &State{laleRepo: laleRepo}
+}
+
+const modeMessage = `
+Send the reset mode:
+<code>relearn</code> - repeat the card starting from today
+<code>forget</code> - learn the card from scratch
+`
+
+func (s *State) Process(
+	ctx context.Context,
+	client processor.Client,
+	chatID int64,
+	updateChan tg.UpdatesChannel,
+) error {
+	cardID, userName, back, err := auxl.RequestInput(
+		ctx,
+		func(s string) bool {
+			return s != ""
+		},
+		chatID,
+		"Send the card ID to reset",
+		func(input string, _ int64, _ processor.Client) (string, error) {
+			return strings.TrimSpace(input), nil
+		},
+		client,
+		updateChan,
+	)
+	if err != nil {
+		return fmt.Errorf("request card ID: %w", err)
+	}
+	if back {
+		return nil
+	}
+
+	mode, _, back, err := auxl.RequestInput(
+		ctx,
+		func(s string) bool {
+			return s != ""
+		},
+		chatID,
+		modeMessage,
+		func(input string, chatID int64, client processor.Client) (string, error) {
+			mode := strings.ToLower(strings.TrimSpace(input))
+			if mode != modeRelearn && mode != modeForget {
+				return "", client.SendWithParseMode(chatID, fmt.Sprintf("Unknown mode <code>%s</code>", input), tg.ModeHTML)
+			}
+			return mode, nil
+		},
+		client,
+		updateChan,
+	)
+	if err != nil {
+		return fmt.Errorf("request reset mode: %w", err)
+	}
+	if back {
+		return nil
+	}
+
+	req := &api.ResetCardRequest{
+		UserID: strings.TrimSpace(userName),
+		CardID: cardID,
+		Mode:   mode,
+	}
+
+	resp, err := s.laleRepo.Client.ResetCard(ctx, req)
+	if err != nil {
+		return client.SendWithParseMode(
+			chatID,
+			fmt.Sprintf("<code>grpc [ResetCard] err: %s</code>", err.Error()),
+			tg.ModeHTML,
+		)
+	}
+
+	msg := "Card <code>%s</code> is due for repeat today"
+	if mode == modeForget {
+		msg = "Card <code>%s</code> will be learnt from scratch"
+	}
+	return client.SendWithParseMode(chatID, fmt.Sprintf(msg, resp.GetId()), tg.ModeHTML)
+}
+
+func (s *State) Command() string {
+	return Command
+}
+
+func (s *State) Description() string {
+	return "Reset a card to relearn or forget it"
+}
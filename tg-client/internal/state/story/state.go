@@ -32,13 +32,13 @@ func (s *State) Process(ctx context.Context, client processor.Client, chatID int
 		return err
 	}
 
-	language, userName, back, err := auxl.RequestInput[string](
+	input, userName, back, err := auxl.RequestInput[string](
 		ctx,
 		func(s string) bool {
 			return len(strings.TrimSpace(s)) != 0
 		},
 		chatID,
-		"Send the ISO 1 Letter Language Code of the story, ex: <code>en</code>",
+		"Send the ISO 1 Letter Language Code of the story, ex: <code>en</code>\n"+auxl.FilterHint,
 		func(input string, _ int64, _ processor.Client) (string, error) {
 			return strings.TrimSpace(input), nil
 		},
@@ -52,9 +52,11 @@ func (s *State) Process(ctx context.Context, client processor.Client, chatID int
 		return nil
 	}
 
+	language, filter := auxl.ParseLanguageAndFilter(input)
 	req := &api.GenerateStoryRequest{
 		UserID:   userName,
 		Language: language,
+		Filter:   filter,
 	}
 
 	resp, err := s.laleRepo.Client.GenerateStory(ctx, req)
@@ -0,0 +1,121 @@
+package suspend
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/genvmoroz/bot-engine/processor"
+	"github.com/genvmoroz/bot-engine/tg"
+	"github.com/genvmoroz/lale-tg-client/internal/auxl"
+	"github.com/genvmoroz/lale-tg-client/internal/repository"
+	"github.com/genvmoroz/lale/service/api"
+)
+
+type (
+	// State suspends a card, it's neither learnt nor repeated until resumed, its schedule is kept.
+	State struct {
+		laleRepo *repository.LaleRepo
+	}
+
+	// UnsuspendState resumes a suspended card.
+	UnsuspendState struct {
+		laleRepo *repository.LaleRepo
+	}
+)
+
+const (
+	Command          = "/suspend"
+	UnsuspendCommand = "/unsuspend"
+)
+
+func NewState(laleRepo *repository.LaleRepo) *State {
+	return &State{laleRepo: laleRepo}
+}
+
+func NewUnsuspendState(laleRepo *repository.LaleRepo) *UnsuspendState {
+	return &UnsuspendState{laleRepo: laleRepo}
+}
+
+func (s *State) Process(ctx context.Context, client processor.Client, chatID int64, updateChan tg.UpdatesChannel) error {
+	return process(ctx, s.laleRepo, true, client, chatID, updateChan)
+}
+
+func (s *State) Command() string {
+	return Command
+}
+
+func (s *State) Description() string {
+	return "Suspend a card keeping its schedule"
+}
+
+func (s *UnsuspendState) Process(
+	ctx context.Context,
+	client processor.Client,
+	chatID int64,
+	updateChan tg.UpdatesChannel,
+) error {
+	return process(ctx, s.laleRepo, false, client, chatID, updateChan)
+}
+
+func (s *UnsuspendState) Command() string {
+	return UnsuspendCommand
+}
+
+func (s *UnsuspendState) Description() string {
+	return "Resume a suspended card"
+}
+
+func process(
+	ctx context.Context,
+	laleRepo *repository.LaleRepo,
+	suspended bool,
+	client processor.Client,
+	chatID int64,
+	updateChan tg.UpdatesChannel,
+) error {
+	action := "suspend"
+	if !suspended {
+		action = "resume"
+	}
+
+	cardID, userName, back, err := auxl.RequestInput(
+		ctx,
+		func(s string) bool {
+			return s != ""
+		},
+		chatID,
+		fmt.Sprintf("Send the card ID to %s", action),
+		func(input string, _ int64, _ processor.Client) (string, error) {
+			return strings.TrimSpace(input), nil
+		},
+		client,
+		updateChan,
+	)
+	if err != nil {
+		return fmt.Errorf("request card ID: %w", err)
+	}
+	if back {
+		return nil
+	}
+
+	req := &api.SuspendCardRequest{
+		UserID:    strings.TrimSpace(userName),
+		CardID:    cardID,
+		Suspended: suspended,
+	}
+
+	resp, err := laleRepo.Client.SuspendCard(ctx, req)
+	if err != nil {
+		return client.SendWithParseMode(
+			chatID,
+			fmt.Sprintf("<code>grpc [SuspendCard] err: %s</code>", err.Error()),
+			tg.ModeHTML,
+		)
+	}
+
+	if resp.GetSuspended() {
+		return client.SendWithParseMode(chatID, fmt.Sprintf("Card <code>%s</code> suspended", resp.GetId()), tg.ModeHTML)
+	}
+	return client.SendWithParseMode(chatID, fmt.Sprintf("Card <code>%s</code> resumed", resp.GetId()), tg.ModeHTML)
+}
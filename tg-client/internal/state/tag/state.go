@@ -0,0 +1,121 @@
+package tag
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/genvmoroz/bot-engine/processor"
+	"github.com/genvmoroz/bot-engine/tg"
+	"github.com/genvmoroz/lale-tg-client/internal/auxl"
+	"github.com/genvmoroz/lale-tg-client/internal/repository"
+	"github.com/genvmoroz/lale/service/api"
+)
+
+// State tags a card and removes the tags from it.
+type State struct {
+	laleRepo *repository.LaleRepo
+}
+
+const Command = "/tag"
+
+func NewState(laleRepo *repository.LaleRepo) *State {
+	return &State{laleRepo: laleRepo}
+}
+
+const tagsMessage = `
+Send the tags separated by spaces, prefix a tag with <code>-</code> to remove it from the Card,
+ex: <code>travel book:Dune -work</code>
+`
+
+// tags are the tags to add to a card and to remove from it.
+type tags struct {
+	add    []string
+	remove []string
+}
+
+func (s *State) Process(ctx context.Context, client processor.Client, chatID int64, updateChan tg.UpdatesChannel) error {
+	cardID, userName, back, err := auxl.RequestInput(
+		ctx,
+		func(s string) bool {
+			return s != ""
+		},
+		chatID,
+		"Send the card ID to tag",
+		func(input string, _ int64, _ processor.Client) (string, error) {
+			return strings.TrimSpace(input), nil
+		},
+		client,
+		updateChan,
+	)
+	if err != nil {
+		return fmt.Errorf("request card ID: %w", err)
+	}
+	if back {
+		return nil
+	}
+
+	input, _, back, err := auxl.RequestInput(
+		ctx,
+		func(t *tags) bool {
+			return t != nil
+		},
+		chatID,
+		tagsMessage,
+		func(input string, chatID int64, client processor.Client) (*tags, error) {
+			var t tags
+			for _, field := range strings.Fields(input) {
+				if tag, ok := strings.CutPrefix(field, "-"); ok {
+					t.remove = append(t.remove, tag)
+					continue
+				}
+				t.add = append(t.add, field)
+			}
+			if len(t.add) == 0 && len(t.remove) == 0 {
+				return nil, client.Send(chatID, "No tags found")
+			}
+			return &t, nil
+		},
+		client,
+		updateChan,
+	)
+	if err != nil {
+		return fmt.Errorf("request tags: %w", err)
+	}
+	if back {
+		return nil
+	}
+
+	req := &api.UpdateCardTagsRequest{
+		UserID: strings.TrimSpace(userName),
+		CardID: cardID,
+		Add:    input.add,
+		Remove: input.remove,
+	}
+
+	resp, err := s.laleRepo.Client.UpdateCardTags(ctx, req)
+	if err != nil {
+		return client.SendWithParseMode(
+			chatID,
+			fmt.Sprintf("<code>grpc [UpdateCardTags] err: %s</code>", err.Error()),
+			tg.ModeHTML,
+		)
+	}
+
+	if len(resp.GetTags()) == 0 {
+		return client.SendWithParseMode(chatID, fmt.Sprintf("Card <code>%s</code> has no tags", resp.GetId()), tg.ModeHTML)
+	}
+	return client.SendWithParseMode(
+		chatID,
+		fmt.Sprintf("Card <code>%s</code> tags: <code>%s</code>", resp.GetId(), strings.Join(resp.GetTags(), " ")),
+		tg.ModeHTML,
+	)
+}
+
+func (s *State) Command() string {
+	return Command
+}
+
+func (s *State) Description() string {
+	return "Add tags to a card or remove them"
+}
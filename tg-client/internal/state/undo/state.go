@@ -0,0 +1,94 @@
+package undo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/genvmoroz/bot-engine/processor"
+	"github.com/genvmoroz/bot-engine/tg"
+	"github.com/genvmoroz/lale-tg-client/internal/repository"
+	"github.com/genvmoroz/lale/service/api"
+)
+
+type State struct {
+	laleRepo *repository.LaleRepo
+}
+
+const Command = "/undo"
+
+func NewState(laleRepo *repository.LaleRepo) *State {
+	return &State{laleRepo: laleRepo}
+}
+
+const initialMessage = `
+Undo last review
+`
+
+const promptCardID = "Send the card ID to undo its last review, or <code>last</code> to undo your last review. " +
+	"The card gets the schedule it had before the review."
+
+func (s *State) Process(ctx context.Context, client processor.Client, chatID int64, updateChan tg.UpdatesChannel) error {
+	if err := client.Send(chatID, initialMessage); err != nil {
+		return err
+	}
+
+	var req *api.UndoLastReviewRequest
+
+	for req == nil {
+		if err := client.SendWithParseMode(chatID, promptCardID, tg.ModeHTML); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case update, ok := <-updateChan:
+			if !ok {
+				return errors.New("updateChan is closed")
+			}
+			raw := strings.TrimSpace(update.Message.Text)
+			switch strings.ToLower(raw) {
+			case "/back":
+				return client.Send(chatID, "Back to previous state")
+			case "":
+				if err := client.Send(chatID, "Empty value is not allowed"); err != nil {
+					return err
+				}
+			case "last":
+				req = &api.UndoLastReviewRequest{
+					UserID: strings.TrimSpace(update.Message.From.UserName),
+				}
+			default:
+				req = &api.UndoLastReviewRequest{
+					UserID: strings.TrimSpace(update.Message.From.UserName),
+					CardID: raw,
+				}
+			}
+		}
+	}
+
+	resp, err := s.laleRepo.Client.UndoLastReview(ctx, req)
+	if err != nil {
+		return client.SendWithParseMode(
+			chatID,
+			fmt.Sprintf("<code>grpc [UndoLastReview] err: %s</code>", err.Error()),
+			tg.ModeHTML,
+		)
+	}
+
+	return client.SendWithParseMode(
+		chatID,
+		fmt.Sprintf("Last review of card <code>%s</code> undone, next due date: %s", resp.GetId(), resp.GetNextDueDate().AsTime()),
+		tg.ModeHTML,
+	)
+}
+
+func (s *State) Command() string {
+	return Command
+}
+
+func (s *State) Description() string {
+	return "Undo last review"
+}
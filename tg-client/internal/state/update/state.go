@@ -10,8 +10,10 @@ import (
 	"github.com/genvmoroz/bot-engine/processor"
 	"github.com/genvmoroz/bot-engine/tg"
 	"github.com/genvmoroz/lale-tg-client/internal/auxl"
+	"github.com/genvmoroz/lale-tg-client/internal/pretty"
 	"github.com/genvmoroz/lale-tg-client/internal/repository"
 	"github.com/genvmoroz/lale/service/api"
+	"github.com/genvmoroz/lale/service/pkg/entity"
 )
 
 type State struct {
@@ -61,9 +63,27 @@ func (s *State) Process(ctx context.Context, client processor.Client, chatID int
 					return err
 				}
 			default:
+				userID := strings.TrimSpace(update.Message.From.UserName)
+				card, err := auxl.GetCard(ctx, s.laleRepo.Client, userID, text)
+				if err != nil {
+					return client.SendWithParseMode(chatID, fmt.Sprintf("<code>grpc [GetCardsForCram] err: %s</code>", err.Error()), tg.ModeHTML)
+				}
+				if card == nil {
+					if err = client.SendWithParseMode(chatID, fmt.Sprintf("Card <code>%s</code> not found", text), tg.ModeHTML); err != nil {
+						return err
+					}
+					continue
+				}
+				for _, msg := range pretty.Card(card, true) {
+					if err = client.SendWithParseMode(chatID, msg, tg.ModeHTML); err != nil {
+						return err
+					}
+				}
+				// the version of the card the user sees, the update is rejected if the card is changed meanwhile
 				req = &api.UpdateCardRequest{
-					UserID: strings.TrimSpace(update.Message.From.UserName),
-					CardID: text,
+					UserID:  userID,
+					CardID:  card.GetId(),
+					Version: card.GetVersion(),
 				}
 			}
 		}
@@ -89,6 +109,25 @@ func (s *State) Process(ctx context.Context, client processor.Client, chatID int
 		return nil
 	}
 
+	noteType, back, err := auxl.RequestNoteType(ctx, chatID, client, updateChan)
+	if err != nil {
+		return err
+	}
+	if back {
+		return nil
+	}
+	req.Type = string(noteType)
+	if noteType != entity.NoteTypeWords {
+		req.Note, _, back, err = auxl.RequestNote(ctx, chatID, noteType, client, updateChan)
+		if err != nil {
+			return err
+		}
+		if back {
+			return nil
+		}
+		return s.updateCard(ctx, client, chatID, req)
+	}
+
 	wordsList, _, back, err := auxl.RequestInput[[][2]string](
 		ctx,
 		func(s [][2]string) bool {
@@ -136,18 +175,27 @@ func (s *State) Process(ctx context.Context, client processor.Client, chatID int
 		})
 	}
 
-	resp, err := s.laleRepo.Client.UpdateCard(ctx, req)
+	back, err = auxl.RequestWordAttachments(ctx, chatID, req.GetUserID(), req.WordInformationList, s.laleRepo.Client, client, updateChan)
 	if err != nil {
-		if err = client.SendWithParseMode(chatID, fmt.Sprintf("<code>grpc [UpdateCard] err: %s</code>", err.Error()), tg.ModeHTML); err != nil {
-			return err
-		}
+		return fmt.Errorf("request word attachments: %w", err)
+	}
+	if back {
+		return nil
 	}
 
-	if err = client.SendWithParseMode(chatID, fmt.Sprintf("Card with ID <code>%s</code> updated", resp.GetId()), tg.ModeHTML); err != nil {
-		return err
+	return s.updateCard(ctx, client, chatID, req)
+}
+
+func (s *State) updateCard(ctx context.Context, client processor.Client, chatID int64, req *api.UpdateCardRequest) error {
+	resp, err := s.laleRepo.Client.UpdateCard(ctx, req)
+	if auxl.IsConflict(err) {
+		return client.SendWithParseMode(chatID, fmt.Sprintf("Card <code>%s</code> has been changed meanwhile, update it again", req.GetCardID()), tg.ModeHTML)
+	}
+	if err != nil {
+		return client.SendWithParseMode(chatID, fmt.Sprintf("<code>grpc [UpdateCard] err: %s</code>", err.Error()), tg.ModeHTML)
 	}
 
-	return nil
+	return client.SendWithParseMode(chatID, fmt.Sprintf("Card with ID <code>%s</code> updated", resp.GetId()), tg.ModeHTML)
 }
 
 func (s *State) Command() string {
@@ -51,6 +51,8 @@ func (t transformer) ToCoreWordInformation(info *api.WordInformation) entity.Wor
 		Origin:      info.Origin,
 		Phonetics:   t.toCorePhonetics(info.Phonetics),
 		Meanings:    t.toCoreMeanings(info.Meanings),
+		Notes:       info.GetNotes(),
+		ImageID:     info.GetImageID(),
 	}
 }
 